@@ -605,7 +605,6 @@ func New(ctx context.Context, log *zap.Logger, cfg *viper.Viper) (*Server, error
 	server.netmapProcessor, err = netmap.New(&netmap.Params{
 		Log:              log,
 		PoolSize:         cfg.GetInt("workers.netmap"),
-		NetmapContract:   server.contracts.netmap,
 		NetmapClient:     server.netmapClient,
 		EpochTimer:       server,
 		EpochState:       server,
@@ -629,6 +628,8 @@ func New(ctx context.Context, log *zap.Logger, cfg *viper.Viper) (*Server, error
 		return nil, err
 	}
 
+	server.registerIOCloser(server.netmapProcessor)
+
 	err = bindMorphProcessor(server.netmapProcessor, server)
 	if err != nil {
 		return nil, err
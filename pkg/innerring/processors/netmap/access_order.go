@@ -0,0 +1,47 @@
+package netmap
+
+import (
+	"container/list"
+)
+
+// accessOrder tracks the order of access to the keys,
+// so the least recently accessed key can be evicted.
+type accessOrder struct {
+	list *list.List
+
+	elems map[string]*list.Element
+}
+
+func newAccessOrder() *accessOrder {
+	return &accessOrder{
+		list:  list.New(),
+		elems: make(map[string]*list.Element),
+	}
+}
+
+// touch marks the key as the most recently accessed one.
+func (o *accessOrder) touch(key string) {
+	if e, ok := o.elems[key]; ok {
+		o.list.MoveToBack(e)
+		return
+	}
+
+	o.elems[key] = o.list.PushBack(key)
+}
+
+func (o *accessOrder) remove(key string) {
+	if e, ok := o.elems[key]; ok {
+		o.list.Remove(e)
+		delete(o.elems, key)
+	}
+}
+
+// oldest returns the least recently accessed key.
+func (o *accessOrder) oldest() (string, bool) {
+	e := o.list.Front()
+	if e == nil {
+		return "", false
+	}
+
+	return e.Value.(string), true
+}
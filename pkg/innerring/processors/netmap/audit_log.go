@@ -0,0 +1,85 @@
+package netmap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+)
+
+// AdmissionDecision describes the processing of the network map candidate
+// by the NodeValidator.
+type AdmissionDecision struct {
+	// Time of the decision.
+	Time time.Time
+
+	// Information about the candidate after the validation
+	// (see Mutation).
+	Candidate *netmap.NodeInfo
+
+	// Description of the NodeValidator (see DescribeValidator).
+	Validator string
+
+	// Error returned by the NodeValidator, nil if the candidate is accepted.
+	ValidationError error
+
+	// Outcomes of the validators wrapped by the NodeValidator, empty if
+	// the NodeValidator does not implement NodeReporter.
+	Outcomes []ValidatorOutcome
+
+	// Changes of the candidate made by the NodeValidator.
+	Mutation NodeInfoDiff
+
+	// True if the approval of the candidate has been sent to the network
+	// map contract. Accepted candidates that are already known are not
+	// approved again.
+	Approved bool
+
+	// Error of the approval, nil if the approval is not sent or succeeded.
+	ApprovalError error
+
+	// True if the candidate has been approved bypassing the NodeValidator
+	// (see Processor.ForceAddPeer). Validator and Mutation are empty then.
+	Forced bool
+}
+
+// ValidatorOutcome describes the result of the single validator wrapped
+// by the NodeValidator.
+type ValidatorOutcome struct {
+	// Description of the validator (see DescribeValidator).
+	Validator string
+
+	// Error returned by the validator, nil if the candidate is accepted.
+	Error error
+}
+
+// Accepted returns true if the candidate passed the NodeValidator.
+func (d AdmissionDecision) Accepted() bool {
+	return d.ValidationError == nil
+}
+
+// AuditLog is an interface of the durable log of the admission decisions.
+type AuditLog interface {
+	// Log is called with each decision made for the network map candidate
+	// which information has been parsed, including the forced admissions.
+	// Candidates are not processed in non-alphabet mode, so no decisions
+	// are made then.
+	//
+	// Log is called synchronously from the event handler,
+	// so it should not block for long.
+	Log(AdmissionDecision)
+}
+
+type noopAuditLog struct{}
+
+func (noopAuditLog) Log(AdmissionDecision) {}
+
+// DescribeValidator returns the result of String method of the NodeValidator
+// if it is implemented, type name otherwise.
+func DescribeValidator(v NodeValidator) string {
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,179 @@
+package netmap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+type memAuditLog []AdmissionDecision
+
+func (l *memAuditLog) Log(d AdmissionDecision) {
+	*l = append(*l, d)
+}
+
+type stringValidator struct {
+	nodeValidatorFunc
+}
+
+func (stringValidator) String() string {
+	return "test validator"
+}
+
+// testReporter reports the outcomes and returns the last error.
+type testReporter []ValidatorOutcome
+
+func (r testReporter) String() string {
+	return "test validator"
+}
+
+func (r testReporter) VerifyAndUpdate(n *netmap.NodeInfo) error {
+	_, err := r.VerifyAndReport(n)
+	return err
+}
+
+func (r testReporter) VerifyAndReport(*netmap.NodeInfo) ([]ValidatorOutcome, error) {
+	return r, r[len(r)-1].Error
+}
+
+func TestProcessor_AuditLog(t *testing.T) {
+	var (
+		epoch  = testEpochState(1)
+		cli    = new(testNetmapClient)
+		audits memAuditLog
+		badKey = genKey(t).PublicKey().Bytes()
+		errBad = errors.New("bad candidate")
+	)
+
+	validator := stringValidator{func(n *netmap.NodeInfo) error {
+		if bytes.Equal(n.PublicKey(), badKey) {
+			return fmt.Errorf("validation: %w", errBad)
+		}
+
+		n.SetAttributes(append(n.Attributes(), nodeAttribute("Verified", "true"))...)
+
+		return nil
+	}}
+
+	np := &Processor{
+		log:            test.NewLogger(false),
+		epochState:     &epoch,
+		alphabetState:  testAlphabetState(true),
+		netmapClient:   cli,
+		netmapSnapshot: newCleanupTable(true, 1),
+		nodeValidator:  validator,
+		rejectionSink:  noopRejectionSink{},
+		auditLog:       &audits,
+		now:            time.Now,
+	}
+
+	addPeer := func(t *testing.T, key []byte) AdmissionDecision {
+		info := netmap.NewNodeInfo()
+		info.SetPublicKey(key)
+
+		data, err := info.Marshal()
+		require.NoError(t, err)
+
+		start := time.Now()
+
+		np.processAddPeer(data)
+
+		require.NotEmpty(t, audits)
+
+		d := audits[len(audits)-1]
+		require.Equal(t, key, d.Candidate.PublicKey())
+		require.Equal(t, "test validator", d.Validator)
+		require.False(t, d.Time.Before(start))
+		require.False(t, d.Time.After(time.Now()))
+
+		return d
+	}
+
+	key := genKey(t).PublicKey().Bytes()
+
+	t.Run("accepted", func(t *testing.T) {
+		d := addPeer(t, key)
+
+		require.True(t, d.Accepted())
+		require.NoError(t, d.ValidationError)
+		require.Equal(t, []string{"Verified"}, d.Mutation.AddedAttributes)
+		require.True(t, d.Approved)
+		require.NoError(t, d.ApprovalError)
+		require.Len(t, cli.added, 1)
+	})
+
+	t.Run("accepted known", func(t *testing.T) {
+		d := addPeer(t, key)
+
+		require.True(t, d.Accepted())
+		require.False(t, d.Approved)
+		require.Len(t, cli.added, 1)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		d := addPeer(t, badKey)
+
+		require.False(t, d.Accepted())
+		require.True(t, errors.Is(d.ValidationError, errBad))
+		require.True(t, d.Mutation.Empty())
+		require.False(t, d.Approved)
+		require.Len(t, cli.added, 1)
+	})
+
+	t.Run("approval failure", func(t *testing.T) {
+		testErr := errors.New("test error")
+		cli.err = testErr
+
+		defer func() { cli.err = nil }()
+
+		d := addPeer(t, genKey(t).PublicKey().Bytes())
+
+		require.True(t, d.Accepted())
+		require.True(t, d.Approved)
+		require.True(t, errors.Is(d.ApprovalError, testErr))
+	})
+
+	t.Run("non alphabet", func(t *testing.T) {
+		np.alphabetState = testAlphabetState(false)
+		defer func() { np.alphabetState = testAlphabetState(true) }()
+
+		ln := len(audits)
+
+		info := newNodeInfo(genKey(t).PublicKey())
+
+		data, err := info.Marshal()
+		require.NoError(t, err)
+
+		np.processAddPeer(data)
+
+		require.Len(t, audits, ln)
+	})
+
+	t.Run("validator outcomes", func(t *testing.T) {
+		np.nodeValidator = testReporter{
+			{Validator: "first"},
+			{Validator: "second", Error: errBad},
+		}
+		defer func() { np.nodeValidator = validator }()
+
+		d := addPeer(t, genKey(t).PublicKey().Bytes())
+
+		require.False(t, d.Accepted())
+		require.True(t, errors.Is(d.ValidationError, errBad))
+		require.Equal(t, []ValidatorOutcome{
+			{Validator: "first"},
+			{Validator: "second", Error: errBad},
+		}, d.Outcomes)
+	})
+
+	t.Run("default type name", func(t *testing.T) {
+		require.Equal(t, "netmap.nodeValidatorFunc",
+			DescribeValidator(nodeValidatorFunc(func(*netmap.NodeInfo) error { return nil })))
+	})
+}
@@ -0,0 +1,51 @@
+package netmap
+
+// CleanupCoordinator is an interface of the mechanism which coordinates
+// the network map cleanup between the alphabet nodes (e.g. each node
+// publishes its candidates to the shared storage), so the nodes absent
+// in the stale local view of a single alphabet node are not removed.
+type CleanupCoordinator interface {
+	// AgreedCandidates publishes the local cleanup candidates of the epoch
+	// and returns the candidates agreed by the quorum of the alphabet nodes.
+	// Candidates are hex-encoded public keys of the nodes.
+	//
+	// AgreedCandidates is called synchronously from the cleanup routine,
+	// so it should not block for long.
+	AgreedCandidates(epoch uint64, candidates []string) ([]string, error)
+}
+
+// agreedCleanupCandidates returns the local cleanup candidates of the epoch
+// agreed by the CleanupCoordinator in the order of the local view. Returns
+// all the candidates if coordinator is not set.
+//
+// All the local candidates are published to the coordinator, and the limit
+// of the candidates per iteration is applied to the agreed ones only, so
+// the disagreed candidates do not hold up the agreed ones.
+func (np *Processor) agreedCleanupCandidates(epoch uint64) ([]string, error) {
+	candidates := np.netmapSnapshot.allRemoveCandidates(epoch)
+
+	if np.cleanupCoordinator == nil || len(candidates) == 0 {
+		return np.netmapSnapshot.limitCandidates(candidates), nil
+	}
+
+	agreed, err := np.cleanupCoordinator.AgreedCandidates(epoch, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	mAgreed := make(map[string]struct{}, len(agreed))
+	for i := range agreed {
+		mAgreed[agreed[i]] = struct{}{}
+	}
+
+	res := candidates[:0]
+
+	for i := range candidates {
+		// candidates missing in the local view are not voted
+		if _, ok := mAgreed[candidates[i]]; ok {
+			res = append(res, candidates[i])
+		}
+	}
+
+	return np.netmapSnapshot.limitCandidates(res), nil
+}
@@ -0,0 +1,151 @@
+package netmap
+
+import (
+	"encoding/hex"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+// testCleanupBoard agrees on the candidates published by
+// at least quorum alphabet nodes including the local one.
+type testCleanupBoard struct {
+	quorum int
+
+	// candidates published by the other alphabet nodes
+	remote [][]string
+
+	err error
+}
+
+func (b *testCleanupBoard) AgreedCandidates(_ uint64, candidates []string) ([]string, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	var (
+		res    []string
+		counts = make(map[string]int)
+	)
+
+	for _, set := range append(b.remote, candidates) {
+		for _, s := range set {
+			if counts[s]++; counts[s] == b.quorum {
+				res = append(res, s)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func TestProcessor_CleanupCoordinator(t *testing.T) {
+	const threshold = 1
+
+	keys := make([]string, 5)
+	for i := range keys {
+		keys[i] = hex.EncodeToString(genKey(t).PublicKey().Bytes())
+	}
+
+	newProcessor := func(board CleanupCoordinator) (*Processor, *testNetmapClient) {
+		cli := new(testNetmapClient)
+
+		np := &Processor{
+			log:                test.NewLogger(false),
+			alphabetState:      testAlphabetState(true),
+			netmapClient:       cli,
+			netmapSnapshot:     newCleanupTable(true, threshold),
+			cleanupCoordinator: board,
+		}
+
+		// local view is stale for all the nodes except the last one
+		for _, s := range keys[:4] {
+			np.netmapSnapshot.touch(s, 1)
+		}
+
+		return np, cli
+	}
+
+	updated := func(cli *testNetmapClient) []string {
+		res := make([]string, len(cli.updated))
+		for i := range cli.updated {
+			res[i] = hex.EncodeToString(cli.updated[i])
+		}
+
+		return res
+	}
+
+	const epoch = 1 + threshold + 1
+
+	t.Run("divergent candidates", func(t *testing.T) {
+		np, cli := newProcessor(&testCleanupBoard{
+			quorum: 2,
+			remote: [][]string{
+				{keys[0], keys[1], keys[4]},
+				{keys[0], keys[2]},
+			},
+		})
+
+		np.processNetmapCleanupTick(epoch)
+
+		require.ElementsMatch(t, keys[:3], updated(cli))
+
+		// only voted nodes are flagged
+		for _, s := range keys[:3] {
+			require.False(t, np.netmapSnapshot.active(s))
+		}
+
+		require.True(t, np.netmapSnapshot.active(keys[3]))
+	})
+
+	t.Run("no agreement", func(t *testing.T) {
+		np, cli := newProcessor(&testCleanupBoard{
+			quorum: 3,
+			remote: [][]string{{keys[0]}, {keys[1]}},
+		})
+
+		np.processNetmapCleanupTick(epoch)
+
+		require.Empty(t, cli.updated)
+	})
+
+	t.Run("coordinator failure", func(t *testing.T) {
+		np, cli := newProcessor(&testCleanupBoard{err: errors.New("test error")})
+
+		np.processNetmapCleanupTick(epoch)
+
+		require.Empty(t, cli.updated)
+
+		for _, s := range keys[:4] {
+			require.True(t, np.netmapSnapshot.active(s))
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		local := append([]string(nil), keys[:4]...)
+		sort.Strings(local)
+
+		// the first local candidate is not agreed
+		np, cli := newProcessor(&testCleanupBoard{
+			quorum: 2,
+			remote: [][]string{{local[1], local[2]}},
+		})
+
+		np.netmapSnapshot.limit = 1
+
+		np.processNetmapCleanupTick(epoch)
+
+		require.Equal(t, local[1:2], updated(cli))
+	})
+
+	t.Run("without coordinator", func(t *testing.T) {
+		np, cli := newProcessor(nil)
+
+		np.processNetmapCleanupTick(epoch)
+
+		require.ElementsMatch(t, keys[:4], updated(cli))
+	})
+}
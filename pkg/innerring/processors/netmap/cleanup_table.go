@@ -2,6 +2,7 @@ package netmap
 
 import (
 	"encoding/hex"
+	"sort"
 	"sync"
 
 	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
@@ -13,14 +14,45 @@ type (
 		enabled    bool
 		threshold  uint64
 		lastAccess map[string]epochStamp
+
+		// max number of remove candidates per iteration,
+		// not limited if not positive
+		limit int
+
+		// number of epochs the nodes under maintenance are not
+		// considered as remove candidates
+		maintenanceGrace uint64
+
+		// max number of the tracked nodes absent in the network map,
+		// not limited if not positive
+		maxEntries int
+		// order of access to the tracked nodes absent in the network map,
+		// nil if the number of the tracked nodes is not limited
+		order *accessOrder
+		// called on each evicted node, may be nil
+		onEvict func(string)
 	}
 
 	epochStamp struct {
 		epoch      uint64
 		removeFlag bool
+
+		maintenance      bool
+		maintenanceEpoch uint64 // epoch when maintenance was started
+
+		// node is presented in the last network map snapshot,
+		// such nodes are never evicted
+		inNetmap bool
+
+		// node is restored from the state store and has not been
+		// accessed since, so it may have left the network map
+		restored bool
 	}
 )
 
+// name of the cleanup table for the metrics
+const cleanupTableStructure = "cleanup_table"
+
 func newCleanupTable(enabled bool, threshold uint64) cleanupTable {
 	return cleanupTable{
 		RWMutex:    new(sync.RWMutex),
@@ -30,6 +62,61 @@ func newCleanupTable(enabled bool, threshold uint64) cleanupTable {
 	}
 }
 
+// Limit the number of the tracked nodes absent in the network map (e.g.
+// the candidates). Least recently accessed of them are evicted when the
+// limit is exceeded. Nodes presented in the network map are not evicted,
+// so they are always voted to be removed by the cleanup routine.
+func (c *cleanupTable) setMaxEntries(max int, onEvict func(string)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.maxEntries = max
+	c.onEvict = onEvict
+	c.order = nil
+
+	if max <= 0 {
+		return
+	}
+
+	c.order = newAccessOrder()
+
+	for keyString, access := range c.lastAccess {
+		if !access.inNetmap {
+			c.order.touch(keyString)
+		}
+	}
+
+	c.evict()
+}
+
+// Mark node as the most recently accessed one and evict the least
+// recently accessed nodes over the limit. Nodes presented in the network
+// map are not tracked. Must be called under the lock.
+func (c *cleanupTable) accessed(keyString string) {
+	if c.order == nil || c.lastAccess[keyString].inNetmap {
+		return
+	}
+
+	c.order.touch(keyString)
+	c.evict()
+}
+
+func (c *cleanupTable) evict() {
+	for c.order.list.Len() > c.maxEntries {
+		keyString, ok := c.order.oldest()
+		if !ok {
+			return
+		}
+
+		c.order.remove(keyString)
+		delete(c.lastAccess, keyString)
+
+		if c.onEvict != nil {
+			c.onEvict(keyString)
+		}
+	}
+}
+
 // Update cleanup table based on on-chain information about netmap.
 func (c *cleanupTable) update(snapshot *netmap.Netmap, now uint64) {
 	c.Lock()
@@ -42,13 +129,20 @@ func (c *cleanupTable) update(snapshot *netmap.Netmap, now uint64) {
 		keyString := hex.EncodeToString(snapshot.Nodes[i].PublicKey())
 		if access, ok := c.lastAccess[keyString]; ok {
 			access.removeFlag = false // reset remove Flag on each Update
+			access.inNetmap = true
+			access.restored = false
 			newMap[keyString] = access
 		} else {
-			newMap[keyString] = epochStamp{epoch: now}
+			newMap[keyString] = epochStamp{epoch: now, inNetmap: true}
 		}
 	}
 
 	c.lastAccess = newMap
+
+	// all the tracked nodes are in the network map now
+	if c.order != nil {
+		c.order = newAccessOrder()
+	}
 }
 
 func (c *cleanupTable) touch(keyString string, now uint64) bool {
@@ -56,15 +150,19 @@ func (c *cleanupTable) touch(keyString string, now uint64) bool {
 	defer c.Unlock()
 
 	access, ok := c.lastAccess[keyString]
-	result := !access.removeFlag && ok
+	result := !access.removeFlag && !access.restored && ok
 
-	access.removeFlag = false // reset remove flag on each touch
+	access.removeFlag = false  // reset remove flag on each touch
+	access.maintenance = false // node is back from maintenance
+	access.restored = false
 	if now > access.epoch {
 		access.epoch = now
 	}
 
 	c.lastAccess[keyString] = access
 
+	c.accessed(keyString)
+
 	return result
 }
 
@@ -78,20 +176,178 @@ func (c *cleanupTable) flag(keyString string) {
 	}
 }
 
-func (c *cleanupTable) forEachRemoveCandidate(epoch uint64, f func(string) error) error {
+// Mark node as the one under maintenance since the specified epoch.
+// Returns false if node is not presented in the table.
+func (c *cleanupTable) maintain(keyString string, now uint64) bool {
 	c.Lock()
 	defer c.Unlock()
 
+	access, ok := c.lastAccess[keyString]
+	if !ok {
+		return false
+	}
+
+	access.maintenance = true
+	access.maintenanceEpoch = now
+	c.lastAccess[keyString] = access
+
+	return true
+}
+
+// Check if node is presented in the table.
+func (c *cleanupTable) contains(keyString string) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	_, ok := c.lastAccess[keyString]
+
+	return ok
+}
+
+// Return the epoch of the last activity of the node. Returns false
+// if node is not presented in the table.
+func (c *cleanupTable) lastEpoch(keyString string) (uint64, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	access, ok := c.lastAccess[keyString]
+
+	return access.epoch, ok
+}
+
+// Check if node is presented in the table and is not flagged to be removed.
+func (c *cleanupTable) active(keyString string) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	access, ok := c.lastAccess[keyString]
+
+	return ok && !access.removeFlag
+}
+
+// Check if node is presented in the table, is not flagged to be removed
+// and is not restored from the state store without the access since.
+func (c *cleanupTable) approved(keyString string) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	access, ok := c.lastAccess[keyString]
+
+	return ok && !access.removeFlag && !access.restored
+}
+
+// Iterate over remove candidates starting from the longest absent ones.
+// Function is called with the key of the node and the epoch of its last
+// activity. Number of candidates is limited, the rest are processed in
+// the next iterations.
+func (c *cleanupTable) forEachRemoveCandidate(epoch uint64, f func(string, uint64) error) error {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, keyString := range c.limitCandidates(c.removeCandidatesLocked(epoch)) {
+		access := c.lastAccess[keyString]
+		access.removeFlag = true // set remove flag
+		c.lastAccess[keyString] = access
+
+		if err := f(keyString, access.epoch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Return remove candidates in the order of forEachRemoveCandidate
+// without flagging them.
+func (c *cleanupTable) removeCandidates(epoch uint64) []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.limitCandidates(c.removeCandidatesLocked(epoch))
+}
+
+// Return all remove candidates in the order of forEachRemoveCandidate
+// ignoring the limit.
+func (c *cleanupTable) allRemoveCandidates(epoch uint64) []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.removeCandidatesLocked(epoch)
+}
+
+// limitCandidates cuts candidates to the limit of the candidates
+// processed per iteration.
+func (c *cleanupTable) limitCandidates(candidates []string) []string {
+	if c.limit > 0 && len(candidates) > c.limit {
+		return candidates[:c.limit]
+	}
+
+	return candidates
+}
+
+func (c *cleanupTable) removeCandidatesLocked(epoch uint64) []string {
+	candidates := make([]string, 0)
+
 	for keyString, access := range c.lastAccess {
+		if access.maintenance && epoch-access.maintenanceEpoch <= c.maintenanceGrace {
+			continue
+		}
+
 		if epoch-access.epoch > c.threshold {
-			access.removeFlag = true // set remove flag
-			c.lastAccess[keyString] = access
+			candidates = append(candidates, keyString)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		ei, ej := c.lastAccess[candidates[i]].epoch, c.lastAccess[candidates[j]].epoch
+		if ei != ej {
+			return ei < ej
+		}
+
+		return candidates[i] < candidates[j]
+	})
+
+	return candidates
+}
+
+// Reconcile cleanup table with on-chain information about netmap. Returns
+// keys of the nodes that were missing in the table and keys of the nodes
+// that are no longer presented in the netmap.
+func (c *cleanupTable) reconcile(snapshot *netmap.Netmap, now uint64) (added, removed []string) {
+	c.Lock()
+	defer c.Unlock()
 
-			if err := f(keyString); err != nil {
-				return err
+	actual := make(map[string]struct{}, len(snapshot.Nodes))
+
+	for i := range snapshot.Nodes {
+		keyString := hex.EncodeToString(snapshot.Nodes[i].PublicKey())
+		actual[keyString] = struct{}{}
+
+		access, ok := c.lastAccess[keyString]
+		if !ok {
+			access.epoch = now
+			added = append(added, keyString)
+		}
+
+		access.inNetmap = true
+		access.restored = false
+		c.lastAccess[keyString] = access
+
+		if c.order != nil {
+			c.order.remove(keyString)
+		}
+	}
+
+	for keyString := range c.lastAccess {
+		if _, ok := actual[keyString]; !ok {
+			delete(c.lastAccess, keyString)
+			removed = append(removed, keyString)
+
+			if c.order != nil {
+				c.order.remove(keyString)
 			}
 		}
 	}
 
-	return nil
+	return added, removed
 }
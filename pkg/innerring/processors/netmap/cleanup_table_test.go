@@ -2,10 +2,13 @@ package netmap
 
 import (
 	"encoding/hex"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
 	"github.com/stretchr/testify/require"
 )
 
@@ -85,7 +88,7 @@ func TestCleanupTable(t *testing.T) {
 		t.Run("no nodes to remove", func(t *testing.T) {
 			cnt := 0
 			require.NoError(t,
-				c.forEachRemoveCandidate(2, func(_ string) error {
+				c.forEachRemoveCandidate(2, func(string, uint64) error {
 					cnt++
 					return nil
 				}))
@@ -95,7 +98,7 @@ func TestCleanupTable(t *testing.T) {
 		t.Run("all nodes to remove", func(t *testing.T) {
 			cnt := 0
 			require.NoError(t,
-				c.forEachRemoveCandidate(4, func(s string) error {
+				c.forEachRemoveCandidate(4, func(s string, _ uint64) error {
 					cnt++
 					_, ok := mapInfos[s]
 					require.True(t, ok)
@@ -111,7 +114,7 @@ func TestCleanupTable(t *testing.T) {
 			require.False(t, c.touch(key, 4)) // one node was updated
 
 			require.NoError(t,
-				c.forEachRemoveCandidate(4, func(s string) error {
+				c.forEachRemoveCandidate(4, func(s string, _ uint64) error {
 					cnt++
 					require.NotEqual(t, s, key)
 					return nil
@@ -121,7 +124,208 @@ func TestCleanupTable(t *testing.T) {
 	})
 }
 
+func TestCleanupTable_Priority(t *testing.T) {
+	const (
+		nodes = 10
+		limit = 3
+	)
+
+	c := newCleanupTable(true, 1)
+	c.limit = limit
+
+	// node i was last seen in epoch i+1
+	keys := make([]string, nodes)
+	for i := range keys {
+		keys[i] = hex.EncodeToString(genKey(t).PublicKey().Bytes())
+		c.lastAccess[keys[i]] = epochStamp{epoch: uint64(i + 1)}
+	}
+
+	// only the last node is not stale
+	const epoch = nodes + 1
+
+	var visited []string
+
+	iterate := func() []string {
+		var res []string
+
+		require.NoError(t, c.forEachRemoveCandidate(epoch, func(s string, _ uint64) error {
+			res = append(res, s)
+			return nil
+		}))
+
+		visited = append(visited, res...)
+
+		return res
+	}
+
+	// longest absent nodes go first
+	require.Equal(t, keys[:limit], iterate())
+
+	for i := 0; i < limit; i++ {
+		require.True(t, c.lastAccess[keys[i]].removeFlag)
+	}
+
+	require.False(t, c.lastAccess[keys[limit]].removeFlag)
+
+	// candidates are re-voted until removed from the netmap
+	for i := 0; i < limit; i++ {
+		delete(c.lastAccess, keys[i])
+	}
+
+	require.Equal(t, keys[limit:2*limit], iterate())
+
+	for i := limit; i < 2*limit; i++ {
+		delete(c.lastAccess, keys[i])
+	}
+
+	require.Equal(t, keys[2*limit:nodes-1], iterate())
+	require.Equal(t, keys[:nodes-1], visited)
+}
+
 func newNodeInfo(key *keys.PublicKey) (n netmap.NodeInfo) {
 	n.SetPublicKey(key.Bytes())
 	return n
 }
+
+func TestCleanupTable_MaxEntries(t *testing.T) {
+	const max = 100
+
+	var evicted []string
+
+	c := newCleanupTable(true, 1)
+	c.setMaxEntries(max, func(keyString string) {
+		evicted = append(evicted, keyString)
+	})
+
+	requireBounded := func(t *testing.T) {
+		require.Len(t, c.lastAccess, max)
+		require.Len(t, c.order.elems, max)
+		require.Equal(t, max, c.order.list.Len())
+	}
+
+	t.Run("flood", func(t *testing.T) {
+		for i := 0; i < 100*max; i++ {
+			c.touch(strconv.Itoa(i), 1)
+		}
+
+		requireBounded(t)
+		require.Len(t, evicted, 99*max)
+
+		// the most recent keys are kept
+		for i := 99 * max; i < 100*max; i++ {
+			require.Contains(t, c.lastAccess, strconv.Itoa(i))
+		}
+	})
+
+	t.Run("least recently used", func(t *testing.T) {
+		evicted = nil
+
+		oldest := strconv.Itoa(99 * max)
+
+		// oldest key becomes the most recent one
+		require.True(t, c.touch(oldest, 2))
+		require.False(t, c.touch("new", 2))
+
+		requireBounded(t)
+		require.Equal(t, []string{strconv.Itoa(99*max + 1)}, evicted)
+		require.Contains(t, c.lastAccess, oldest)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		evicted = nil
+
+		infos := []netmap.NodeInfo{
+			newNodeInfo(genKey(t).PublicKey()),
+			newNodeInfo(genKey(t).PublicKey()),
+		}
+
+		networkMap, err := netmap.NewNetmap(netmap.NodesFromInfo(infos))
+		require.NoError(t, err)
+
+		// replaced by the network map
+		c.update(networkMap, 3)
+
+		require.Len(t, c.lastAccess, len(infos))
+		require.Empty(t, c.order.elems)
+		require.Empty(t, evicted)
+
+		t.Run("network map nodes are not evicted", func(t *testing.T) {
+			for i := 0; i < 2*max; i++ {
+				c.touch(strconv.Itoa(i), 4)
+			}
+
+			require.Len(t, c.lastAccess, len(infos)+max)
+			require.Len(t, evicted, max)
+
+			for i := range infos {
+				keyString := hex.EncodeToString(infos[i].PublicKey())
+
+				require.True(t, c.touch(keyString, 4))
+				require.Contains(t, c.lastAccess, keyString)
+			}
+
+			require.Len(t, c.lastAccess, len(infos)+max)
+			require.Len(t, c.order.elems, max)
+		})
+	})
+}
+
+func TestProcessor_MaxTrackedNodes(t *testing.T) {
+	const max = 10
+
+	epoch := testEpochState(1)
+	metrics := new(testMetrics)
+
+	np := &Processor{
+		log:            test.NewLogger(false),
+		epochState:     &epoch,
+		alphabetState:  testAlphabetState(true),
+		netmapClient:   new(testNetmapClient),
+		netmapSnapshot: newCleanupTable(true, 1),
+		nodeValidator:  nodeValidatorFunc(func(*netmap.NodeInfo) error { return nil }),
+		rejectionSink:  noopRejectionSink{},
+		auditLog:       noopAuditLog{},
+		now:            time.Now,
+		metrics:        metrics,
+	}
+
+	np.netmapSnapshot.setMaxEntries(max, func(string) {
+		np.metrics.StateEvicted(cleanupTableStructure)
+	})
+
+	for i := 0; i < 3*max; i++ {
+		info := newNodeInfo(genKey(t).PublicKey())
+
+		data, err := info.Marshal()
+		require.NoError(t, err)
+
+		np.processAddPeer(data)
+	}
+
+	require.Len(t, np.netmapSnapshot.lastAccess, max)
+	require.Equal(t, 2*max, metrics.evicted[cleanupTableStructure])
+}
+
+func TestCleanupTable_Maintenance(t *testing.T) {
+	c := newCleanupTable(true, 1)
+	c.maintenanceGrace = 3
+
+	c.touch("a", 1)
+	c.touch("b", 1)
+
+	require.False(t, c.maintain("c", 1))
+	require.True(t, c.maintain("a", 2))
+
+	// "a" is past the threshold, but still within the grace window
+	require.Equal(t, []string{"b"}, c.removeCandidates(5))
+
+	// grace window is over
+	require.Equal(t, []string{"a", "b"}, c.removeCandidates(6))
+
+	t.Run("back from maintenance", func(t *testing.T) {
+		c.maintain("b", 2)
+		c.touch("b", 3)
+
+		require.Equal(t, []string{"a", "b"}, c.removeCandidates(6))
+	})
+}
@@ -0,0 +1,68 @@
+package netmap
+
+import (
+	"errors"
+	"time"
+)
+
+const defaultCloseTimeout = 5 * time.Second
+
+var errCloseTimeout = errors.New("netmap processor: timeout waiting for the handled events")
+
+// Close stops accepting new events, waits for the events being handled,
+// cancels the postponed epoch timer reset (see Params.EpochTimerResetDebounce)
+// and releases the worker pool. Events received after the Close are ignored.
+//
+// Returns an error if the events are still being handled after the timeout
+// (see Params.CloseTimeout), the pool is released anyway. Repeated calls
+// are no-op.
+func (np *Processor) Close() error {
+	np.closeMtx.Lock()
+
+	if np.closed {
+		np.closeMtx.Unlock()
+		return nil
+	}
+
+	np.closed = true
+
+	np.closeMtx.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		np.tasks.Wait()
+		close(done)
+	}()
+
+	var err error
+
+	select {
+	case <-done:
+	case <-time.After(np.closeTimeout):
+		err = errCloseTimeout
+	}
+
+	np.stopEpochTimerReset()
+	np.pool.Release()
+
+	return err
+}
+
+// stopEpochTimerReset cancels the postponed epoch timer reset if any.
+func (np *Processor) stopEpochTimerReset() {
+	np.epochTimerMtx.Lock()
+	defer np.epochTimerMtx.Unlock()
+
+	if np.epochTimerReset != nil {
+		np.epochTimerReset.Stop()
+		np.epochTimerReset = nil
+	}
+}
+
+func (np *Processor) isClosed() bool {
+	np.closeMtx.RLock()
+	defer np.closeMtx.RUnlock()
+
+	return np.closed
+}
@@ -0,0 +1,100 @@
+package netmap
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/panjf2000/ants/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_Close(t *testing.T) {
+	newProcessor := func(t *testing.T, timeout time.Duration) *Processor {
+		pool, err := ants.NewPool(10, ants.WithNonblocking(true))
+		require.NoError(t, err)
+
+		return &Processor{
+			log:          test.NewLogger(false),
+			pool:         pool,
+			metrics:      noopMetrics{},
+			closeTimeout: timeout,
+		}
+	}
+
+	t.Run("drained", func(t *testing.T) {
+		np := newProcessor(t, time.Second)
+
+		var handled int32
+
+		for i := 0; i < 3; i++ {
+			np.submit("test", func() {
+				time.Sleep(50 * time.Millisecond)
+				atomic.AddInt32(&handled, 1)
+			})
+		}
+
+		require.NoError(t, np.Close())
+		require.EqualValues(t, 3, atomic.LoadInt32(&handled))
+
+		t.Run("closed", func(t *testing.T) {
+			np.submit("test", func() {
+				atomic.AddInt32(&handled, 1)
+			})
+
+			require.NoError(t, np.Close())
+			require.EqualValues(t, 3, atomic.LoadInt32(&handled))
+		})
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		np := newProcessor(t, 50*time.Millisecond)
+
+		release := make(chan struct{})
+		defer close(release)
+
+		np.submit("test", func() { <-release })
+
+		start := time.Now()
+
+		require.True(t, errors.Is(np.Close(), errCloseTimeout))
+		require.GreaterOrEqual(t, int64(time.Since(start)), int64(50*time.Millisecond))
+	})
+
+	t.Run("dropped events", func(t *testing.T) {
+		np := newProcessor(t, time.Second)
+		np.pool = newTestPool(t)
+
+		release := make(chan struct{})
+		np.submit("test", func() { <-release })
+
+		// rejected by the saturated pool, so it is not awaited
+		np.submit("test", func() {})
+
+		close(release)
+
+		require.NoError(t, np.Close())
+	})
+
+	t.Run("postponed epoch timer reset", func(t *testing.T) {
+		const debounce = 50 * time.Millisecond
+
+		np := newProcessor(t, time.Second)
+
+		timer := new(testEpochTimer)
+		np.epochTimer = timer
+		np.epochTimerDebounce = debounce
+
+		np.resetEpochTimer()
+
+		require.NoError(t, np.Close())
+
+		// reset is not scheduled after the Close too
+		np.resetEpochTimer()
+
+		time.Sleep(2 * debounce)
+		require.Zero(t, timer.resetCount())
+	})
+}
@@ -0,0 +1,137 @@
+package netmap
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// defaultDedupMaxEntries is a default max number of the notifications
+// remembered by dedupCache.
+const defaultDedupMaxEntries = 10000
+
+const addPeerDedupStructure = "add_peer_dedup"
+
+// dedupCache remembers the recently handled notifications, so the
+// repeated deliveries of the same notification are skipped.
+//
+// Entries are kept in the order of their recording, so the expired
+// ones are aged from the front of the list. If the number of entries
+// exceeds the limit, the oldest one is evicted.
+//
+// Methods of the nil dedupCache are no-op, nothing is deduplicated.
+type dedupCache struct {
+	mtx sync.Mutex
+
+	window time.Duration
+	now    func() time.Time
+
+	max     int
+	onEvict func()
+
+	// list of *dedupEntry, the oldest one is at the front
+	order *list.List
+	seen  map[[sha256.Size]byte]*list.Element
+}
+
+type dedupEntry struct {
+	key [sha256.Size]byte
+	at  time.Time
+}
+
+func newDedupCache(window time.Duration, max int, onEvict func()) *dedupCache {
+	if max <= 0 {
+		max = defaultDedupMaxEntries
+	}
+
+	if onEvict == nil {
+		onEvict = func() {}
+	}
+
+	return &dedupCache{
+		window:  window,
+		now:     time.Now,
+		max:     max,
+		onEvict: onEvict,
+		order:   list.New(),
+		seen:    make(map[[sha256.Size]byte]*list.Element),
+	}
+}
+
+// duplicate returns true if the same data has been remembered
+// within the window.
+func (c *dedupCache) duplicate(data []byte) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.age(c.now())
+
+	_, ok := c.seen[sha256.Sum256(data)]
+
+	return ok
+}
+
+// add remembers the data, so the repeated data is considered
+// as a duplicate until the window is over. Must be called only
+// after the data has been successfully handled.
+func (c *dedupCache) add(data []byte) {
+	if c == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := c.now()
+
+	c.age(now)
+
+	k := sha256.Sum256(data)
+
+	if e, ok := c.seen[k]; ok {
+		e.Value.(*dedupEntry).at = now
+		c.order.MoveToBack(e)
+
+		return
+	}
+
+	c.seen[k] = c.order.PushBack(&dedupEntry{key: k, at: now})
+
+	for len(c.seen) > c.max {
+		c.remove(c.order.Front())
+		c.onEvict()
+	}
+}
+
+// age removes the entries recorded earlier than the window before now.
+func (c *dedupCache) age(now time.Time) {
+	for e := c.order.Front(); e != nil; e = c.order.Front() {
+		if now.Sub(e.Value.(*dedupEntry).at) <= c.window {
+			return
+		}
+
+		c.remove(e)
+	}
+}
+
+func (c *dedupCache) remove(e *list.Element) {
+	c.order.Remove(e)
+	delete(c.seen, e.Value.(*dedupEntry).key)
+}
+
+// reset forgets all the remembered data.
+func (c *dedupCache) reset() {
+	if c == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	c.order.Init()
+	c.seen = make(map[[sha256.Size]byte]*list.Element)
+	c.mtx.Unlock()
+}
@@ -0,0 +1,177 @@
+package netmap
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/panjf2000/ants/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupCache(t *testing.T) {
+	now := time.Now()
+
+	c := newDedupCache(time.Minute, 0, nil)
+	c.now = func() time.Time { return now }
+
+	require.False(t, c.duplicate([]byte("a")))
+	// data is not remembered until it is added
+	require.False(t, c.duplicate([]byte("a")))
+
+	c.add([]byte("a"))
+
+	require.True(t, c.duplicate([]byte("a")))
+	require.False(t, c.duplicate([]byte("b")))
+
+	t.Run("window is over", func(t *testing.T) {
+		c.add([]byte("b"))
+
+		now = now.Add(time.Minute + time.Second)
+
+		require.False(t, c.duplicate([]byte("a")))
+		require.False(t, c.duplicate([]byte("b")))
+		require.Empty(t, c.seen)
+		require.Zero(t, c.order.Len())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		c.add([]byte("a"))
+		c.reset()
+
+		require.False(t, c.duplicate([]byte("a")))
+	})
+
+	t.Run("max entries", func(t *testing.T) {
+		var evicted int
+
+		c := newDedupCache(time.Minute, 2, func() { evicted++ })
+
+		c.add([]byte("a"))
+		c.add([]byte("b"))
+		// repeated data is moved to the back
+		c.add([]byte("a"))
+		c.add([]byte("c"))
+
+		require.Equal(t, 1, evicted)
+		require.True(t, c.duplicate([]byte("a")))
+		require.False(t, c.duplicate([]byte("b")))
+		require.True(t, c.duplicate([]byte("c")))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var c *dedupCache
+
+		c.add([]byte("a"))
+		require.False(t, c.duplicate([]byte("a")))
+
+		c.reset()
+	})
+}
+
+func TestProcessor_AddPeerDedup(t *testing.T) {
+	pool, err := ants.NewPool(10, ants.WithNonblocking(true))
+	require.NoError(t, err)
+
+	var (
+		epoch   = testEpochState(1)
+		metrics = newEventMetrics()
+	)
+
+	np := &Processor{
+		log:           test.NewLogger(false),
+		pool:          pool,
+		epochState:    &epoch,
+		epochTimer:    new(testEpochTimer),
+		alphabetState: testAlphabetState(false),
+		// snapshot failure stops processing right after the epoch is set
+		netmapClient: &testNetmapClient{err: errors.New("test error")},
+		metrics:      metrics,
+		addPeerDedup: newDedupCache(time.Hour, 0, nil),
+	}
+
+	info := newNodeInfo(genKey(t).PublicKey())
+
+	handled := func(n int) {
+		require.Eventually(t, func() bool {
+			return metrics.count(metrics.handled, addPeerNotification) == n
+		}, time.Second, time.Millisecond)
+	}
+
+	np.handleAddPeer(addPeerEvent(t, &info))
+	handled(1)
+
+	t.Run("duplicate", func(t *testing.T) {
+		np.handleAddPeer(addPeerEvent(t, &info))
+
+		require.Equal(t, 2, metrics.count(metrics.received, addPeerNotification))
+
+		// the same key with the other info is not a duplicate
+		var changed netmap.NodeInfo
+		changed.SetPublicKey(info.PublicKey())
+		changed.SetAddresses("/ip4/127.0.0.1/tcp/8080")
+
+		np.handleAddPeer(addPeerEvent(t, &changed))
+		handled(2)
+	})
+
+	t.Run("later epoch", func(t *testing.T) {
+		np.processNewEpoch(2)
+
+		np.handleAddPeer(addPeerEvent(t, &info))
+		handled(3)
+	})
+
+	t.Run("failed processing", func(t *testing.T) {
+		np.alphabetState = testAlphabetState(true)
+		defer func() { np.alphabetState = testAlphabetState(false) }()
+
+		// candidate can't be parsed
+		garbage := []byte{1, 2, 3}
+
+		require.Error(t, np.processAddPeerOnce(garbage))
+		require.False(t, np.addPeerDedup.duplicate(garbage))
+		require.Error(t, np.processAddPeerOnce(garbage))
+	})
+}
+
+func TestProcessor_AddPeerOnceApprovalFailure(t *testing.T) {
+	epoch := testEpochState(1)
+	cli := &testNetmapClient{err: errors.New("test error")}
+
+	np := &Processor{
+		log:            test.NewLogger(false),
+		epochState:     &epoch,
+		alphabetState:  testAlphabetState(true),
+		netmapClient:   cli,
+		netmapSnapshot: newCleanupTable(true, 1),
+		nodeValidator:  nodeValidatorFunc(func(*netmap.NodeInfo) error { return nil }),
+		rejectionSink:  noopRejectionSink{},
+		auditLog:       noopAuditLog{},
+		now:            time.Now,
+		addPeerDedup:   newDedupCache(time.Hour, 0, nil),
+	}
+
+	info := newNodeInfo(genKey(t).PublicKey())
+
+	data, err := info.Marshal()
+	require.NoError(t, err)
+
+	require.Error(t, np.processAddPeerOnce(data))
+	require.False(t, np.addPeerDedup.duplicate(data))
+	require.False(t, np.netmapSnapshot.contains(hex.EncodeToString(info.PublicKey())))
+
+	// repeated delivery is approved again
+	cli.err = nil
+
+	require.NoError(t, np.processAddPeerOnce(data))
+	require.Len(t, cli.added, 2)
+	require.True(t, np.netmapSnapshot.active(hex.EncodeToString(info.PublicKey())))
+
+	// handled notification is not processed again
+	require.NoError(t, np.processAddPeerOnce(data))
+	require.Len(t, cli.added, 2)
+}
@@ -0,0 +1,40 @@
+package netmap
+
+import (
+	"go.uber.org/zap"
+)
+
+// checkEpochDuration compares the wall-clock interval since the previous
+// new epoch with the expected epoch duration, and reports the deviations
+// exceeding the tolerance. Unexpected epoch cadence usually signals
+// contract misconfiguration or clock issues.
+func (np *Processor) checkEpochDuration(epoch uint64) {
+	if np.epochDuration <= 0 {
+		return
+	}
+
+	np.lastEpochMtx.Lock()
+	now := np.now()
+	prev := np.lastEpochAt
+	np.lastEpochAt = now
+	np.lastEpochMtx.Unlock()
+
+	if prev.IsZero() {
+		return
+	}
+
+	actual := now.Sub(prev)
+	deviation := actual - np.epochDuration
+
+	if deviation <= np.epochDurationTolerance && -deviation <= np.epochDurationTolerance {
+		return
+	}
+
+	np.log.Warn("epoch duration deviates from expected",
+		zap.Uint64("epoch", epoch),
+		zap.Duration("expected", np.epochDuration),
+		zap.Duration("actual", actual),
+		zap.Duration("tolerance", np.epochDurationTolerance))
+
+	np.metrics.EpochDurationDeviated(deviation)
+}
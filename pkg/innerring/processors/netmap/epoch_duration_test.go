@@ -0,0 +1,114 @@
+package netmap
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+type testMetrics struct {
+	noopMetrics
+
+	deviations []time.Duration
+
+	evicted map[string]int
+
+	rejectionRates []float64
+}
+
+func (m *testMetrics) EpochDurationDeviated(d time.Duration) {
+	m.deviations = append(m.deviations, d)
+}
+
+func (m *testMetrics) RejectionRate(rate float64) {
+	m.rejectionRates = append(m.rejectionRates, rate)
+}
+
+func (m *testMetrics) StateEvicted(structure string) {
+	if m.evicted == nil {
+		m.evicted = make(map[string]int)
+	}
+
+	m.evicted[structure]++
+}
+
+func TestProcessor_EpochDuration(t *testing.T) {
+	now := time.Now()
+	metrics := new(testMetrics)
+
+	np := &Processor{
+		log:                    test.NewLogger(false),
+		metrics:                metrics,
+		epochDuration:          time.Minute,
+		epochDurationTolerance: 5 * time.Second,
+		now:                    func() time.Time { return now },
+	}
+
+	var epoch uint64
+
+	newEpochAfter := func(d time.Duration) {
+		now = now.Add(d)
+		epoch++
+
+		np.checkEpochDuration(epoch)
+	}
+
+	// first epoch has nothing to compare with
+	newEpochAfter(0)
+	require.Empty(t, metrics.deviations)
+
+	for _, d := range []time.Duration{
+		time.Minute,
+		time.Minute + 5*time.Second,
+		time.Minute - 5*time.Second,
+	} {
+		newEpochAfter(d)
+	}
+
+	require.Empty(t, metrics.deviations)
+
+	// too late
+	newEpochAfter(2 * time.Minute)
+	// too early
+	newEpochAfter(10 * time.Second)
+	// back to normal
+	newEpochAfter(time.Minute + time.Second)
+
+	require.Equal(t, []time.Duration{time.Minute, -50 * time.Second}, metrics.deviations)
+
+	t.Run("disabled", func(t *testing.T) {
+		np.epochDuration = 0
+		metrics.deviations = nil
+
+		newEpochAfter(time.Hour)
+		require.Empty(t, metrics.deviations)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		np := &Processor{
+			log:                    test.NewLogger(false),
+			metrics:                noopMetrics{},
+			epochDuration:          time.Minute,
+			epochDurationTolerance: time.Hour,
+			now:                    time.Now,
+		}
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func(epoch uint64) {
+				defer wg.Done()
+				np.checkEpochDuration(epoch)
+			}(uint64(i))
+		}
+
+		wg.Wait()
+
+		require.False(t, np.lastEpochAt.IsZero())
+	})
+}
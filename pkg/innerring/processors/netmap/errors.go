@@ -0,0 +1,59 @@
+package netmap
+
+import (
+	"fmt"
+)
+
+// Reason is an enumeration of the reasons of the node validation failure.
+type Reason uint8
+
+const (
+	// ReasonUnknown is a reason of the failure that does not fit
+	// any other reason.
+	ReasonUnknown Reason = iota
+
+	// PolicyDenied is a reason of the failure when node is rejected
+	// by the network admission policy rather than because of its
+	// incorrect information.
+	PolicyDenied
+
+	// InvalidInfo is a reason of the failure when node information
+	// is incorrect or inconsistent.
+	InvalidInfo
+)
+
+// String returns string representation of the reason.
+func (r Reason) String() string {
+	switch r {
+	default:
+		return "UNKNOWN"
+	case PolicyDenied:
+		return "POLICY_DENIED"
+	case InvalidInfo:
+		return "INVALID_INFO"
+	}
+}
+
+// ValidationError is an error returned by NodeValidator
+// that describes the reason of the node rejection.
+type ValidationError struct {
+	// Reason of the node rejection.
+	Reason Reason
+
+	// Error with the details of the rejection.
+	Err error
+}
+
+// Error implements error interface.
+func (e ValidationError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("node validation failed: %s", e.Reason)
+	}
+
+	return fmt.Sprintf("node validation failed (%s): %s", e.Reason, e.Err)
+}
+
+// Unwrap returns wrapped error with the details of the rejection.
+func (e ValidationError) Unwrap() error {
+	return e.Err
+}
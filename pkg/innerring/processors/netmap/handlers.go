@@ -15,67 +15,70 @@ func (np *Processor) HandleNewEpochTick(ev event.Event) {
 
 	// send event to the worker pool
 
-	err := np.pool.Submit(func() { np.processNewEpochTick() })
-	if err != nil {
-		// there system can be moved into controlled degradation stage
-		np.log.Warn("netmap worker pool drained",
-			zap.Int("capacity", np.pool.Cap()))
-	}
+	np.submit(newEpochTickEvent, func() { np.processNewEpochTick() })
 }
 
 func (np *Processor) handleNewEpoch(ev event.Event) {
 	epochEvent := ev.(netmapEvent.NewEpoch)
+
+	np.metrics.EventReceived(newEpochNotification)
+
 	np.log.Info("notification",
 		zap.String("type", "new epoch"),
 		zap.Uint64("value", epochEvent.EpochNumber()))
 
+	np.checkEpochDuration(epochEvent.EpochNumber())
+
 	// send event to the worker pool
 
-	err := np.pool.Submit(func() {
-		np.processNewEpoch(epochEvent.EpochNumber())
+	np.submitEvent(newEpochNotification, func() error {
+		np.throttle()
+
+		return np.processNewEpoch(epochEvent.EpochNumber())
 	})
-	if err != nil {
-		// there system can be moved into controlled degradation stage
-		np.log.Warn("netmap worker pool drained",
-			zap.Int("capacity", np.pool.Cap()))
-	}
 }
 
 func (np *Processor) handleAddPeer(ev event.Event) {
 	newPeer := ev.(netmapEvent.AddPeer)
 
+	np.metrics.EventReceived(addPeerNotification)
+
+	if np.addPeerDedup.duplicate(newPeer.Node()) {
+		np.log.Debug("repeated notification, skip",
+			zap.String("type", "add peer"))
+
+		return
+	}
+
 	np.log.Info("notification",
 		zap.String("type", "add peer"),
 	)
 
 	// send event to the worker pool
 
-	err := np.pool.Submit(func() {
-		np.processAddPeer(newPeer.Node())
+	np.submitEvent(addPeerNotification, func() error {
+		np.throttle()
+
+		return np.processAddPeerOnce(newPeer.Node())
 	})
-	if err != nil {
-		// there system can be moved into controlled degradation stage
-		np.log.Warn("netmap worker pool drained",
-			zap.Int("capacity", np.pool.Cap()))
-	}
 }
 
 func (np *Processor) handleUpdateState(ev event.Event) {
 	updPeer := ev.(netmapEvent.UpdatePeer)
+
+	np.metrics.EventReceived(updatePeerStateNotification)
+
 	np.log.Info("notification",
 		zap.String("type", "update peer state"),
 		zap.String("key", hex.EncodeToString(updPeer.PublicKey().Bytes())))
 
 	// send event to the worker pool
 
-	err := np.pool.Submit(func() {
-		np.processUpdatePeer(updPeer)
+	np.submitEvent(updatePeerStateNotification, func() error {
+		np.throttle()
+
+		return np.processUpdatePeer(updPeer)
 	})
-	if err != nil {
-		// there system can be moved into controlled degradation stage
-		np.log.Warn("netmap worker pool drained",
-			zap.Int("capacity", np.pool.Cap()))
-	}
 }
 
 func (np *Processor) handleCleanupTick(ev event.Event) {
@@ -90,12 +93,20 @@ func (np *Processor) handleCleanupTick(ev event.Event) {
 	np.log.Info("tick", zap.String("type", "netmap cleaner"))
 
 	// send event to the worker pool
-	err := np.pool.Submit(func() {
+	np.submit(netmapCleanupTickEvent, func() {
 		np.processNetmapCleanupTick(cleanup.epoch)
 	})
-	if err != nil {
-		// there system can be moved into controlled degradation stage
-		np.log.Warn("netmap worker pool drained",
-			zap.Int("capacity", np.pool.Cap()))
-	}
+}
+
+// submitEvent sends handler of the contract event to the worker pool
+// and reports the result of the handling to the metrics.
+func (np *Processor) submitEvent(event string, handle func() error) {
+	np.submit(event, func() {
+		if err := handle(); err != nil {
+			np.metrics.EventFailed(event)
+			return
+		}
+
+		np.metrics.EventHandled(event)
+	})
 }
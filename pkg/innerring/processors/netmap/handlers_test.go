@@ -0,0 +1,250 @@
+package netmap
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/morph/event"
+	netmapEvent "github.com/nspcc-dev/neofs-node/pkg/morph/event/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/panjf2000/ants/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type testAlphabetState bool
+
+func (s testAlphabetState) IsAlphabet() bool {
+	return bool(s)
+}
+
+func newTestPool(t *testing.T) *ants.Pool {
+	pool, err := ants.NewPool(1, ants.WithNonblocking(true))
+	require.NoError(t, err)
+
+	return pool
+}
+
+func newEpochEvent(t *testing.T, epoch uint64) event.Event {
+	ev, err := netmapEvent.ParseNewEpoch([]stackitem.Item{
+		stackitem.NewBigInteger(new(big.Int).SetUint64(epoch)),
+	})
+	require.NoError(t, err)
+
+	return ev
+}
+
+func addPeerEvent(t *testing.T, info *netmap.NodeInfo) event.Event {
+	data, err := info.Marshal()
+	require.NoError(t, err)
+
+	ev, err := netmapEvent.ParseAddPeer([]stackitem.Item{
+		stackitem.NewByteArray(data),
+	})
+	require.NoError(t, err)
+
+	return ev
+}
+
+func updatePeerEvent(t *testing.T, st netmap.NodeState, key []byte) event.Event {
+	ev, err := netmapEvent.ParseUpdatePeer([]stackitem.Item{
+		stackitem.NewBigInteger(big.NewInt(int64(st.ToV2()))),
+		stackitem.NewByteArray(key),
+	})
+	require.NoError(t, err)
+
+	return ev
+}
+
+// eventMetrics counts the events reported to Metrics,
+// it is safe for concurrent use by the pool workers.
+type eventMetrics struct {
+	noopMetrics
+
+	mtx sync.Mutex
+
+	received, handled, failed, rejected map[string]int
+}
+
+func newEventMetrics() *eventMetrics {
+	return &eventMetrics{
+		received: make(map[string]int),
+		handled:  make(map[string]int),
+		failed:   make(map[string]int),
+		rejected: make(map[string]int),
+	}
+}
+
+func (m *eventMetrics) inc(counters map[string]int, event string) {
+	m.mtx.Lock()
+	counters[event]++
+	m.mtx.Unlock()
+}
+
+func (m *eventMetrics) count(counters map[string]int, event string) int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return counters[event]
+}
+
+func (m *eventMetrics) EventReceived(event string) { m.inc(m.received, event) }
+
+func (m *eventMetrics) EventHandled(event string) { m.inc(m.handled, event) }
+
+func (m *eventMetrics) EventFailed(event string) { m.inc(m.failed, event) }
+
+func (m *eventMetrics) PoolRejected(event string) { m.inc(m.rejected, event) }
+
+func TestProcessor_EventMetrics(t *testing.T) {
+	pool, err := ants.NewPool(10, ants.WithNonblocking(true))
+	require.NoError(t, err)
+
+	metrics := newEventMetrics()
+
+	np := &Processor{
+		log:            test.NewLogger(false),
+		pool:           pool,
+		alphabetState:  testAlphabetState(true),
+		netmapClient:   new(testNetmapClient),
+		netmapSnapshot: newCleanupTable(true, 1),
+		metrics:        metrics,
+	}
+
+	key := genKey(t).PublicKey().Bytes()
+
+	eventually := func(counters map[string]int, n int) {
+		require.Eventually(t, func() bool {
+			return metrics.count(counters, updatePeerStateNotification) == n
+		}, time.Second, time.Millisecond)
+	}
+
+	t.Run("handled", func(t *testing.T) {
+		np.handleUpdateState(updatePeerEvent(t, netmap.NodeStateOffline, key))
+
+		eventually(metrics.handled, 1)
+		require.Equal(t, 1, metrics.count(metrics.received, updatePeerStateNotification))
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		// only offline state can be proposed
+		np.handleUpdateState(updatePeerEvent(t, netmap.NodeStateOnline, key))
+
+		eventually(metrics.failed, 1)
+		require.Equal(t, 2, metrics.count(metrics.received, updatePeerStateNotification))
+		require.Equal(t, 1, metrics.count(metrics.handled, updatePeerStateNotification))
+	})
+
+	t.Run("pool overflow", func(t *testing.T) {
+		busy := newTestPool(t)
+
+		release := make(chan struct{})
+		defer close(release)
+
+		require.NoError(t, busy.Submit(func() { <-release }))
+
+		np.pool = busy
+
+		np.handleUpdateState(updatePeerEvent(t, netmap.NodeStateOffline, key))
+
+		require.Equal(t, 3, metrics.count(metrics.received, updatePeerStateNotification))
+		require.Equal(t, 1, metrics.count(metrics.rejected, updatePeerStateNotification))
+		require.Equal(t, 1, metrics.count(metrics.handled, updatePeerStateNotification))
+	})
+}
+
+func TestProcessor_Throttle(t *testing.T) {
+	var (
+		lag int
+
+		mtx   sync.Mutex
+		slept []time.Duration
+		block chan struct{}
+	)
+
+	np := &Processor{
+		log:               test.NewLogger(false),
+		pool:              newTestPool(t),
+		alphabetState:     testAlphabetState(false),
+		metrics:           noopMetrics{},
+		chainHeightLag:    func() int { return lag },
+		throttleThreshold: 10,
+		throttleDelay:     time.Second,
+		sleep: func(d time.Duration) {
+			if block != nil {
+				<-block
+			}
+
+			mtx.Lock()
+			slept = append(slept, d)
+			mtx.Unlock()
+		},
+	}
+
+	info := newNodeInfo(genKey(t).PublicKey())
+
+	sleptFor := func() []time.Duration {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		return append([]time.Duration(nil), slept...)
+	}
+
+	// waits for the pool worker, pool has the single one
+	handle := func() {
+		np.handleAddPeer(addPeerEvent(t, &info))
+
+		require.Eventually(t, func() bool {
+			return np.pool.Running() == 0
+		}, time.Second, time.Millisecond)
+	}
+
+	t.Run("large lag", func(t *testing.T) {
+		lag = 1000
+
+		for i := 0; i < 3; i++ {
+			handle()
+		}
+
+		require.Equal(t, []time.Duration{time.Second, time.Second, time.Second}, sleptFor())
+	})
+
+	t.Run("lag cleared", func(t *testing.T) {
+		lag = 10
+		slept = nil
+
+		handle()
+
+		require.Empty(t, sleptFor())
+	})
+
+	t.Run("throttling disabled", func(t *testing.T) {
+		np.chainHeightLag = nil
+		slept = nil
+
+		handle()
+
+		require.Empty(t, sleptFor())
+
+		np.chainHeightLag = func() int { return lag }
+	})
+
+	t.Run("listener is not blocked", func(t *testing.T) {
+		lag = 1000
+		slept = nil
+		block = make(chan struct{})
+
+		// handler returns while the delay is in progress
+		np.handleAddPeer(addPeerEvent(t, &info))
+		require.Empty(t, sleptFor())
+
+		close(block)
+
+		require.Eventually(t, func() bool {
+			return len(sleptFor()) == 1
+		}, time.Second, time.Millisecond)
+	})
+}
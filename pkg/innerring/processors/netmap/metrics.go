@@ -0,0 +1,214 @@
+package netmap
+
+import (
+	"io"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Metrics is an interface of the collector of the Processor's
+// event handling statistics. Argument is a name of the event
+// notification (e.g. "NewEpoch").
+type Metrics interface {
+	// EventReceived is called on each received event.
+	EventReceived(string)
+	// EventHandled is called on each successfully handled event.
+	EventHandled(string)
+	// EventFailed is called on each event which handling failed.
+	EventFailed(string)
+	// PoolRejected is called on each event dropped because
+	// the worker pool is overflowed.
+	PoolRejected(string)
+	// EpochDurationDeviated is called when the interval between the
+	// successive new epochs differs from the expected one more than
+	// allowed. Argument is the difference between the actual and the
+	// expected intervals.
+	EpochDurationDeviated(time.Duration)
+	// StateEvicted is called on each entry evicted from the bounded
+	// in-memory state of the Processor. Argument is a name of the
+	// structure (e.g. "cleanup_table").
+	//
+	// Each structure is bounded by its own limit rather than by the
+	// shared one: memory is still bounded by the sum of the limits,
+	// while the flood of the distinct keys of one kind (e.g. repeated
+	// candidates) can not evict the entries of the other structures.
+	StateEvicted(string)
+	// RejectionRate is called on each validated network map candidate
+	// with the rolling rate of the candidates rejected by the node
+	// validator (in range [0; 1]).
+	RejectionRate(float64)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) EventReceived(string) {}
+
+func (noopMetrics) EventHandled(string) {}
+
+func (noopMetrics) EventFailed(string) {}
+
+func (noopMetrics) PoolRejected(string) {}
+
+func (noopMetrics) EpochDurationDeviated(time.Duration) {}
+
+func (noopMetrics) StateEvicted(string) {}
+
+func (noopMetrics) RejectionRate(float64) {}
+
+// PrometheusMetrics is a built-in Metrics implementation which
+// accumulates the Prometheus counters.
+//
+// PrometheusMetrics implements prometheus.Collector, so it can be
+// registered in any prometheus.Registerer, or rendered in the text
+// exposition format via WriteText.
+type PrometheusMetrics struct {
+	received, handled, failed, rejected *prometheus.CounterVec
+
+	epochDeviations prometheus.Counter
+
+	epochDeviation prometheus.Gauge
+
+	evicted *prometheus.CounterVec
+
+	rejectionRate prometheus.Gauge
+}
+
+const (
+	metricsNamespace = "neofs_ir"
+	metricsSubsystem = "netmap"
+
+	metricsEventLabel = "event"
+
+	metricsStructureLabel = "structure"
+)
+
+// NewPrometheusMetrics creates, initializes and returns PrometheusMetrics instance.
+func NewPrometheusMetrics() *PrometheusMetrics {
+	newCounter := func(name, help string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      name,
+			Help:      help,
+		}, []string{metricsEventLabel})
+	}
+
+	return &PrometheusMetrics{
+		received: newCounter("events_received_total", "Number of received netmap events"),
+		handled:  newCounter("events_handled_total", "Number of successfully handled netmap events"),
+		failed:   newCounter("events_failed_total", "Number of netmap events which handling failed"),
+		rejected: newCounter("events_pool_rejected_total", "Number of netmap events dropped due to worker pool overflow"),
+		epochDeviations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "epoch_duration_deviations_total",
+			Help:      "Number of epochs which duration deviated from the expected one",
+		}),
+		epochDeviation: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "epoch_duration_deviation_seconds",
+			Help:      "Last deviation of the epoch duration from the expected one",
+		}),
+		evicted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "state_evicted_total",
+			Help:      "Number of entries evicted from the bounded in-memory state",
+		}, []string{metricsStructureLabel}),
+		rejectionRate: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "candidates_rejection_rate",
+			Help:      "Rolling rate of the network map candidates rejected by the node validator",
+		}),
+	}
+}
+
+// EventReceived implements Metrics.
+func (m *PrometheusMetrics) EventReceived(event string) {
+	m.received.WithLabelValues(event).Inc()
+}
+
+// EventHandled implements Metrics.
+func (m *PrometheusMetrics) EventHandled(event string) {
+	m.handled.WithLabelValues(event).Inc()
+}
+
+// EventFailed implements Metrics.
+func (m *PrometheusMetrics) EventFailed(event string) {
+	m.failed.WithLabelValues(event).Inc()
+}
+
+// PoolRejected implements Metrics.
+func (m *PrometheusMetrics) PoolRejected(event string) {
+	m.rejected.WithLabelValues(event).Inc()
+}
+
+// EpochDurationDeviated implements Metrics.
+func (m *PrometheusMetrics) EpochDurationDeviated(d time.Duration) {
+	m.epochDeviations.Inc()
+	m.epochDeviation.Set(d.Seconds())
+}
+
+// StateEvicted implements Metrics.
+func (m *PrometheusMetrics) StateEvicted(structure string) {
+	m.evicted.WithLabelValues(structure).Inc()
+}
+
+// RejectionRate implements Metrics.
+func (m *PrometheusMetrics) RejectionRate(rate float64) {
+	m.rejectionRate.Set(rate)
+}
+
+func (m *PrometheusMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.received,
+		m.handled,
+		m.failed,
+		m.rejected,
+		m.epochDeviations,
+		m.epochDeviation,
+		m.evicted,
+		m.rejectionRate,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *PrometheusMetrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *PrometheusMetrics) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+// WriteText writes current values of the metrics to w
+// in the Prometheus text exposition format.
+func (m *PrometheusMetrics) WriteText(w io.Writer) error {
+	reg := prometheus.NewRegistry()
+
+	if err := reg.Register(m); err != nil {
+		return err
+	}
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		return err
+	}
+
+	for i := range mfs {
+		if _, err := expfmt.MetricFamilyToText(w, mfs[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
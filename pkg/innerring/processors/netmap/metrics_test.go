@@ -0,0 +1,58 @@
+package netmap
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	m := NewPrometheusMetrics()
+
+	m.EventReceived(newEpochNotification)
+	m.EventReceived(newEpochNotification)
+	m.EventHandled(newEpochNotification)
+	m.EventFailed(addPeerNotification)
+	m.PoolRejected(updatePeerStateNotification)
+	m.StateEvicted(cleanupTableStructure)
+	m.RejectionRate(0.5)
+
+	require.EqualValues(t, 2, testutil.ToFloat64(m.received.WithLabelValues(newEpochNotification)))
+	require.EqualValues(t, 1, testutil.ToFloat64(m.handled.WithLabelValues(newEpochNotification)))
+	require.EqualValues(t, 1, testutil.ToFloat64(m.failed.WithLabelValues(addPeerNotification)))
+	require.EqualValues(t, 1, testutil.ToFloat64(m.rejected.WithLabelValues(updatePeerStateNotification)))
+	require.EqualValues(t, 1, testutil.ToFloat64(m.evicted.WithLabelValues(cleanupTableStructure)))
+	require.EqualValues(t, 0.5, testutil.ToFloat64(m.rejectionRate))
+
+	t.Run("text", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+
+		require.NoError(t, m.WriteText(buf))
+
+		text := buf.String()
+
+		for _, name := range []string{
+			"neofs_ir_netmap_events_received_total",
+			"neofs_ir_netmap_events_handled_total",
+			"neofs_ir_netmap_events_failed_total",
+			"neofs_ir_netmap_events_pool_rejected_total",
+			"neofs_ir_netmap_state_evicted_total",
+			"neofs_ir_netmap_candidates_rejection_rate",
+		} {
+			require.Contains(t, text, name)
+		}
+
+		require.Contains(t, text, `neofs_ir_netmap_events_received_total{event="NewEpoch"} 2`)
+	})
+
+	t.Run("registry", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+
+		require.NoError(t, reg.Register(m))
+		// 5 labeled counters, 2 epoch duration metrics and rejection rate
+		require.Equal(t, 8, testutil.CollectAndCount(m))
+	})
+}
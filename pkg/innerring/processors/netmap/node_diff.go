@@ -0,0 +1,113 @@
+package netmap
+
+import (
+	"bytes"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+)
+
+// NodeInfoDiff describes the differences between two versions
+// of the information about the node.
+type NodeInfoDiff struct {
+	// Public key of the node differs.
+	PublicKey bool
+
+	// Network addresses or their order differ.
+	Addresses bool
+
+	// Node state differs.
+	State bool
+
+	// Keys of the attributes present in the new version only.
+	AddedAttributes []string
+
+	// Keys of the attributes present in the old version only.
+	RemovedAttributes []string
+
+	// Keys of the attributes with the different values.
+	ChangedAttributes []string
+}
+
+// Empty returns true if there are no differences.
+//
+// Order of the attributes is not taken into account.
+func (d NodeInfoDiff) Empty() bool {
+	return !d.PublicKey && !d.Addresses && !d.State &&
+		len(d.AddedAttributes) == 0 &&
+		len(d.RemovedAttributes) == 0 &&
+		len(d.ChangedAttributes) == 0
+}
+
+// DiffNodeInfo returns the differences between the old
+// and the new versions of the information about the node.
+func DiffNodeInfo(before, after *netmap.NodeInfo) NodeInfoDiff {
+	var d NodeInfoDiff
+
+	d.PublicKey = !bytes.Equal(before.PublicKey(), after.PublicKey())
+	d.State = before.State() != after.State()
+	d.Addresses = !equalStrings(nodeAddresses(before), nodeAddresses(after))
+
+	attrsBefore := before.Attributes()
+	attrsAfter := after.Attributes()
+
+	mBefore := make(map[string]string, len(attrsBefore))
+	for _, a := range attrsBefore {
+		mBefore[a.Key()] = a.Value()
+	}
+
+	mAfter := make(map[string]string, len(attrsAfter))
+
+	for _, a := range attrsAfter {
+		key := a.Key()
+
+		if _, ok := mAfter[key]; ok {
+			continue
+		}
+
+		mAfter[key] = a.Value()
+
+		switch val, ok := mBefore[key]; {
+		case !ok:
+			d.AddedAttributes = append(d.AddedAttributes, key)
+		case val != a.Value():
+			d.ChangedAttributes = append(d.ChangedAttributes, key)
+		}
+	}
+
+	for _, a := range attrsBefore {
+		key := a.Key()
+
+		if _, ok := mAfter[key]; !ok {
+			d.RemovedAttributes = append(d.RemovedAttributes, key)
+			// prevent duplicates
+			mAfter[key] = ""
+		}
+	}
+
+	return d
+}
+
+func nodeAddresses(n *netmap.NodeInfo) []string {
+	addrs := make([]string, 0, n.NumberOfAddresses())
+
+	n.IterateAddresses(func(s string) bool {
+		addrs = append(addrs, s)
+		return false
+	})
+
+	return addrs
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
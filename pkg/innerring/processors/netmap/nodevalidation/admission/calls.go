@@ -0,0 +1,84 @@
+package admission
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+)
+
+// VerifyAndUpdate rejects n if it is not a network member and the
+// number of the new nodes admitted in the current epoch has reached
+// the limit. Repeated registration of the node admitted in the current
+// epoch is not counted twice.
+//
+// n is not counted until Commit is called.
+//
+// Rejection error is netmap.ValidationError with netmap.PolicyDenied reason.
+//
+// NodeInfo is not modified.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	key := n.PublicKey()
+
+	if v.known(key) {
+		return nil
+	}
+
+	epoch := v.currentEpoch()
+	keyString := hex.EncodeToString(key)
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	v.checkEpoch(epoch)
+
+	if _, ok := v.admitted.Get(keyString); ok {
+		return nil
+	}
+
+	if v.count >= v.max {
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err:    fmt.Errorf("limit of %d new nodes per epoch reached", v.max),
+		}
+	}
+
+	return nil
+}
+
+// Commit counts n as the new node admitted in the current epoch unless
+// it is a network member or has been already admitted in the epoch.
+//
+// Implements netmap.NodeCommitter.
+func (v *Validator) Commit(n *apinetmap.NodeInfo) {
+	key := n.PublicKey()
+
+	if v.known(key) {
+		return
+	}
+
+	epoch := v.currentEpoch()
+	keyString := hex.EncodeToString(key)
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	v.checkEpoch(epoch)
+
+	if _, ok := v.admitted.Get(keyString); ok {
+		return
+	}
+
+	v.count++
+	v.admitted.Add(keyString, struct{}{})
+}
+
+// checkEpoch forgets the nodes admitted in the previous epoch.
+// Must be called under the lock.
+func (v *Validator) checkEpoch(epoch uint64) {
+	if epoch != v.epoch {
+		v.epoch = epoch
+		v.resetAdmitted()
+	}
+}
@@ -0,0 +1,146 @@
+package admission_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/admission"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfoWithKey(key ...byte) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+	n.SetPublicKey(key)
+
+	return n
+}
+
+func requireDenied(t *testing.T, err error) {
+	var vErr netmap.ValidationError
+
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, netmap.PolicyDenied, vErr.Reason)
+}
+
+// admit verifies n and commits it if n is accepted, as the Processor does.
+func admit(v netmap.NodeValidator, n *apinetmap.NodeInfo) error {
+	err := v.VerifyAndUpdate(n)
+	if err == nil {
+		v.(netmap.NodeCommitter).Commit(n)
+	}
+
+	return err
+}
+
+type validatorFunc func(*apinetmap.NodeInfo) error
+
+func (f validatorFunc) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	return f(n)
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	var epoch uint64 = 1
+
+	memberKey := []byte{0}
+
+	v := admission.New(admission.Prm{
+		MaxPerEpoch:  2,
+		CurrentEpoch: func() uint64 { return epoch },
+		Known: func(key []byte) bool {
+			return bytes.Equal(key, memberKey)
+		},
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		require.NoError(t, admit(v, nodeInfoWithKey(1)))
+		require.NoError(t, admit(v, nodeInfoWithKey(2)))
+
+		// repeated registration is not counted
+		require.NoError(t, admit(v, nodeInfoWithKey(1)))
+
+		requireDenied(t, admit(v, nodeInfoWithKey(3)))
+
+		// members are not limited
+		require.NoError(t, admit(v, nodeInfoWithKey(memberKey...)))
+	})
+
+	t.Run("next epoch", func(t *testing.T) {
+		epoch++
+
+		require.NoError(t, admit(v, nodeInfoWithKey(3)))
+		require.NoError(t, admit(v, nodeInfoWithKey(4)))
+
+		requireDenied(t, admit(v, nodeInfoWithKey(5)))
+	})
+
+	t.Run("max remembered", func(t *testing.T) {
+		var evicted int
+
+		v := admission.New(admission.Prm{
+			MaxPerEpoch:   3,
+			CurrentEpoch:  func() uint64 { return epoch },
+			Known:         func([]byte) bool { return false },
+			MaxRemembered: 1,
+			OnEvict:       func() { evicted++ },
+		})
+
+		require.NoError(t, admit(v, nodeInfoWithKey(1)))
+		require.NoError(t, admit(v, nodeInfoWithKey(2)))
+		require.Equal(t, 1, evicted)
+
+		// forgotten node is counted again
+		require.NoError(t, admit(v, nodeInfoWithKey(1)))
+
+		requireDenied(t, admit(v, nodeInfoWithKey(3)))
+
+		// epoch change is not an eviction
+		epoch++
+
+		require.NoError(t, admit(v, nodeInfoWithKey(3)))
+		require.Equal(t, 2, evicted)
+	})
+}
+
+func TestValidator_Commit(t *testing.T) {
+	newValidator := func() *admission.Validator {
+		return admission.New(admission.Prm{
+			MaxPerEpoch:  1,
+			CurrentEpoch: func() uint64 { return 1 },
+			Known:        func([]byte) bool { return false },
+		})
+	}
+
+	t.Run("not committed", func(t *testing.T) {
+		v := newValidator()
+
+		require.NoError(t, v.VerifyAndUpdate(nodeInfoWithKey(1)))
+		require.NoError(t, v.VerifyAndUpdate(nodeInfoWithKey(2)))
+
+		require.NoError(t, admit(v, nodeInfoWithKey(2)))
+		requireDenied(t, v.VerifyAndUpdate(nodeInfoWithKey(1)))
+	})
+
+	t.Run("rejected by later validator", func(t *testing.T) {
+		errTest := errors.New("test error")
+
+		v := newValidator()
+
+		c := nodevalidation.New(v, validatorFunc(func(n *apinetmap.NodeInfo) error {
+			if bytes.Equal(n.PublicKey(), []byte{1}) {
+				return errTest
+			}
+
+			return nil
+		}))
+
+		require.True(t, errors.Is(admit(c, nodeInfoWithKey(1)), errTest))
+
+		// rejected node is not counted
+		require.NoError(t, admit(c, nodeInfoWithKey(2)))
+		requireDenied(t, admit(c, nodeInfoWithKey(3)))
+	})
+}
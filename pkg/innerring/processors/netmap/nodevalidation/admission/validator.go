@@ -0,0 +1,131 @@
+package admission
+
+import (
+	"sync"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+)
+
+// DefaultMaxRemembered is a default max number of the admitted
+// nodes remembered by the Validator.
+const DefaultMaxRemembered = 10000
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Max number of new nodes admitted per epoch.
+	//
+	// Must be positive.
+	MaxPerEpoch int
+
+	// Function that returns the current epoch number.
+	//
+	// Must not be nil.
+	CurrentEpoch func() uint64
+
+	// Predicate that reports whether the node with
+	// the given public key is already a network member.
+	//
+	// Must not be nil.
+	Known func(key []byte) bool
+
+	// Max number of the nodes admitted in the epoch which are remembered,
+	// so their repeated registrations are not counted twice. The least
+	// recently registered node is forgotten on overflow, and its next
+	// registration is counted again.
+	//
+	// Optional: DefaultMaxRemembered is used if not positive.
+	MaxRemembered int
+
+	// Callback called on each admitted node forgotten
+	// because MaxRemembered is reached.
+	//
+	// Optional.
+	OnEvict func()
+}
+
+// Validator is an utility that limits the number of new nodes
+// admitted to the network per epoch, so the flash mob of
+// registrations does not destabilize the placement. Network
+// members are able to update their information without limits.
+//
+// Validator counts the new nodes on Commit, so the nodes rejected
+// by the other validators of the chain are not counted.
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	max int
+
+	currentEpoch func() uint64
+
+	known func([]byte) bool
+
+	mtx *sync.Mutex
+
+	epoch uint64
+
+	// number of the nodes admitted in the epoch
+	count int
+
+	maxRemembered int
+
+	onEvict simplelru.EvictCallback
+
+	// hex-encoded public keys of the recently
+	// registered nodes admitted in the epoch
+	admitted *simplelru.LRU
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	switch {
+	case prm.MaxPerEpoch <= 0:
+		panic("max number of admissions per epoch must be positive")
+	case prm.CurrentEpoch == nil:
+		panic("current epoch function is not set")
+	case prm.Known == nil:
+		panic("network member predicate is not set")
+	}
+
+	maxRemembered := prm.MaxRemembered
+	if maxRemembered <= 0 {
+		maxRemembered = DefaultMaxRemembered
+	}
+
+	v := &Validator{
+		max:           prm.MaxPerEpoch,
+		currentEpoch:  prm.CurrentEpoch,
+		known:         prm.Known,
+		mtx:           new(sync.Mutex),
+		maxRemembered: maxRemembered,
+	}
+
+	if prm.OnEvict != nil {
+		v.onEvict = func(interface{}, interface{}) {
+			prm.OnEvict()
+		}
+	}
+
+	v.resetAdmitted()
+
+	return v
+}
+
+// resetAdmitted forgets the nodes admitted in the epoch. Forgotten
+// nodes are not reported as evicted.
+func (v *Validator) resetAdmitted() {
+	v.count = 0
+	// error is returned for non-positive size only
+	v.admitted, _ = simplelru.NewLRU(v.maxRemembered, v.onEvict)
+}
@@ -0,0 +1,101 @@
+package attestation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+)
+
+var (
+	errMissingAttestation = errors.New("missing attestation")
+	errInvalidAttestation = errors.New("invalid attestation signature")
+)
+
+// VerifyAndUpdate checks that n carries AttributeAttestation attribute
+// with the valid authority signature of SignedData.
+//
+// Rejection error is netmap.ValidationError with netmap.PolicyDenied reason.
+//
+// NodeInfo is not modified.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	var sigHex string
+
+	for _, a := range n.Attributes() {
+		if a.Key() == AttributeAttestation {
+			sigHex = a.Value()
+			break
+		}
+	}
+
+	if sigHex == "" {
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err:    errMissingAttestation,
+		}
+	}
+
+	sig, err := hex.DecodeString(sigHex)
+	if err != nil {
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err:    fmt.Errorf("%w: %v", errInvalidAttestation, err),
+		}
+	}
+
+	h := sha256.Sum256(SignedData(n, v.attrs))
+
+	if !v.key.Verify(sig, h[:]) {
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err:    errInvalidAttestation,
+		}
+	}
+
+	return nil
+}
+
+// SignedData returns canonical binary representation of the attested
+// node information: public key of the node followed by the sorted
+// attributes with the specified keys (all attributes except
+// AttributeAttestation if keys are empty). Each key and value is
+// terminated by zero byte, absent attribute is treated as an attribute
+// with an empty value.
+//
+// Authority must sign SHA256 hash of SignedData.
+func SignedData(n *apinetmap.NodeInfo, keys []string) []byte {
+	mAttrs := make(map[string]string)
+
+	for _, a := range n.Attributes() {
+		if a.Key() != AttributeAttestation {
+			mAttrs[a.Key()] = a.Value()
+		}
+	}
+
+	if len(keys) == 0 {
+		keys = make([]string, 0, len(mAttrs))
+
+		for key := range mAttrs {
+			keys = append(keys, key)
+		}
+	} else {
+		keys = append([]string{}, keys...)
+	}
+
+	sort.Strings(keys)
+
+	data := append([]byte{}, n.PublicKey()...)
+
+	for _, key := range keys {
+		data = append(data, key...)
+		data = append(data, 0)
+		data = append(data, mAttrs[key]...)
+		data = append(data, 0)
+	}
+
+	return data
+}
@@ -0,0 +1,113 @@
+package attestation_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/attestation"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfo(kv ...string) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+	n.SetPublicKey([]byte{1, 2, 3})
+
+	as := make([]*apinetmap.NodeAttribute, 0, len(kv)/2)
+
+	for i := 0; i < len(kv); i += 2 {
+		a := apinetmap.NewNodeAttribute()
+		a.SetKey(kv[i])
+		a.SetValue(kv[i+1])
+
+		as = append(as, a)
+	}
+
+	n.SetAttributes(as...)
+
+	return n
+}
+
+// attest adds attestation attribute signed by the key.
+func attest(key *keys.PrivateKey, n *apinetmap.NodeInfo, attrs []string) {
+	a := apinetmap.NewNodeAttribute()
+	a.SetKey(attestation.AttributeAttestation)
+	a.SetValue(hex.EncodeToString(key.Sign(attestation.SignedData(n, attrs))))
+
+	n.SetAttributes(append(n.Attributes(), a)...)
+}
+
+func requireDenied(t *testing.T, err error) {
+	var vErr netmap.ValidationError
+
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, netmap.PolicyDenied, vErr.Reason)
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	authority, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	attrs := []string{"Datacenter", "KYC"}
+
+	v := attestation.New(attestation.Prm{
+		AuthorityKey: authority.PublicKey().Bytes(),
+		Attributes:   attrs,
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		n := nodeInfo("KYC", "passed", "Datacenter", "DC1", "Price", "10")
+		attest(authority, n, attrs)
+
+		require.NoError(t, v.VerifyAndUpdate(n))
+
+		// not attested attributes can change
+		n.SetAttributes(append(n.Attributes(), nodeInfo("Capacity", "100").Attributes()...)...)
+		require.NoError(t, v.VerifyAndUpdate(n))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		requireDenied(t, v.VerifyAndUpdate(nodeInfo("KYC", "passed", "Datacenter", "DC1")))
+	})
+
+	t.Run("changed attribute", func(t *testing.T) {
+		n := nodeInfo("KYC", "passed", "Datacenter", "DC1")
+		attest(authority, n, attrs)
+
+		n.Attributes()[1].SetValue("DC2")
+
+		requireDenied(t, v.VerifyAndUpdate(n))
+	})
+
+	t.Run("wrong authority", func(t *testing.T) {
+		other, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+
+		n := nodeInfo("KYC", "passed", "Datacenter", "DC1")
+		attest(other, n, attrs)
+
+		requireDenied(t, v.VerifyAndUpdate(n))
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		requireDenied(t, v.VerifyAndUpdate(nodeInfo(attestation.AttributeAttestation, "not hex")))
+	})
+
+	t.Run("all attributes", func(t *testing.T) {
+		v := attestation.New(attestation.Prm{
+			AuthorityKey: authority.PublicKey().Bytes(),
+		})
+
+		n := nodeInfo("KYC", "passed", "Price", "10")
+		attest(authority, n, nil)
+
+		require.NoError(t, v.VerifyAndUpdate(n))
+
+		n.Attributes()[1].SetValue("20")
+
+		requireDenied(t, v.VerifyAndUpdate(n))
+	})
+}
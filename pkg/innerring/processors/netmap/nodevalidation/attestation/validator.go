@@ -0,0 +1,63 @@
+package attestation
+
+import (
+	"crypto/elliptic"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// AttributeAttestation is a key of the node attribute which value is
+// a hex-encoded signature of the authority over the attested node
+// information (see SignedData).
+const AttributeAttestation = "Attestation"
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Binary public key of the authority.
+	//
+	// Must be a valid compressed or uncompressed P-256 key.
+	AuthorityKey []byte
+
+	// Keys of the attested node attributes.
+	//
+	// Optional: all attributes are attested if empty.
+	Attributes []string
+}
+
+// Validator is an utility that verifies the assertions about the
+// node attributes signed by the trusted authority (e.g. KYC or
+// datacenter certification), so only the nodes approved by the
+// authority are admitted to the permissioned network.
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	key *keys.PublicKey
+
+	attrs []string
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	key, err := keys.NewPublicKeyFromBytes(prm.AuthorityKey, elliptic.P256())
+	if err != nil {
+		panic(fmt.Sprintf("invalid authority key: %v", err))
+	}
+
+	return &Validator{
+		key:   key,
+		attrs: prm.Attributes,
+	}
+}
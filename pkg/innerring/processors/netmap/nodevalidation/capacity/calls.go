@@ -0,0 +1,89 @@
+package capacity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/network"
+)
+
+// VerifyAndUpdate probes the free space of the sampled node and rejects
+// n if its Capacity attribute value exceeds the probed one by more than
+// the tolerance. Node addresses are probed one by one until the first
+// successful response. Nodes that do not declare the capacity are not
+// probed.
+//
+// Rejection error is netmap.ValidationError with netmap.PolicyDenied reason
+// if capacity is over-declared or can not be probed, and with
+// netmap.InvalidInfo reason if n's attributes or addresses are incorrect.
+//
+// NodeInfo is not modified.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	if v.random() >= v.sampleRate {
+		return nil
+	}
+
+	var (
+		declared uint64
+		err      error
+	)
+
+	for _, a := range n.Attributes() {
+		if a.Key() == apinetmap.AttrCapacity {
+			declared, err = strconv.ParseUint(a.Value(), 10, 64)
+			if err != nil {
+				return netmap.ValidationError{
+					Reason: netmap.InvalidInfo,
+					Err:    fmt.Errorf("invalid capacity value: %w", err),
+				}
+			}
+
+			break
+		}
+	}
+
+	if declared == 0 {
+		return nil
+	}
+
+	var addrs network.AddressGroup
+
+	if err := addrs.FromIterator(n); err != nil {
+		return netmap.ValidationError{
+			Reason: netmap.InvalidInfo,
+			Err:    fmt.Errorf("could not parse network addresses: %w", err),
+		}
+	}
+
+	probed, err := v.probeGroup(addrs)
+	if err != nil {
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err:    fmt.Errorf("could not probe capacity: %w", err),
+		}
+	}
+
+	if float64(declared) > float64(probed)*(1+v.tolerance) {
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err: fmt.Errorf("declared capacity %d exceeds probed %d by more than %.2f%%",
+				declared, probed, 100*v.tolerance),
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) probeGroup(addrs network.AddressGroup) (res uint64, err error) {
+	err = errors.New("no addresses")
+
+	addrs.IterateAddresses(func(a network.Address) bool {
+		res, err = v.probe(a.HostAddr())
+		return err == nil
+	})
+
+	return
+}
@@ -0,0 +1,127 @@
+package capacity_test
+
+import (
+	"errors"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/capacity"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfo(capacity string, addrs ...string) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+	n.SetAddresses(addrs...)
+
+	if capacity != "" {
+		a := apinetmap.NewNodeAttribute()
+		a.SetKey(apinetmap.AttrCapacity)
+		a.SetValue(capacity)
+
+		n.SetAttributes(a)
+	}
+
+	return n
+}
+
+func requireReason(t *testing.T, err error, reason netmap.Reason) {
+	var vErr netmap.ValidationError
+
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, reason, vErr.Reason)
+}
+
+// probe reports free space of the nodes by address.
+type probe map[string]uint64
+
+func (p probe) free(addr string) (uint64, error) {
+	res, ok := p[addr]
+	if !ok {
+		return 0, errors.New("unreachable")
+	}
+
+	return res, nil
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	p := probe{
+		"10.0.0.1:8080": 100,
+		"10.0.0.2:8080": 200,
+	}
+
+	v := capacity.New(capacity.Prm{
+		Probe:      p.free,
+		SampleRate: 1,
+		Tolerance:  0.1,
+	})
+
+	t.Run("honest", func(t *testing.T) {
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("100", "/ip4/10.0.0.1/tcp/8080")))
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("50", "/ip4/10.0.0.1/tcp/8080")))
+
+		// within the tolerance
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("110", "/ip4/10.0.0.1/tcp/8080")))
+	})
+
+	t.Run("over-declaring", func(t *testing.T) {
+		requireReason(t, v.VerifyAndUpdate(nodeInfo("111", "/ip4/10.0.0.1/tcp/8080")), netmap.PolicyDenied)
+		requireReason(t, v.VerifyAndUpdate(nodeInfo("1000", "/ip4/10.0.0.2/tcp/8080")), netmap.PolicyDenied)
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		requireReason(t, v.VerifyAndUpdate(nodeInfo("100", "/ip4/10.0.0.3/tcp/8080")), netmap.PolicyDenied)
+
+		// the first reachable address is probed
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("200",
+			"/ip4/10.0.0.2/tcp/8080",
+			"/ip4/10.0.0.3/tcp/8080",
+		)))
+	})
+
+	t.Run("no capacity", func(t *testing.T) {
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("", "/ip4/10.0.0.3/tcp/8080")))
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("0", "/ip4/10.0.0.3/tcp/8080")))
+	})
+
+	t.Run("invalid info", func(t *testing.T) {
+		requireReason(t, v.VerifyAndUpdate(nodeInfo("many", "/ip4/10.0.0.1/tcp/8080")), netmap.InvalidInfo)
+		requireReason(t, v.VerifyAndUpdate(nodeInfo("100")), netmap.InvalidInfo)
+	})
+}
+
+func TestValidator_Sampling(t *testing.T) {
+	const sampleRate = 0.25
+
+	var (
+		probed int
+		rnd    float64
+	)
+
+	v := capacity.New(capacity.Prm{
+		Probe: func(string) (uint64, error) {
+			probed++
+			return 1, nil
+		},
+		SampleRate: sampleRate,
+		Random: func() float64 {
+			return rnd
+		},
+	})
+
+	over := nodeInfo("1000", "/ip4/10.0.0.1/tcp/8080")
+
+	for i := 0; i < 100; i++ {
+		rnd = float64(i) / 100
+
+		err := v.VerifyAndUpdate(over)
+
+		if rnd < sampleRate {
+			requireReason(t, err, netmap.PolicyDenied)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+
+	require.Equal(t, 25, probed)
+}
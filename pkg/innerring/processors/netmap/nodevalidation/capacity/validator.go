@@ -0,0 +1,83 @@
+package capacity
+
+import (
+	"math/rand"
+)
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Function that requests the node listening on the specified
+	// network address (host:port) to report its actual free space.
+	// Space must be measured in the units of the Capacity attribute.
+	//
+	// Must not be nil.
+	Probe func(addr string) (uint64, error)
+
+	// Fraction of the admissions to be probed.
+	//
+	// Must be in range (0; 1].
+	SampleRate float64
+
+	// Allowed relative excess of the declared capacity over the
+	// probed one (e.g. 0.1 allows the declared capacity to exceed
+	// the probed one by 10%).
+	//
+	// Must not be negative.
+	Tolerance float64
+
+	// Source of the random numbers uniformly distributed
+	// in [0; 1) used for sampling.
+	//
+	// Optional: math/rand.Float64 is used if not set.
+	Random func() float64
+}
+
+// Validator is an utility that cross-checks the declared capacity of
+// the sampled admitted nodes against the free space reported by the
+// nodes themselves, so over-declaring nodes are caught without probing
+// each of them.
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	probe func(string) (uint64, error)
+
+	sampleRate, tolerance float64
+
+	random func() float64
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	switch {
+	case prm.Probe == nil:
+		panic("capacity probe is not set")
+	case prm.SampleRate <= 0 || prm.SampleRate > 1:
+		panic("sample rate is out of range (0; 1]")
+	case prm.Tolerance < 0:
+		panic("negative capacity tolerance")
+	}
+
+	random := prm.Random
+	if random == nil {
+		random = rand.Float64
+	}
+
+	return &Validator{
+		probe:      prm.Probe,
+		sampleRate: prm.SampleRate,
+		tolerance:  prm.Tolerance,
+		random:     random,
+	}
+}
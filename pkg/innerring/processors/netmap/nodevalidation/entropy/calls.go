@@ -0,0 +1,59 @@
+package entropy
+
+import (
+	"fmt"
+	"math"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+)
+
+// VerifyAndUpdate rejects n if the Shannon entropy of at least one
+// checked attribute value exceeds the limit. Values shorter than
+// the min length are not checked.
+//
+// Rejection error is netmap.ValidationError with netmap.InvalidInfo reason.
+//
+// NodeInfo is not modified.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	for _, a := range n.Attributes() {
+		if _, ok := v.attrs[a.Key()]; !ok {
+			continue
+		}
+
+		if e, ok := v.entropy(a.Value()); ok && e > v.max {
+			return netmap.ValidationError{
+				Reason: netmap.InvalidInfo,
+				Err: fmt.Errorf("value of attribute %s looks random: %.2f bits per character exceeds %.2f",
+					a.Key(), e, v.max),
+			}
+		}
+	}
+
+	return nil
+}
+
+// entropy returns Shannon entropy of the string characters in bits per
+// character. Returns false if the string is shorter than the min length.
+func (v *Validator) entropy(s string) (float64, bool) {
+	mFreq := make(map[rune]int)
+	total := 0
+
+	for _, r := range s {
+		mFreq[r]++
+		total++
+	}
+
+	if total < v.minLen {
+		return 0, false
+	}
+
+	var e float64
+
+	for _, cnt := range mFreq {
+		p := float64(cnt) / float64(total)
+		e -= p * math.Log2(p)
+	}
+
+	return e, true
+}
@@ -0,0 +1,76 @@
+package entropy_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/entropy"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfo(kv ...string) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+
+	as := make([]*apinetmap.NodeAttribute, 0, len(kv)/2)
+
+	for i := 0; i < len(kv); i += 2 {
+		a := apinetmap.NewNodeAttribute()
+		a.SetKey(kv[i])
+		a.SetValue(kv[i+1])
+
+		as = append(as, a)
+	}
+
+	n.SetAttributes(as...)
+
+	return n
+}
+
+func randomString(t *testing.T, size int) string {
+	data := make([]byte, size)
+
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	v := entropy.New(entropy.Prm{
+		MaxBitsPerChar: 4.5,
+		Attributes:     []string{"Description", "Operator"},
+	})
+
+	t.Run("normal values", func(t *testing.T) {
+		for _, val := range []string{
+			"Storage node in the Moscow datacenter",
+			"NeoFS community operated storage node",
+			"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			"Узел хранения в московском датацентре",
+		} {
+			require.NoError(t, v.VerifyAndUpdate(nodeInfo("Description", val)))
+		}
+	})
+
+	t.Run("random values", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			err := v.VerifyAndUpdate(nodeInfo("Operator", randomString(t, 64)))
+
+			var vErr netmap.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, netmap.InvalidInfo, vErr.Reason)
+		}
+	})
+
+	t.Run("not checked", func(t *testing.T) {
+		// other attributes
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("Key", randomString(t, 64))))
+
+		// short values
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("Operator", randomString(t, 9))))
+	})
+}
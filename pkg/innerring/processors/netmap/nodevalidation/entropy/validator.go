@@ -0,0 +1,74 @@
+package entropy
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Max Shannon entropy of the attribute value in bits per character.
+	//
+	// Must be positive.
+	MaxBitsPerChar float64
+
+	// Keys of the checked free-form node attributes.
+	//
+	// Must not be empty.
+	Attributes []string
+
+	// Min length of the checked value. Entropy estimation of the
+	// short values is meaningless, so they are not checked.
+	//
+	// Optional: DefaultMinLength is used if not positive.
+	MinLength int
+}
+
+// DefaultMinLength is a default min length of the checked attribute value.
+const DefaultMinLength = 16
+
+// Validator is an utility that heuristically detects junk values of
+// the free-form node attributes (e.g. random strings used to evade
+// deduplication or quotas) by their high Shannon entropy.
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	max float64
+
+	attrs map[string]struct{}
+
+	minLen int
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	switch {
+	case prm.MaxBitsPerChar <= 0:
+		panic("max entropy must be positive")
+	case len(prm.Attributes) == 0:
+		panic("checked attributes are not set")
+	}
+
+	attrs := make(map[string]struct{}, len(prm.Attributes))
+	for i := range prm.Attributes {
+		attrs[prm.Attributes[i]] = struct{}{}
+	}
+
+	minLen := prm.MinLength
+	if minLen <= 0 {
+		minLen = DefaultMinLength
+	}
+
+	return &Validator{
+		max:    prm.MaxBitsPerChar,
+		attrs:  attrs,
+		minLen: minLen,
+	}
+}
@@ -0,0 +1,46 @@
+package exclusion
+
+import (
+	"fmt"
+	"strings"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+)
+
+// VerifyAndUpdate checks that n declares no more than one attribute
+// of each mutually exclusive group. Attribute is declared if n has
+// the attribute with its key regardless of the value.
+//
+// Rejection error is netmap.ValidationError with netmap.InvalidInfo reason
+// which names the conflicting attributes.
+//
+// NodeInfo is not modified.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	as := n.Attributes()
+
+	declared := make(map[string]struct{}, len(as))
+	for i := range as {
+		declared[as[i].Key()] = struct{}{}
+	}
+
+	for _, g := range v.groups {
+		var conflict []string
+
+		for _, key := range g {
+			if _, ok := declared[key]; ok {
+				conflict = append(conflict, key)
+			}
+		}
+
+		if len(conflict) > 1 {
+			return netmap.ValidationError{
+				Reason: netmap.InvalidInfo,
+				Err: fmt.Errorf("mutually exclusive attributes are declared: %s",
+					strings.Join(conflict, ", ")),
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package exclusion_test
+
+import (
+	"errors"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/exclusion"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfo(kv ...string) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+
+	as := make([]*apinetmap.NodeAttribute, 0, len(kv)/2)
+
+	for i := 0; i < len(kv); i += 2 {
+		a := apinetmap.NewNodeAttribute()
+		a.SetKey(kv[i])
+		a.SetValue(kv[i+1])
+
+		as = append(as, a)
+	}
+
+	n.SetAttributes(as...)
+
+	return n
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	v := exclusion.New(exclusion.Prm{
+		Groups: [][]string{
+			{"ArchiveOnly", "HotTier"},
+			{"Gateway", "Relay", "Storage"},
+		},
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		for _, n := range []*apinetmap.NodeInfo{
+			nodeInfo(),
+			nodeInfo("Price", "10"),
+			nodeInfo("ArchiveOnly", "true", "Price", "10"),
+			nodeInfo("HotTier", "true", "Relay", "true"),
+			nodeInfo("ArchiveOnly", "true", "Storage", "true", "Capacity", "100"),
+		} {
+			require.NoError(t, v.VerifyAndUpdate(n))
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			n        *apinetmap.NodeInfo
+			conflict string
+		}{
+			{
+				name:     "pair",
+				n:        nodeInfo("ArchiveOnly", "true", "HotTier", "true"),
+				conflict: "ArchiveOnly, HotTier",
+			},
+			{
+				name:     "regardless of values",
+				n:        nodeInfo("HotTier", "false", "Price", "10", "ArchiveOnly", ""),
+				conflict: "ArchiveOnly, HotTier",
+			},
+			{
+				name:     "part of group",
+				n:        nodeInfo("Storage", "true", "Gateway", "true"),
+				conflict: "Gateway, Storage",
+			},
+			{
+				name:     "whole group",
+				n:        nodeInfo("Relay", "1", "Gateway", "1", "Storage", "1"),
+				conflict: "Gateway, Relay, Storage",
+			},
+			{
+				name:     "second group",
+				n:        nodeInfo("ArchiveOnly", "true", "Relay", "true", "Gateway", "true"),
+				conflict: "Gateway, Relay",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				err := v.VerifyAndUpdate(tc.n)
+
+				var vErr netmap.ValidationError
+
+				require.True(t, errors.As(err, &vErr))
+				require.Equal(t, netmap.InvalidInfo, vErr.Reason)
+				require.Contains(t, err.Error(), tc.conflict)
+			})
+		}
+	})
+}
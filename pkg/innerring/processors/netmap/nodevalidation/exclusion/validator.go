@@ -0,0 +1,63 @@
+package exclusion
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Groups of the mutually exclusive node attribute keys:
+	// node must not declare more than one attribute of each group.
+	//
+	// Must not be empty. Each group must contain at least two
+	// different keys.
+	Groups [][]string
+}
+
+// Validator is an utility that verifies that the node does not
+// declare the attributes which are not allowed to be combined
+// (e.g. archive-only and hot-tier node).
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	groups [][]string
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	if len(prm.Groups) == 0 {
+		panic("mutually exclusive groups are not set")
+	}
+
+	groups := make([][]string, len(prm.Groups))
+
+	for i, g := range prm.Groups {
+		if len(g) < 2 {
+			panic("mutually exclusive group must contain at least two keys")
+		}
+
+		uniq := make(map[string]struct{}, len(g))
+
+		for _, key := range g {
+			if _, ok := uniq[key]; ok {
+				panic("duplicated attribute key " + key + " in mutually exclusive group")
+			}
+
+			uniq[key] = struct{}{}
+		}
+
+		groups[i] = append([]string(nil), g...)
+	}
+
+	return &Validator{
+		groups: groups,
+	}
+}
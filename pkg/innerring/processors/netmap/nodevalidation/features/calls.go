@@ -0,0 +1,87 @@
+package features
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"go.uber.org/zap"
+)
+
+// VerifyAndUpdate checks that n declares all required features
+// in the AttributeFeatures attribute.
+//
+// If n declares deprecated features, warning is logged and
+// AttributeDeprecationWarning attribute with the list of such features
+// is added to n. Attribute value set by the node itself is discarded.
+//
+// Rejection error is netmap.ValidationError with netmap.PolicyDenied reason.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	var declared []string
+
+	as := n.Attributes()
+
+	for i := 0; i < len(as); i++ { // don't use range, slice mutates in body
+		switch as[i].Key() {
+		case AttributeFeatures:
+			declared = parseFeatures(as[i].Value())
+		case AttributeDeprecationWarning:
+			as = append(as[:i], as[i+1:]...)
+			i--
+		}
+	}
+
+	mDeclared := make(map[string]struct{}, len(declared))
+	for i := range declared {
+		mDeclared[declared[i]] = struct{}{}
+	}
+
+	for _, f := range v.required {
+		if _, ok := mDeclared[f]; !ok {
+			return netmap.ValidationError{
+				Reason: netmap.PolicyDenied,
+				Err:    fmt.Errorf("required feature %s is not supported", f),
+			}
+		}
+	}
+
+	var deprecated []string
+
+	for i := range declared {
+		if _, ok := v.deprecated[declared[i]]; ok {
+			deprecated = append(deprecated, declared[i])
+		}
+	}
+
+	if len(deprecated) > 0 {
+		v.log.Warn("node declares deprecated features",
+			zap.String("key", hex.EncodeToString(n.PublicKey())),
+			zap.Strings("features", deprecated),
+		)
+
+		a := apinetmap.NewNodeAttribute()
+		a.SetKey(AttributeDeprecationWarning)
+		a.SetValue(strings.Join(deprecated, ","))
+
+		as = append(as, a)
+	}
+
+	n.SetAttributes(as...)
+
+	return nil
+}
+
+func parseFeatures(s string) []string {
+	fs := strings.Split(s, ",")
+	res := fs[:0]
+
+	for i := range fs {
+		if f := strings.TrimSpace(fs[i]); f != "" {
+			res = append(res, f)
+		}
+	}
+
+	return res
+}
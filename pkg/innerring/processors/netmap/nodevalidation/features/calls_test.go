@@ -0,0 +1,91 @@
+package features_test
+
+import (
+	"errors"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/features"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfo(kv ...string) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+	n.SetPublicKey([]byte{1, 2, 3})
+
+	as := make([]*apinetmap.NodeAttribute, 0, len(kv)/2)
+
+	for i := 0; i < len(kv); i += 2 {
+		a := apinetmap.NewNodeAttribute()
+		a.SetKey(kv[i])
+		a.SetValue(kv[i+1])
+
+		as = append(as, a)
+	}
+
+	n.SetAttributes(as...)
+
+	return n
+}
+
+func attributeValue(n *apinetmap.NodeInfo, key string) (string, bool) {
+	for _, a := range n.Attributes() {
+		if a.Key() == key {
+			return a.Value(), true
+		}
+	}
+
+	return "", false
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	v := features.New(features.Prm{
+		Required:   []string{"tz", "split"},
+		Deprecated: []string{"legacy_acl", "v1_sig"},
+	})
+
+	t.Run("compatible", func(t *testing.T) {
+		n := nodeInfo(features.AttributeFeatures, "split, tz,eacl")
+
+		require.NoError(t, v.VerifyAndUpdate(n))
+
+		_, ok := attributeValue(n, features.AttributeDeprecationWarning)
+		require.False(t, ok)
+	})
+
+	t.Run("missing required", func(t *testing.T) {
+		for _, n := range []*apinetmap.NodeInfo{
+			nodeInfo(features.AttributeFeatures, "tz"),
+			nodeInfo("Price", "10"),
+		} {
+			err := v.VerifyAndUpdate(n)
+
+			var vErr netmap.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, netmap.PolicyDenied, vErr.Reason)
+		}
+	})
+
+	t.Run("deprecated", func(t *testing.T) {
+		n := nodeInfo(features.AttributeFeatures, "tz,v1_sig,split,legacy_acl")
+
+		require.NoError(t, v.VerifyAndUpdate(n))
+
+		val, ok := attributeValue(n, features.AttributeDeprecationWarning)
+		require.True(t, ok)
+		require.Equal(t, "v1_sig,legacy_acl", val)
+	})
+
+	t.Run("warning set by node", func(t *testing.T) {
+		n := nodeInfo(
+			features.AttributeFeatures, "tz,split",
+			features.AttributeDeprecationWarning, "none",
+		)
+
+		require.NoError(t, v.VerifyAndUpdate(n))
+
+		_, ok := attributeValue(n, features.AttributeDeprecationWarning)
+		require.False(t, ok)
+	})
+}
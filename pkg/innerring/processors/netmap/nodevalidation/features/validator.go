@@ -0,0 +1,79 @@
+package features
+
+import (
+	"go.uber.org/zap"
+)
+
+const (
+	// AttributeFeatures is a key of the node attribute which value is
+	// a comma-separated list of the protocol features supported by the node.
+	AttributeFeatures = "Features"
+
+	// AttributeDeprecationWarning is a key of the node attribute which value
+	// is a comma-separated list of the deprecated features declared by the node.
+	//
+	// Attribute is set by the Validator.
+	AttributeDeprecationWarning = "DeprecatedFeatures"
+)
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Features that must be supported by the node.
+	Required []string
+
+	// Features that are still supported, but
+	// going to be removed from the protocol.
+	Deprecated []string
+
+	// Logger of the deprecated feature warnings.
+	//
+	// Optional: warnings are not logged if nil.
+	Log *zap.Logger
+}
+
+// Validator is an utility that verifies compatibility of the protocol
+// features supported by the node with the ones required by the network.
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	required []string
+
+	deprecated map[string]struct{}
+
+	log *zap.Logger
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	if len(prm.Required) == 0 && len(prm.Deprecated) == 0 {
+		panic("neither required nor deprecated features are set")
+	}
+
+	deprecated := make(map[string]struct{}, len(prm.Deprecated))
+	for i := range prm.Deprecated {
+		deprecated[prm.Deprecated[i]] = struct{}{}
+	}
+
+	log := prm.Log
+	if log == nil {
+		log = zap.NewNop()
+	}
+
+	return &Validator{
+		required:   prm.Required,
+		deprecated: deprecated,
+		log:        log,
+	}
+}
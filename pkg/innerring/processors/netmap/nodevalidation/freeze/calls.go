@@ -0,0 +1,27 @@
+package freeze
+
+import (
+	"errors"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+)
+
+var errFrozen = errors.New("new node admissions are frozen")
+
+// VerifyAndUpdate rejects n if the admission freeze is active
+// and n is not a network member.
+//
+// Rejection error is netmap.ValidationError with netmap.PolicyDenied reason.
+//
+// NodeInfo is not modified.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	if v.frozen() && !v.known(n.PublicKey()) {
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err:    errFrozen,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package freeze_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/freeze"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfoWithKey(key []byte) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+	n.SetPublicKey(key)
+
+	return n
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	var frozen bool
+
+	memberKey := []byte{1, 2, 3}
+
+	v := freeze.New(freeze.Prm{
+		Frozen: func() bool { return frozen },
+		Known: func(key []byte) bool {
+			return bytes.Equal(key, memberKey)
+		},
+	})
+
+	member := nodeInfoWithKey(memberKey)
+	candidate := nodeInfoWithKey([]byte{4, 5, 6})
+
+	t.Run("not frozen", func(t *testing.T) {
+		frozen = false
+
+		require.NoError(t, v.VerifyAndUpdate(member))
+		require.NoError(t, v.VerifyAndUpdate(candidate))
+	})
+
+	t.Run("frozen", func(t *testing.T) {
+		frozen = true
+
+		require.NoError(t, v.VerifyAndUpdate(member))
+
+		err := v.VerifyAndUpdate(candidate)
+
+		var vErr netmap.ValidationError
+		require.True(t, errors.As(err, &vErr))
+		require.Equal(t, netmap.PolicyDenied, vErr.Reason)
+	})
+
+	t.Run("unfrozen", func(t *testing.T) {
+		frozen = false
+
+		require.NoError(t, v.VerifyAndUpdate(candidate))
+	})
+}
@@ -0,0 +1,54 @@
+package freeze
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Predicate that reports whether new node
+	// admissions are frozen at the moment.
+	//
+	// Must not be nil.
+	Frozen func() bool
+
+	// Predicate that reports whether the node with
+	// the given public key is already a network member.
+	//
+	// Must not be nil.
+	Known func(key []byte) bool
+}
+
+// Validator is an utility that rejects registrations of the new
+// nodes while the admission freeze is active. Nodes that are already
+// network members are able to update their information.
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	frozen func() bool
+
+	known func([]byte) bool
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	switch {
+	case prm.Frozen == nil:
+		panic("freeze predicate is not set")
+	case prm.Known == nil:
+		panic("network member predicate is not set")
+	}
+
+	return &Validator{
+		frozen: prm.Frozen,
+		known:  prm.Known,
+	}
+}
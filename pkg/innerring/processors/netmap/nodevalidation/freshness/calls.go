@@ -0,0 +1,55 @@
+package freshness
+
+import (
+	"fmt"
+	"strconv"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+)
+
+// VerifyAndUpdate rejects n if the epoch declared in AttributeEpoch
+// lags behind the current epoch by more than the limit. Nodes ahead
+// of the current epoch are accepted.
+//
+// Rejection error is netmap.ValidationError with netmap.PolicyDenied reason
+// if the node lags behind and with netmap.InvalidInfo reason if the attribute
+// is missing or malformed.
+//
+// NodeInfo is not modified.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	declared, err := declaredEpoch(n)
+	if err != nil {
+		return netmap.ValidationError{
+			Reason: netmap.InvalidInfo,
+			Err:    err,
+		}
+	}
+
+	if current := v.currentEpoch(); current > declared && current-declared > v.maxLag {
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err: fmt.Errorf("declared epoch %d lags behind the current epoch %d by more than %d",
+				declared, current, v.maxLag),
+		}
+	}
+
+	return nil
+}
+
+func declaredEpoch(n *apinetmap.NodeInfo) (uint64, error) {
+	for _, a := range n.Attributes() {
+		if a.Key() != AttributeEpoch {
+			continue
+		}
+
+		epoch, err := strconv.ParseUint(a.Value(), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value of attribute %s: %w", AttributeEpoch, err)
+		}
+
+		return epoch, nil
+	}
+
+	return 0, fmt.Errorf("missing attribute %s", AttributeEpoch)
+}
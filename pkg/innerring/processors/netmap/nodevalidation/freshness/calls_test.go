@@ -0,0 +1,72 @@
+package freshness_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/freshness"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfo(kv ...string) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+
+	as := make([]*apinetmap.NodeAttribute, 0, len(kv)/2)
+
+	for i := 0; i < len(kv); i += 2 {
+		a := apinetmap.NewNodeAttribute()
+		a.SetKey(kv[i])
+		a.SetValue(kv[i+1])
+
+		as = append(as, a)
+	}
+
+	n.SetAttributes(as...)
+
+	return n
+}
+
+func nodeInfoWithEpoch(epoch uint64) *apinetmap.NodeInfo {
+	return nodeInfo(freshness.AttributeEpoch, strconv.FormatUint(epoch, 10))
+}
+
+func requireReason(t *testing.T, err error, reason netmap.Reason) {
+	var vErr netmap.ValidationError
+
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, reason, vErr.Reason)
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	const current = 100
+
+	v := freshness.New(freshness.Prm{
+		MaxLag:       2,
+		CurrentEpoch: func() uint64 { return current },
+	})
+
+	t.Run("in sync", func(t *testing.T) {
+		require.NoError(t, v.VerifyAndUpdate(nodeInfoWithEpoch(current)))
+
+		// node is ahead of the Inner Ring
+		require.NoError(t, v.VerifyAndUpdate(nodeInfoWithEpoch(current+1)))
+	})
+
+	t.Run("slightly lagging", func(t *testing.T) {
+		require.NoError(t, v.VerifyAndUpdate(nodeInfoWithEpoch(current-1)))
+		require.NoError(t, v.VerifyAndUpdate(nodeInfoWithEpoch(current-2)))
+	})
+
+	t.Run("far lagging", func(t *testing.T) {
+		requireReason(t, v.VerifyAndUpdate(nodeInfoWithEpoch(current-3)), netmap.PolicyDenied)
+		requireReason(t, v.VerifyAndUpdate(nodeInfoWithEpoch(0)), netmap.PolicyDenied)
+	})
+
+	t.Run("invalid attribute", func(t *testing.T) {
+		requireReason(t, v.VerifyAndUpdate(nodeInfo()), netmap.InvalidInfo)
+		requireReason(t, v.VerifyAndUpdate(nodeInfo(freshness.AttributeEpoch, "-1")), netmap.InvalidInfo)
+	})
+}
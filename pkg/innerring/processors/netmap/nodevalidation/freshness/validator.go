@@ -0,0 +1,52 @@
+package freshness
+
+// AttributeEpoch is a key of the node attribute which value is a decimal
+// number of the latest epoch known to the node at the moment of registration.
+const AttributeEpoch = "Epoch"
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Max number of epochs the epoch declared by
+	// the node can lag behind the current one.
+	MaxLag uint64
+
+	// Function that returns the current epoch number.
+	//
+	// Must not be nil.
+	CurrentEpoch func() uint64
+}
+
+// Validator is an utility that filters out the nodes which do not keep
+// up with the consensus: the ones which declare the epoch that lags
+// behind the current one too much.
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	maxLag uint64
+
+	currentEpoch func() uint64
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	if prm.CurrentEpoch == nil {
+		panic("current epoch function is not set")
+	}
+
+	return &Validator{
+		maxLag:       prm.MaxLag,
+		currentEpoch: prm.CurrentEpoch,
+	}
+}
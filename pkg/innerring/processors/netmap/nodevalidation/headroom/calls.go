@@ -0,0 +1,88 @@
+package headroom
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+)
+
+// VerifyAndUpdate rejects n if the free network capacity is below the
+// minimum ratio and the Capacity attribute value of n is lower than the
+// current threshold. Nodes that do not declare the capacity are treated
+// as the nodes with zero capacity. All nodes are admitted while the
+// network is healthy or the statistics are not available.
+//
+// Rejection error is netmap.ValidationError with netmap.PolicyDenied reason
+// if capacity is not enough, and with netmap.InvalidInfo reason if n's
+// Capacity attribute is incorrect.
+//
+// NodeInfo is not modified.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	threshold := v.threshold()
+	if threshold == 0 {
+		return nil
+	}
+
+	var declared uint64
+
+	for _, a := range n.Attributes() {
+		if a.Key() == apinetmap.AttrCapacity {
+			var err error
+
+			declared, err = strconv.ParseUint(a.Value(), 10, 64)
+			if err != nil {
+				return netmap.ValidationError{
+					Reason: netmap.InvalidInfo,
+					Err:    fmt.Errorf("invalid capacity value: %w", err),
+				}
+			}
+
+			break
+		}
+	}
+
+	if declared < threshold {
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err: fmt.Errorf("network free capacity is low, declared capacity %d is less than required %d",
+				declared, threshold),
+		}
+	}
+
+	return nil
+}
+
+// threshold returns minimum capacity of the admitted node
+// according to the current network statistics.
+func (v *Validator) threshold() uint64 {
+	used, total := v.stats()
+	if total == 0 {
+		return 0
+	}
+
+	free := 0.0
+	if used < total {
+		free = float64(total-used) / float64(total)
+	}
+
+	if free >= v.minFreeRatio {
+		return 0
+	}
+
+	pressure := (v.minFreeRatio - free) / v.minFreeRatio
+
+	res := math.Ceil(pressure * float64(v.maxThreshold))
+
+	switch {
+	case res >= float64(v.maxThreshold):
+		return v.maxThreshold
+	case res < 1:
+		// reject the nodes without capacity under any pressure
+		return 1
+	}
+
+	return uint64(res)
+}
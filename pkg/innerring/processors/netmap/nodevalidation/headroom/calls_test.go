@@ -0,0 +1,116 @@
+package headroom_test
+
+import (
+	"errors"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/headroom"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfo(capacity string) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+
+	if capacity != "" {
+		a := apinetmap.NewNodeAttribute()
+		a.SetKey(apinetmap.AttrCapacity)
+		a.SetValue(capacity)
+
+		n.SetAttributes(a)
+	}
+
+	return n
+}
+
+func requireReason(t *testing.T, err error, reason netmap.Reason) {
+	var vErr netmap.ValidationError
+
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, reason, vErr.Reason)
+}
+
+// networkStats is a network capacity statistics that can be changed.
+type networkStats struct {
+	used, total uint64
+}
+
+func (s *networkStats) get() (uint64, uint64) {
+	return s.used, s.total
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	stats := new(networkStats)
+
+	v := headroom.New(headroom.Prm{
+		MinFreeRatio: 0.2,
+		Stats:        stats.get,
+		MaxThreshold: 1000,
+	})
+
+	t.Run("healthy network", func(t *testing.T) {
+		for _, used := range []uint64{0, 50, 80} {
+			stats.used, stats.total = used, 100
+
+			for _, capacity := range []string{"", "0", "1", "1000"} {
+				require.NoError(t, v.VerifyAndUpdate(nodeInfo(capacity)), "used %d, capacity %s", used, capacity)
+			}
+		}
+	})
+
+	t.Run("no statistics", func(t *testing.T) {
+		stats.used, stats.total = 0, 0
+
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("")))
+	})
+
+	t.Run("low free capacity", func(t *testing.T) {
+		// free ratio is 0.1, so the threshold is a half of the maximum one
+		stats.used, stats.total = 90, 100
+
+		for _, capacity := range []string{"500", "501", "10000"} {
+			require.NoError(t, v.VerifyAndUpdate(nodeInfo(capacity)), capacity)
+		}
+
+		for _, capacity := range []string{"", "0", "499"} {
+			requireReason(t, v.VerifyAndUpdate(nodeInfo(capacity)), netmap.PolicyDenied)
+		}
+	})
+
+	t.Run("full network", func(t *testing.T) {
+		for _, used := range []uint64{100, 150} {
+			stats.used, stats.total = used, 100
+
+			require.NoError(t, v.VerifyAndUpdate(nodeInfo("1000")))
+			requireReason(t, v.VerifyAndUpdate(nodeInfo("999")), netmap.PolicyDenied)
+		}
+	})
+
+	t.Run("threshold follows the pressure", func(t *testing.T) {
+		n := nodeInfo("600")
+
+		stats.used, stats.total = 90, 100
+		require.NoError(t, v.VerifyAndUpdate(n))
+
+		stats.used = 99
+		requireReason(t, v.VerifyAndUpdate(n), netmap.PolicyDenied)
+
+		stats.used = 70
+		require.NoError(t, v.VerifyAndUpdate(n))
+	})
+
+	t.Run("invalid capacity", func(t *testing.T) {
+		stats.used, stats.total = 90, 100
+
+		requireReason(t, v.VerifyAndUpdate(nodeInfo("-1")), netmap.InvalidInfo)
+		requireReason(t, v.VerifyAndUpdate(nodeInfo("many")), netmap.InvalidInfo)
+	})
+
+	t.Run("invalid capacity in healthy network", func(t *testing.T) {
+		// capacity is not checked at all
+		stats.used, stats.total = 0, 100
+
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("many")))
+	})
+}
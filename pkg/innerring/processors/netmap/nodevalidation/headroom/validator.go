@@ -0,0 +1,70 @@
+package headroom
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Ratio of the free network capacity to the total one below
+	// which the network is considered to be under pressure.
+	//
+	// Must be in range (0; 1].
+	MinFreeRatio float64
+
+	// Function that returns used and total capacity of the network.
+	// Capacity must be measured in the units of the Capacity attribute.
+	// Zero total capacity means that the statistics are not available.
+	//
+	// Must not be nil.
+	Stats func() (used, total uint64)
+
+	// Minimum capacity of the admitted node when the network has
+	// no free capacity at all.
+	//
+	// Must not be zero.
+	MaxThreshold uint64
+}
+
+// Validator is an utility that adapts the admission of the nodes
+// to the pressure of the network: while free network capacity is
+// critically low, nodes that do not declare enough capacity are
+// rejected, so high-capacity nodes are admitted in the first place.
+//
+// Capacity threshold grows linearly from zero at the minimum free ratio
+// to the maximum threshold when the network is full.
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	minFreeRatio float64
+
+	stats func() (uint64, uint64)
+
+	maxThreshold uint64
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	switch {
+	case prm.MinFreeRatio <= 0 || prm.MinFreeRatio > 1:
+		panic("minimum free ratio is out of range (0; 1]")
+	case prm.Stats == nil:
+		panic("network capacity statistics are not set")
+	case prm.MaxThreshold == 0:
+		panic("zero maximum capacity threshold")
+	}
+
+	return &Validator{
+		minFreeRatio: prm.MinFreeRatio,
+		stats:        prm.Stats,
+		maxThreshold: prm.MaxThreshold,
+	}
+}
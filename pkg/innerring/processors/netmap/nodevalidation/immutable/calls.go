@@ -0,0 +1,120 @@
+package immutable
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+)
+
+// VerifyAndUpdate checks that n does not change the immutable attributes
+// declared by the node at the previous registration. Absent attribute
+// is treated as an attribute with an empty value.
+//
+// If n contains the override attribute with "true" value,
+// changes are allowed. The override attribute is removed from n.
+//
+// Attributes of n are not remembered until Commit is called.
+//
+// Rejection error is netmap.ValidationError with netmap.InvalidInfo reason.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	var override bool
+
+	as := n.Attributes()
+	mValues := make(map[string]string, len(v.attrs))
+
+	for i := 0; i < len(as); i++ { // don't use range, slice mutates in body
+		if as[i].Key() == v.overrideAttr {
+			override = as[i].Value() == "true"
+
+			as = append(as[:i], as[i+1:]...)
+			i--
+
+			continue
+		}
+
+		for _, key := range v.attrs {
+			if as[i].Key() == key {
+				mValues[key] = as[i].Value()
+				break
+			}
+		}
+	}
+
+	keyString := hex.EncodeToString(n.PublicKey())
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	if prevValues, ok := v.mNodes.Get(keyString); ok && !override {
+		prev := prevValues.(map[string]string)
+
+		for _, key := range v.attrs {
+			if prev[key] != mValues[key] {
+				return netmap.ValidationError{
+					Reason: netmap.InvalidInfo,
+					Err: fmt.Errorf("immutable attribute %s changed from %q to %q",
+						key, prev[key], mValues[key]),
+				}
+			}
+		}
+	}
+
+	v.pending.Add(keyString, mValues)
+
+	n.SetAttributes(as...)
+
+	return nil
+}
+
+// Commit remembers immutable attributes of n verified by the last
+// VerifyAndUpdate call with the same node, so the next registrations
+// of the node must not change them.
+//
+// Implements netmap.NodeCommitter.
+func (v *Validator) Commit(n *apinetmap.NodeInfo) {
+	keyString := hex.EncodeToString(n.PublicKey())
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	mValues, ok := v.pending.Peek(keyString)
+	if !ok {
+		return
+	}
+
+	v.pending.Remove(keyString)
+	v.mNodes.Add(keyString, mValues)
+}
+
+// Prune forgets immutable attributes of the nodes absent in nm.
+// Forgotten nodes are not reported as evicted.
+//
+// Implements netmap.NodePruner.
+func (v *Validator) Prune(nm *apinetmap.Netmap) {
+	mNetmap := make(map[string]struct{}, len(nm.Nodes))
+
+	for i := range nm.Nodes {
+		mNetmap[hex.EncodeToString(nm.Nodes[i].PublicKey())] = struct{}{}
+	}
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	// error is returned for non-positive size only
+	mNodes, _ := simplelru.NewLRU(v.maxNodes, v.onEvict)
+
+	// keys are ordered from the oldest to the newest
+	for _, key := range v.mNodes.Keys() {
+		if _, ok := mNetmap[key.(string)]; !ok {
+			continue
+		}
+
+		mValues, _ := v.mNodes.Peek(key)
+		mNodes.Add(key, mValues)
+	}
+
+	v.mNodes = mNodes
+}
@@ -0,0 +1,129 @@
+package immutable_test
+
+import (
+	"errors"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/immutable"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfo(kv ...string) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+	n.SetPublicKey([]byte{1, 2, 3})
+
+	as := make([]*apinetmap.NodeAttribute, 0, len(kv)/2)
+
+	for i := 0; i < len(kv); i += 2 {
+		a := apinetmap.NewNodeAttribute()
+		a.SetKey(kv[i])
+		a.SetValue(kv[i+1])
+
+		as = append(as, a)
+	}
+
+	n.SetAttributes(as...)
+
+	return n
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	v := immutable.New(immutable.Prm{
+		Attributes: []string{apinetmap.AttrUNLOCODE},
+	})
+
+	// verifies n and commits it if accepted, like the netmap.Processor does
+	accept := func(v *immutable.Validator, n *apinetmap.NodeInfo) error {
+		err := v.VerifyAndUpdate(n)
+		if err == nil {
+			v.Commit(n)
+		}
+
+		return err
+	}
+
+	// first registration is always allowed
+	require.NoError(t, accept(v, nodeInfo(apinetmap.AttrUNLOCODE, "RU MOW", "Price", "10")))
+
+	t.Run("unchanged", func(t *testing.T) {
+		require.NoError(t, accept(v, nodeInfo(apinetmap.AttrUNLOCODE, "RU MOW", "Price", "20")))
+	})
+
+	t.Run("changed without override", func(t *testing.T) {
+		err := accept(v, nodeInfo(apinetmap.AttrUNLOCODE, "RU LED"))
+
+		var vErr netmap.ValidationError
+		require.True(t, errors.As(err, &vErr))
+		require.Equal(t, netmap.InvalidInfo, vErr.Reason)
+
+		// removal is a change too
+		require.Error(t, accept(v, nodeInfo("Price", "20")))
+	})
+
+	t.Run("changed with override", func(t *testing.T) {
+		n := nodeInfo(apinetmap.AttrUNLOCODE, "RU LED", immutable.DefaultOverrideAttribute, "true")
+
+		require.NoError(t, accept(v, n))
+
+		// override attribute is not propagated to the network map
+		require.Len(t, n.Attributes(), 1)
+		require.Equal(t, apinetmap.AttrUNLOCODE, n.Attributes()[0].Key())
+
+		// new value is remembered
+		require.NoError(t, accept(v, nodeInfo(apinetmap.AttrUNLOCODE, "RU LED")))
+		require.Error(t, accept(v, nodeInfo(apinetmap.AttrUNLOCODE, "RU MOW")))
+	})
+
+	t.Run("not committed", func(t *testing.T) {
+		// node is rejected later in the chain
+		n := nodeInfo(apinetmap.AttrUNLOCODE, "RU MOW", immutable.DefaultOverrideAttribute, "true")
+		require.NoError(t, v.VerifyAndUpdate(n))
+
+		// value is not changed
+		require.NoError(t, accept(v, nodeInfo(apinetmap.AttrUNLOCODE, "RU LED")))
+		require.Error(t, accept(v, nodeInfo(apinetmap.AttrUNLOCODE, "RU MOW")))
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		other := nodeInfo(apinetmap.AttrUNLOCODE, "RU MOW")
+		other.SetPublicKey([]byte{4, 5, 6})
+
+		require.NoError(t, accept(v, other))
+
+		nm, err := apinetmap.NewNetmap(apinetmap.NodesFromInfo([]apinetmap.NodeInfo{*other}))
+		require.NoError(t, err)
+
+		v.Prune(nm)
+
+		// node absent in the network map is forgotten
+		require.NoError(t, accept(v, nodeInfo(apinetmap.AttrUNLOCODE, "RU MOW")))
+
+		changed := nodeInfo(apinetmap.AttrUNLOCODE, "RU LED")
+		changed.SetPublicKey(other.PublicKey())
+
+		require.Error(t, accept(v, changed))
+	})
+
+	t.Run("max nodes", func(t *testing.T) {
+		var evicted int
+
+		v := immutable.New(immutable.Prm{
+			Attributes: []string{apinetmap.AttrUNLOCODE},
+			MaxNodes:   1,
+			OnEvict:    func() { evicted++ },
+		})
+
+		require.NoError(t, accept(v, nodeInfo(apinetmap.AttrUNLOCODE, "RU MOW")))
+
+		other := nodeInfo(apinetmap.AttrUNLOCODE, "RU MOW")
+		other.SetPublicKey([]byte{4, 5, 6})
+
+		require.NoError(t, accept(v, other))
+		require.Equal(t, 1, evicted)
+
+		// forgotten node is registered as the new one
+		require.NoError(t, accept(v, nodeInfo(apinetmap.AttrUNLOCODE, "RU LED")))
+	})
+}
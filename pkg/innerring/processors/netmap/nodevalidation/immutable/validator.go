@@ -0,0 +1,124 @@
+package immutable
+
+import (
+	"sync"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+)
+
+// DefaultOverrideAttribute is a default key of the node attribute
+// that allows to change immutable attributes.
+const DefaultOverrideAttribute = "OverrideImmutable"
+
+// DefaultMaxNodes is a default max number of the nodes
+// remembered by the Validator.
+const DefaultMaxNodes = 10000
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Keys of the node attributes that must not
+	// change between registrations.
+	//
+	// Must not be empty.
+	Attributes []string
+
+	// Key of the node attribute which "true" value
+	// allows to change immutable attributes.
+	//
+	// Optional: DefaultOverrideAttribute is used if empty.
+	OverrideAttribute string
+
+	// Max number of the nodes which immutable attributes are remembered.
+	// The least recently registered node is forgotten on overflow, and
+	// its next registration is treated as the first one. The limit
+	// should exceed the network map size.
+	//
+	// Optional: DefaultMaxNodes is used if not positive.
+	MaxNodes int
+
+	// Callback called when the attributes of the least
+	// recently registered node are dropped because MaxNodes
+	// is reached.
+	//
+	// Optional.
+	OnEvict func()
+}
+
+// Validator is an utility that rejects re-registrations of the nodes
+// which change the values of the immutable attributes, unless the
+// registration explicitly allows it with the override attribute.
+//
+// Validator remembers immutable attributes of the node on Commit,
+// i.e. only after the node is accepted by the whole chain of validators,
+// and forgets the nodes that left the network map on Prune. Both methods
+// are called by the netmap.Processor.
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	attrs []string
+
+	overrideAttr string
+
+	mtx *sync.Mutex
+
+	// hex-encoded public key -> immutable attribute values
+	mNodes *simplelru.LRU
+
+	maxNodes int
+
+	onEvict simplelru.EvictCallback
+
+	// hex-encoded public key -> verified immutable attribute
+	// values waiting for Commit
+	pending *simplelru.LRU
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	if len(prm.Attributes) == 0 {
+		panic("immutable attributes are not set")
+	}
+
+	overrideAttr := prm.OverrideAttribute
+	if overrideAttr == "" {
+		overrideAttr = DefaultOverrideAttribute
+	}
+
+	maxNodes := prm.MaxNodes
+	if maxNodes <= 0 {
+		maxNodes = DefaultMaxNodes
+	}
+
+	v := &Validator{
+		attrs:        prm.Attributes,
+		overrideAttr: overrideAttr,
+		mtx:          new(sync.Mutex),
+		maxNodes:     maxNodes,
+	}
+
+	if prm.OnEvict != nil {
+		v.onEvict = func(interface{}, interface{}) {
+			prm.OnEvict()
+		}
+	}
+
+	// error is returned for non-positive size only
+	v.mNodes, _ = simplelru.NewLRU(maxNodes, v.onEvict)
+	// nodes rejected later in the chain are never committed,
+	// so their values are silently forgotten
+	v.pending, _ = simplelru.NewLRU(maxNodes, nil)
+
+	return v
+}
@@ -0,0 +1,160 @@
+package ipquota
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/network"
+)
+
+// VerifyAndUpdate rejects n if the number of nodes registered in the
+// current epoch from the network prefix of at least one n's address
+// has reached the limit. Repeated registration of the node is not
+// counted twice. n is also rejected if at least one its prefix is not
+// registered in the epoch while the limit of the registered prefixes has
+// been reached.
+//
+// n is not counted until Commit is called.
+//
+// Rejection error is netmap.ValidationError with netmap.PolicyDenied reason
+// if quota is exceeded and with netmap.InvalidInfo reason if n's addresses
+// are incorrect.
+//
+// NodeInfo is not modified.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	prefixes, err := v.prefixes(n)
+	if err != nil {
+		return netmap.ValidationError{
+			Reason: netmap.InvalidInfo,
+			Err:    err,
+		}
+	}
+
+	epoch := v.currentEpoch()
+	key := hex.EncodeToString(n.PublicKey())
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	v.checkEpoch(epoch)
+
+	var newPrefixes int
+
+	for _, prefix := range prefixes {
+		nodes, ok := v.registered[prefix]
+		if !ok {
+			newPrefixes++
+			continue
+		}
+
+		if _, ok := nodes[key]; !ok && len(nodes) >= v.max {
+			return netmap.ValidationError{
+				Reason: netmap.PolicyDenied,
+				Err:    fmt.Errorf("limit of %d nodes per epoch reached for network %s", v.max, prefix),
+			}
+		}
+	}
+
+	if len(v.registered)+newPrefixes > v.maxPrefixes {
+		v.onOverflow()
+
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err:    fmt.Errorf("limit of %d network prefixes per epoch reached", v.maxPrefixes),
+		}
+	}
+
+	return nil
+}
+
+// Commit counts n toward the network prefixes of its addresses
+// in the current epoch.
+//
+// Implements netmap.NodeCommitter.
+func (v *Validator) Commit(n *apinetmap.NodeInfo) {
+	prefixes, err := v.prefixes(n)
+	if err != nil {
+		// n with incorrect addresses is rejected by VerifyAndUpdate
+		return
+	}
+
+	epoch := v.currentEpoch()
+	key := hex.EncodeToString(n.PublicKey())
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	v.checkEpoch(epoch)
+
+	for _, prefix := range prefixes {
+		nodes, ok := v.registered[prefix]
+		if !ok {
+			// limit may be reached by the nodes committed
+			// after n has been verified
+			if len(v.registered) >= v.maxPrefixes {
+				continue
+			}
+
+			nodes = make(map[string]struct{}, 1)
+			v.registered[prefix] = nodes
+		}
+
+		nodes[key] = struct{}{}
+	}
+}
+
+// checkEpoch resets the quota of the previous epoch.
+// Must be called under the lock.
+func (v *Validator) checkEpoch(epoch uint64) {
+	if epoch != v.epoch {
+		v.epoch = epoch
+		v.resetRegistered()
+	}
+}
+
+// prefixes returns distinct network prefixes of the node IP addresses
+// in CIDR notation.
+func (v *Validator) prefixes(n *apinetmap.NodeInfo) ([]string, error) {
+	var addrs network.AddressGroup
+
+	if err := addrs.FromIterator(n); err != nil {
+		return nil, fmt.Errorf("could not parse network addresses: %w", err)
+	}
+
+	res := make([]string, 0, len(addrs))
+	mPrefixes := make(map[string]struct{}, len(addrs))
+
+	for i := range addrs {
+		host, _, err := net.SplitHostPort(addrs[i].HostAddr())
+		if err != nil {
+			return nil, fmt.Errorf("could not parse host address: %w", err)
+		}
+
+		ip := net.ParseIP(host)
+		if ip == nil {
+			// domain names are not counted
+			continue
+		}
+
+		var mask net.IPMask
+
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+			mask = net.CIDRMask(v.prefixLen, 8*net.IPv4len)
+		} else {
+			mask = net.CIDRMask(v.prefixLen6, 8*net.IPv6len)
+		}
+
+		prefix := (&net.IPNet{IP: ip.Mask(mask), Mask: mask}).String()
+
+		if _, ok := mPrefixes[prefix]; !ok {
+			mPrefixes[prefix] = struct{}{}
+			res = append(res, prefix)
+		}
+	}
+
+	return res, nil
+}
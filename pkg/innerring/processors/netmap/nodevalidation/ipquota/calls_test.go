@@ -0,0 +1,152 @@
+package ipquota_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/ipquota"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfo(key byte, addrs ...string) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+	n.SetPublicKey([]byte{key})
+	n.SetAddresses(addrs...)
+
+	return n
+}
+
+func requireReason(t *testing.T, err error, reason netmap.Reason) {
+	var vErr netmap.ValidationError
+
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, reason, vErr.Reason)
+}
+
+// admit verifies n and commits it if n is accepted, as the Processor does.
+func admit(v *ipquota.Validator, n *apinetmap.NodeInfo) error {
+	err := v.VerifyAndUpdate(n)
+	if err == nil {
+		v.Commit(n)
+	}
+
+	return err
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	var epoch uint64 = 1
+
+	v := ipquota.New(ipquota.Prm{
+		PrefixLen:    24,
+		MaxPerPrefix: 2,
+		CurrentEpoch: func() uint64 { return epoch },
+	})
+
+	t.Run("quota reached", func(t *testing.T) {
+		require.NoError(t, admit(v, nodeInfo(1, "/ip4/10.0.0.1/tcp/8080")))
+		require.NoError(t, admit(v, nodeInfo(2, "/ip4/10.0.0.2/tcp/8080")))
+
+		// repeated registration is not counted
+		require.NoError(t, admit(v, nodeInfo(1, "/ip4/10.0.0.1/tcp/8080")))
+
+		requireReason(t, admit(v, nodeInfo(3, "/ip4/10.0.0.3/tcp/8080")), netmap.PolicyDenied)
+
+		// other prefix
+		require.NoError(t, admit(v, nodeInfo(3, "/ip4/10.0.1.3/tcp/8080")))
+
+		// domain names are not counted
+		require.NoError(t, admit(v, nodeInfo(4, "/dns4/node.neofs/tcp/8080")))
+	})
+
+	t.Run("multiple addresses", func(t *testing.T) {
+		// both addresses are counted toward their prefixes, while
+		// the addresses of the same prefix are counted once
+		require.NoError(t, admit(v, nodeInfo(5,
+			"/ip4/10.0.1.5/tcp/8080",
+			"/ip4/10.0.1.6/tcp/8080",
+			"/ip4/10.0.2.5/tcp/8080",
+		)))
+
+		// quota of 10.0.1.0/24 is reached
+		requireReason(t, admit(v, nodeInfo(6, "/ip4/10.0.1.7/tcp/8080")), netmap.PolicyDenied)
+
+		// quota of one of the prefixes is reached, node is not counted
+		requireReason(t, admit(v, nodeInfo(7,
+			"/ip4/10.0.2.7/tcp/8080",
+			"/ip4/10.0.0.7/tcp/8080",
+		)), netmap.PolicyDenied)
+
+		require.NoError(t, admit(v, nodeInfo(8, "/ip4/10.0.2.8/tcp/8080")))
+	})
+
+	t.Run("next epoch", func(t *testing.T) {
+		epoch++
+
+		require.NoError(t, admit(v, nodeInfo(3, "/ip4/10.0.0.3/tcp/8080")))
+		require.NoError(t, admit(v, nodeInfo(9, "/ip4/10.0.0.9/tcp/8080")))
+
+		requireReason(t, admit(v, nodeInfo(10, "/ip4/10.0.0.10/tcp/8080")), netmap.PolicyDenied)
+	})
+
+	t.Run("invalid addresses", func(t *testing.T) {
+		requireReason(t, admit(v, nodeInfo(11)), netmap.InvalidInfo)
+		requireReason(t, admit(v, nodeInfo(11, "not an address")), netmap.InvalidInfo)
+	})
+
+	t.Run("max prefixes", func(t *testing.T) {
+		var overflows int
+
+		v := ipquota.New(ipquota.Prm{
+			PrefixLen:    24,
+			MaxPerPrefix: 1,
+			CurrentEpoch: func() uint64 { return epoch },
+			MaxPrefixes:  2,
+			OnOverflow:   func() { overflows++ },
+		})
+
+		require.NoError(t, admit(v, nodeInfo(1, "/ip4/10.0.0.1/tcp/8080")))
+		requireReason(t, admit(v, nodeInfo(2, "/ip4/10.0.0.2/tcp/8080")), netmap.PolicyDenied)
+
+		// node of the new prefixes is rejected as whole
+		requireReason(t, admit(v, nodeInfo(3,
+			"/ip4/10.0.1.3/tcp/8080",
+			"/ip4/10.0.2.3/tcp/8080",
+		)), netmap.PolicyDenied)
+		require.Equal(t, 1, overflows)
+
+		require.NoError(t, admit(v, nodeInfo(3, "/ip4/10.0.1.3/tcp/8080")))
+
+		// flood of the distinct prefixes does not reset the quota
+		for i := 0; i < 10; i++ {
+			requireReason(t, admit(v, nodeInfo(byte(10+i), fmt.Sprintf("/ip4/10.1.%d.1/tcp/8080", i))), netmap.PolicyDenied)
+		}
+
+		require.Equal(t, 11, overflows)
+		requireReason(t, admit(v, nodeInfo(2, "/ip4/10.0.0.2/tcp/8080")), netmap.PolicyDenied)
+
+		// the limit is reset with the quota
+		epoch++
+
+		require.NoError(t, admit(v, nodeInfo(10, "/ip4/10.1.0.1/tcp/8080")))
+	})
+}
+
+func TestValidator_Commit(t *testing.T) {
+	v := ipquota.New(ipquota.Prm{
+		PrefixLen:    24,
+		MaxPerPrefix: 1,
+		CurrentEpoch: func() uint64 { return 1 },
+	})
+
+	// verified but not committed node (e.g. rejected by the later
+	// validator) is not counted
+	require.NoError(t, v.VerifyAndUpdate(nodeInfo(1, "/ip4/10.0.0.1/tcp/8080")))
+	require.NoError(t, v.VerifyAndUpdate(nodeInfo(2, "/ip4/10.0.0.2/tcp/8080")))
+
+	v.Commit(nodeInfo(2, "/ip4/10.0.0.2/tcp/8080"))
+
+	requireReason(t, v.VerifyAndUpdate(nodeInfo(1, "/ip4/10.0.0.1/tcp/8080")), netmap.PolicyDenied)
+}
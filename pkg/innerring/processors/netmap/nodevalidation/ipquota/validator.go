@@ -0,0 +1,139 @@
+package ipquota
+
+import (
+	"sync"
+)
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Length of the IPv4 network prefix in bits.
+	//
+	// Must be in range [1; 32].
+	PrefixLen int
+
+	// Length of the IPv6 network prefix in bits.
+	//
+	// Optional: DefaultIPv6PrefixLen is used if zero.
+	// Must be in range [1; 128] if set.
+	IPv6PrefixLen int
+
+	// Max number of nodes registered from the same
+	// network prefix per epoch.
+	//
+	// Must be positive.
+	MaxPerPrefix int
+
+	// Function that returns the current epoch number.
+	// Quota is reset when the returned value changes.
+	//
+	// Must not be nil.
+	CurrentEpoch func() uint64
+
+	// Max number of the network prefixes registered in the epoch. Nodes
+	// from the new prefixes are rejected on overflow, the registered
+	// prefixes are never forgotten within the epoch, so their quota
+	// can not be reset by the flood of the distinct prefixes.
+	//
+	// Optional: DefaultMaxPrefixes is used if not positive.
+	MaxPrefixes int
+
+	// Callback called on each node rejected since MaxPrefixes
+	// is reached.
+	//
+	// Optional.
+	OnOverflow func()
+}
+
+// DefaultIPv6PrefixLen is a default length of the IPv6 network prefix.
+const DefaultIPv6PrefixLen = 64
+
+// DefaultMaxPrefixes is a default max number of the network
+// prefixes remembered by the Validator.
+const DefaultMaxPrefixes = 10000
+
+// Validator is an utility that limits the number of nodes registered
+// from the same IP network prefix (e.g. /24) per epoch, so the network
+// is not dominated by the nodes of a single hosting.
+//
+// Node with multiple addresses is counted toward each distinct prefix
+// of its addresses. Addresses with domain names are not counted.
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	prefixLen, prefixLen6 int
+
+	max int
+
+	currentEpoch func() uint64
+
+	mtx *sync.Mutex
+
+	epoch uint64
+
+	maxPrefixes int
+
+	onOverflow func()
+
+	// network prefix -> hex-encoded public keys of the nodes
+	// registered from the prefix in the epoch
+	registered map[string]map[string]struct{}
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	prefixLen6 := prm.IPv6PrefixLen
+	if prefixLen6 == 0 {
+		prefixLen6 = DefaultIPv6PrefixLen
+	}
+
+	switch {
+	case prm.PrefixLen < 1 || prm.PrefixLen > 32:
+		panic("IPv4 prefix length must be in range [1; 32]")
+	case prefixLen6 < 1 || prefixLen6 > 128:
+		panic("IPv6 prefix length must be in range [1; 128]")
+	case prm.MaxPerPrefix <= 0:
+		panic("max number of nodes per prefix must be positive")
+	case prm.CurrentEpoch == nil:
+		panic("current epoch function is not set")
+	}
+
+	maxPrefixes := prm.MaxPrefixes
+	if maxPrefixes <= 0 {
+		maxPrefixes = DefaultMaxPrefixes
+	}
+
+	v := &Validator{
+		prefixLen:    prm.PrefixLen,
+		prefixLen6:   prefixLen6,
+		max:          prm.MaxPerPrefix,
+		currentEpoch: prm.CurrentEpoch,
+		mtx:          new(sync.Mutex),
+		maxPrefixes:  maxPrefixes,
+		onOverflow:   prm.OnOverflow,
+	}
+
+	if v.onOverflow == nil {
+		v.onOverflow = func() {}
+	}
+
+	v.resetRegistered()
+
+	return v
+}
+
+// resetRegistered forgets the nodes registered in the epoch.
+func (v *Validator) resetRegistered() {
+	v.registered = make(map[string]map[string]struct{})
+}
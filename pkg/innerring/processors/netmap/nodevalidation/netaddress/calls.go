@@ -0,0 +1,86 @@
+package netaddress
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/multiformats/go-multiaddr"
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/network"
+)
+
+var errNoAddresses = errors.New("node has no network addresses")
+
+// VerifyAndUpdate rejects n if it has no network addresses, if at least
+// one of them is not a valid multiaddr or if the protocols of the address
+// are not supported (see network.VerifyMultiAddress).
+//
+// Rejection error is netmap.ValidationError with netmap.InvalidInfo reason.
+//
+// Addresses of the accepted node are replaced with the canonical ones
+// without the repeats, sorted if configured.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	addrs, err := v.normalize(n)
+	if err != nil {
+		return netmap.ValidationError{
+			Reason: netmap.InvalidInfo,
+			Err:    err,
+		}
+	}
+
+	n.SetAddresses(addrs...)
+
+	return nil
+}
+
+func (v *Validator) normalize(n *apinetmap.NodeInfo) ([]string, error) {
+	var (
+		err   error
+		addrs = make([]string, 0, n.NumberOfAddresses())
+		seen  = make(map[string]struct{}, n.NumberOfAddresses())
+	)
+
+	n.IterateAddresses(func(s string) bool {
+		var ma multiaddr.Multiaddr
+
+		ma, err = multiaddr.NewMultiaddr(s)
+		if err != nil {
+			err = fmt.Errorf("invalid network address %q: %w", s, err)
+			return true
+		}
+
+		s = ma.String()
+
+		if _, ok := seen[s]; !ok {
+			seen[s] = struct{}{}
+			addrs = append(addrs, s)
+		}
+
+		return false
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addrs) == 0 {
+		return nil, errNoAddresses
+	}
+
+	// check the canonical addresses, so the
+	// node is not modified if it is rejected
+	canonical := apinetmap.NewNodeInfo()
+	canonical.SetAddresses(addrs...)
+
+	if err = network.VerifyMultiAddress(canonical); err != nil {
+		return nil, fmt.Errorf("unsupported network address: %w", err)
+	}
+
+	if v.sort {
+		sort.Strings(addrs)
+	}
+
+	return addrs, nil
+}
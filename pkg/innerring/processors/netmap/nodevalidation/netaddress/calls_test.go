@@ -0,0 +1,99 @@
+package netaddress_test
+
+import (
+	"errors"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/netaddress"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfo(addrs ...string) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+	n.SetAddresses(addrs...)
+
+	return n
+}
+
+func addresses(n *apinetmap.NodeInfo) []string {
+	var res []string
+
+	n.IterateAddresses(func(s string) bool {
+		res = append(res, s)
+		return false
+	})
+
+	return res
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	v := netaddress.New(netaddress.Prm{})
+
+	t.Run("correct addresses", func(t *testing.T) {
+		addrs := []string{
+			"/ip4/192.168.0.2/tcp/8080",
+			"/dns4/s01.neofs.devenv/tcp/8080/tls",
+			"/ip6/::1/tcp/8080",
+		}
+
+		n := nodeInfo(addrs...)
+
+		require.NoError(t, v.VerifyAndUpdate(n))
+		require.Equal(t, addrs, addresses(n))
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		n := nodeInfo(
+			"/ip4/192.168.0.2/tcp/8080",
+			"/ip4/192.168.0.1/tcp/8080",
+			"/ip4/192.168.0.2/tcp/8080",
+		)
+
+		require.NoError(t, v.VerifyAndUpdate(n))
+		require.Equal(t, []string{
+			"/ip4/192.168.0.2/tcp/8080",
+			"/ip4/192.168.0.1/tcp/8080",
+		}, addresses(n))
+	})
+
+	t.Run("sort", func(t *testing.T) {
+		v := netaddress.New(netaddress.Prm{Sort: true})
+
+		n := nodeInfo(
+			"/ip4/192.168.0.2/tcp/8080",
+			"/dns4/s01.neofs.devenv/tcp/8080",
+			"/ip4/192.168.0.2/tcp/8080",
+		)
+
+		require.NoError(t, v.VerifyAndUpdate(n))
+		require.Equal(t, []string{
+			"/dns4/s01.neofs.devenv/tcp/8080",
+			"/ip4/192.168.0.2/tcp/8080",
+		}, addresses(n))
+	})
+
+	t.Run("incorrect addresses", func(t *testing.T) {
+		for _, addrs := range [][]string{
+			{}, // no addresses
+			{"192.168.0.2:8080"},
+			{"grpcs://s01.neofs.devenv:8080"},
+			{"/ip4/192.168.0.2/tcp/8080", "/ip4/300.168.0.2/tcp/8080"},
+			{"/ip4/192.168.0.2/udp/8080"},
+			{"/ip4/192.168.0.2/tcp/8080/http"},
+		} {
+			n := nodeInfo(addrs...)
+
+			err := v.VerifyAndUpdate(n)
+			require.Error(t, err, addrs)
+
+			var vErr netmap.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, netmap.InvalidInfo, vErr.Reason)
+
+			// rejected node is not modified
+			require.Equal(t, len(addrs), len(addresses(n)))
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package netaddress
+
+// Prm groups the optional parameters of the Validator's constructor.
+type Prm struct {
+	// Sort the network addresses of the node lexicographically.
+	//
+	// Optional: order of the addresses is kept by default.
+	Sort bool
+}
+
+// Validator is an utility that verifies the network addresses of the node
+// and normalizes them: addresses are written in the canonical multiaddr
+// form and repeated ones are collapsed.
+//
+// Unlike maddress.Validator, only the multiaddr strings are accepted,
+// and the node must announce at least one address.
+//
+// For correct operation, Validator must be created
+// using the constructor (New). After successful creation,
+// the Validator is immediately ready to work through API.
+type Validator struct {
+	sort bool
+}
+
+// New creates a new instance of the Validator.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	return &Validator{
+		sort: prm.Sort,
+	}
+}
@@ -0,0 +1,99 @@
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+)
+
+// VerifyAndUpdate checks n against the current admission parameters:
+//  * Capacity attribute value must not be less than MinCapacity;
+//  * Continent attribute value must be in AllowedContinents.
+//
+// If parameters can not be fetched, previously fetched parameters are used.
+// If there are no such parameters, n is accepted or rejected according
+// to the FailOpen parameter.
+//
+// Rejection error is netmap.ValidationError with netmap.PolicyDenied reason.
+//
+// NodeInfo is not modified.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	prm, err := v.params()
+	if err != nil {
+		if v.failOpen {
+			return nil
+		}
+
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err:    fmt.Errorf("could not fetch admission parameters: %w", err),
+		}
+	}
+
+	var capacity uint64
+
+	continent := ""
+
+	for _, a := range n.Attributes() {
+		switch a.Key() {
+		case apinetmap.AttrCapacity:
+			capacity, err = strconv.ParseUint(a.Value(), 10, 64)
+			if err != nil {
+				return netmap.ValidationError{
+					Reason: netmap.InvalidInfo,
+					Err:    fmt.Errorf("invalid capacity value: %w", err),
+				}
+			}
+		case apinetmap.AttrContinent:
+			continent = a.Value()
+		}
+	}
+
+	if capacity < prm.MinCapacity {
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err:    fmt.Errorf("capacity %d is less than required %d", capacity, prm.MinCapacity),
+		}
+	}
+
+	if len(prm.AllowedContinents) == 0 {
+		return nil
+	}
+
+	for i := range prm.AllowedContinents {
+		if prm.AllowedContinents[i] == continent {
+			return nil
+		}
+	}
+
+	return netmap.ValidationError{
+		Reason: netmap.PolicyDenied,
+		Err:    fmt.Errorf("continent %q is not allowed", continent),
+	}
+}
+
+func (v *Validator) params() (Params, error) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	if v.cached != nil && time.Since(v.fetchedAt) < v.ttl {
+		return *v.cached, nil
+	}
+
+	prm, err := v.fetch()
+	if err != nil {
+		if v.cached != nil {
+			return *v.cached, nil
+		}
+
+		return Params{}, err
+	}
+
+	v.cached = &prm
+	v.fetchedAt = time.Now()
+
+	return prm, nil
+}
@@ -0,0 +1,102 @@
+package policy_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/policy"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfo(capacity, continent string) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+
+	a1 := apinetmap.NewNodeAttribute()
+	a1.SetKey(apinetmap.AttrCapacity)
+	a1.SetValue(capacity)
+
+	a2 := apinetmap.NewNodeAttribute()
+	a2.SetKey(apinetmap.AttrContinent)
+	a2.SetValue(continent)
+
+	n.SetAttributes(a1, a2)
+
+	return n
+}
+
+func requirePolicyDenied(t *testing.T, err error) {
+	var vErr netmap.ValidationError
+
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, netmap.PolicyDenied, vErr.Reason)
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	var (
+		prm      policy.Params
+		fetchErr error
+		fetches  int
+	)
+
+	fetch := func() (policy.Params, error) {
+		fetches++
+		return prm, fetchErr
+	}
+
+	t.Run("changing parameters", func(t *testing.T) {
+		v := policy.New(policy.Prm{Fetch: fetch})
+
+		prm = policy.Params{MinCapacity: 10}
+
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("10", "Europe")))
+		requirePolicyDenied(t, v.VerifyAndUpdate(nodeInfo("9", "Europe")))
+
+		prm = policy.Params{MinCapacity: 10, AllowedContinents: []string{"Asia"}}
+
+		requirePolicyDenied(t, v.VerifyAndUpdate(nodeInfo("10", "Europe")))
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("100", "Asia")))
+
+		err := v.VerifyAndUpdate(nodeInfo("many", "Asia"))
+
+		var vErr netmap.ValidationError
+		require.True(t, errors.As(err, &vErr))
+		require.Equal(t, netmap.InvalidInfo, vErr.Reason)
+	})
+
+	t.Run("cache", func(t *testing.T) {
+		v := policy.New(policy.Prm{Fetch: fetch, TTL: time.Hour})
+
+		prm = policy.Params{MinCapacity: 10}
+		fetches = 0
+
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("10", "Europe")))
+
+		prm = policy.Params{MinCapacity: 100}
+
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("10", "Europe")))
+		require.Equal(t, 1, fetches)
+	})
+
+	t.Run("fetch failure", func(t *testing.T) {
+		fetchErr = errors.New("test error")
+		defer func() { fetchErr = nil }()
+
+		requirePolicyDenied(t, policy.New(policy.Prm{Fetch: fetch}).VerifyAndUpdate(nodeInfo("10", "Europe")))
+		require.NoError(t, policy.New(policy.Prm{Fetch: fetch, FailOpen: true}).VerifyAndUpdate(nodeInfo("1", "Europe")))
+
+		// previously fetched parameters are used
+		fetchErr = nil
+		prm = policy.Params{MinCapacity: 10}
+
+		v := policy.New(policy.Prm{Fetch: fetch})
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("10", "Europe")))
+
+		fetchErr = errors.New("test error")
+
+		requirePolicyDenied(t, v.VerifyAndUpdate(nodeInfo("1", "Europe")))
+		require.NoError(t, v.VerifyAndUpdate(nodeInfo("10", "Europe")))
+	})
+}
@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"sync"
+	"time"
+)
+
+// Params groups the node admission parameters of the network.
+type Params struct {
+	// Minimal value of the Capacity node attribute.
+	// Zero means no limit.
+	MinCapacity uint64
+
+	// Allowed values of the Continent node attribute.
+	// Empty list means any continent.
+	AllowedContinents []string
+}
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Function that reads the current admission
+	// parameters (e.g. from the side chain).
+	//
+	// Must not be nil.
+	Fetch func() (Params, error)
+
+	// Duration of caching the fetched parameters.
+	// Parameters are fetched on every validation if not positive.
+	TTL time.Duration
+
+	// Behavior when the parameters can not be fetched
+	// and there are no previously fetched ones: accept
+	// the node if true, reject otherwise.
+	FailOpen bool
+}
+
+// Validator is an utility that verifies node attributes
+// against the admission parameters of the network, so
+// the parameters can be changed without the IR redeployment.
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	fetch func() (Params, error)
+
+	ttl time.Duration
+
+	failOpen bool
+
+	mtx *sync.Mutex
+
+	cached *Params
+
+	fetchedAt time.Time
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	if prm.Fetch == nil {
+		panic("admission parameters fetcher is not set")
+	}
+
+	return &Validator{
+		fetch:    prm.Fetch,
+		ttl:      prm.TTL,
+		failOpen: prm.FailOpen,
+		mtx:      new(sync.Mutex),
+	}
+}
@@ -0,0 +1,85 @@
+package schemaversion
+
+import (
+	"fmt"
+	"strconv"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+)
+
+// VerifyAndUpdate rejects n if the schema version declared in
+// AttributeSchemaVersion is less than the min one. Nodes which comply
+// with the outdated but compatible version are migrated to the current
+// version: migration function is applied and AttributeSchemaVersion is
+// set to the current version. Nodes which comply with the current or
+// newer version are admitted unchanged.
+//
+// Rejection error is netmap.ValidationError with netmap.PolicyDenied reason
+// if the version is not supported and with netmap.InvalidInfo reason if the
+// attribute is malformed or migration failed.
+func (v *Validator) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	version, err := declaredVersion(n)
+	if err != nil {
+		return netmap.ValidationError{
+			Reason: netmap.InvalidInfo,
+			Err:    err,
+		}
+	}
+
+	switch {
+	case version < v.minVersion:
+		return netmap.ValidationError{
+			Reason: netmap.PolicyDenied,
+			Err: fmt.Errorf("schema version %d is less than the min supported version %d",
+				version, v.minVersion),
+		}
+	case version >= v.currentVersion || v.migrate == nil:
+		return nil
+	}
+
+	if err := v.migrate(n); err != nil {
+		return netmap.ValidationError{
+			Reason: netmap.InvalidInfo,
+			Err:    fmt.Errorf("could not migrate schema version %d: %w", version, err),
+		}
+	}
+
+	setVersion(n, v.currentVersion)
+
+	return nil
+}
+
+func declaredVersion(n *apinetmap.NodeInfo) (int, error) {
+	for _, a := range n.Attributes() {
+		if a.Key() != AttributeSchemaVersion {
+			continue
+		}
+
+		version, err := strconv.ParseUint(a.Value(), 10, 31)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value of attribute %s: %w", AttributeSchemaVersion, err)
+		}
+
+		return int(version), nil
+	}
+
+	return 0, nil
+}
+
+func setVersion(n *apinetmap.NodeInfo, version int) {
+	as := n.Attributes()
+	res := make([]*apinetmap.NodeAttribute, 0, len(as)+1)
+
+	for i := range as {
+		if as[i].Key() != AttributeSchemaVersion {
+			res = append(res, as[i])
+		}
+	}
+
+	a := apinetmap.NewNodeAttribute()
+	a.SetKey(AttributeSchemaVersion)
+	a.SetValue(strconv.Itoa(version))
+
+	n.SetAttributes(append(res, a)...)
+}
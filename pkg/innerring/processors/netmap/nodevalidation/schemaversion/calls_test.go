@@ -0,0 +1,127 @@
+package schemaversion_test
+
+import (
+	"errors"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation/schemaversion"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeInfo(kv ...string) *apinetmap.NodeInfo {
+	n := apinetmap.NewNodeInfo()
+
+	as := make([]*apinetmap.NodeAttribute, 0, len(kv)/2)
+
+	for i := 0; i < len(kv); i += 2 {
+		a := apinetmap.NewNodeAttribute()
+		a.SetKey(kv[i])
+		a.SetValue(kv[i+1])
+
+		as = append(as, a)
+	}
+
+	n.SetAttributes(as...)
+
+	return n
+}
+
+func attributes(n *apinetmap.NodeInfo) map[string]string {
+	m := make(map[string]string)
+
+	for _, a := range n.Attributes() {
+		m[a.Key()] = a.Value()
+	}
+
+	return m
+}
+
+func requireReason(t *testing.T, err error, reason netmap.Reason) {
+	var vErr netmap.ValidationError
+
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, reason, vErr.Reason)
+}
+
+func TestValidator_VerifyAndUpdate(t *testing.T) {
+	const oldKey, newKey = "Location", "UN-LOCODE"
+
+	var migrateErr error
+
+	// version 1 renamed the attribute
+	migrate := func(n *apinetmap.NodeInfo) error {
+		as := n.Attributes()
+
+		for i := range as {
+			if as[i].Key() == oldKey {
+				as[i].SetKey(newKey)
+			}
+		}
+
+		n.SetAttributes(as...)
+
+		return migrateErr
+	}
+
+	v := schemaversion.New(schemaversion.Prm{
+		MinVersion:     1,
+		CurrentVersion: 2,
+		Migrate:        migrate,
+	})
+
+	t.Run("too old", func(t *testing.T) {
+		requireReason(t, v.VerifyAndUpdate(nodeInfo(oldKey, "RU MOW")), netmap.PolicyDenied)
+		requireReason(t, v.VerifyAndUpdate(nodeInfo(schemaversion.AttributeSchemaVersion, "0")), netmap.PolicyDenied)
+	})
+
+	t.Run("migratable", func(t *testing.T) {
+		n := nodeInfo(schemaversion.AttributeSchemaVersion, "1", oldKey, "RU MOW")
+
+		require.NoError(t, v.VerifyAndUpdate(n))
+		require.Equal(t, map[string]string{
+			schemaversion.AttributeSchemaVersion: "2",
+			newKey:                               "RU MOW",
+		}, attributes(n))
+	})
+
+	t.Run("current", func(t *testing.T) {
+		for _, version := range []string{"2", "3"} {
+			n := nodeInfo(schemaversion.AttributeSchemaVersion, version, oldKey, "RU MOW")
+
+			require.NoError(t, v.VerifyAndUpdate(n))
+			require.Equal(t, map[string]string{
+				schemaversion.AttributeSchemaVersion: version,
+				oldKey:                               "RU MOW",
+			}, attributes(n))
+		}
+	})
+
+	t.Run("migration failure", func(t *testing.T) {
+		migrateErr = errors.New("any error")
+		defer func() { migrateErr = nil }()
+
+		n := nodeInfo(schemaversion.AttributeSchemaVersion, "1")
+
+		requireReason(t, v.VerifyAndUpdate(n), netmap.InvalidInfo)
+		require.Equal(t, "1", attributes(n)[schemaversion.AttributeSchemaVersion])
+	})
+
+	t.Run("invalid attribute", func(t *testing.T) {
+		requireReason(t, v.VerifyAndUpdate(nodeInfo(schemaversion.AttributeSchemaVersion, "-1")), netmap.InvalidInfo)
+		requireReason(t, v.VerifyAndUpdate(nodeInfo(schemaversion.AttributeSchemaVersion, "v1")), netmap.InvalidInfo)
+	})
+
+	t.Run("without migration", func(t *testing.T) {
+		v := schemaversion.New(schemaversion.Prm{
+			MinVersion:     1,
+			CurrentVersion: 2,
+		})
+
+		n := nodeInfo(schemaversion.AttributeSchemaVersion, "1", oldKey, "RU MOW")
+
+		require.NoError(t, v.VerifyAndUpdate(n))
+		require.Equal(t, "1", attributes(n)[schemaversion.AttributeSchemaVersion])
+	})
+}
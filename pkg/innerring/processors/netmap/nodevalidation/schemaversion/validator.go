@@ -0,0 +1,67 @@
+package schemaversion
+
+import (
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+)
+
+// AttributeSchemaVersion is a key of the node attribute which value is
+// a decimal number of the attribute schema version the node complies with.
+// Nodes which do not declare the version are considered to comply with
+// the version 0.
+const AttributeSchemaVersion = "SchemaVersion"
+
+// Prm groups the required parameters of the Validator's constructor.
+//
+// All values must comply with the requirements imposed on them.
+// Passing incorrect parameter values will result in constructor
+// failure (error or panic depending on the implementation).
+type Prm struct {
+	// Min attribute schema version of the admitted nodes.
+	//
+	// Must not be negative.
+	MinVersion int
+
+	// Current attribute schema version.
+	//
+	// Must not be less than MinVersion.
+	CurrentVersion int
+
+	// Function that migrates the attributes of the node which complies with
+	// the version in the [MinVersion; CurrentVersion) range to the current
+	// version. Optional: such nodes are admitted unchanged if not set.
+	Migrate func(*apinetmap.NodeInfo) error
+}
+
+// Validator is an utility that admits the nodes that comply with the
+// compatible attribute schema versions and migrates the outdated ones.
+//
+// For correct operation, Validator must be created
+// using the constructor (New) based on the required parameters.
+// After successful creation, the Validator is immediately
+// ready to work through API.
+type Validator struct {
+	minVersion, currentVersion int
+
+	migrate func(*apinetmap.NodeInfo) error
+}
+
+// New creates a new instance of the Validator.
+//
+// Panics if at least one value of the parameters is invalid.
+//
+// The created Validator does not require additional
+// initialization and is completely ready for work.
+func New(prm Prm) *Validator {
+	switch {
+	case prm.MinVersion < 0:
+		panic("negative min schema version")
+	case prm.CurrentVersion < prm.MinVersion:
+		panic("current schema version is less than the min one")
+	}
+
+	return &Validator{
+		minVersion:     prm.MinVersion,
+		currentVersion: prm.CurrentVersion,
+		migrate:        prm.Migrate,
+	}
+}
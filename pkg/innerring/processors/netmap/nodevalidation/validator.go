@@ -1,6 +1,8 @@
 package nodevalidation
 
 import (
+	"strings"
+
 	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
 	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
 )
@@ -23,15 +25,67 @@ func New(validators ...netmap.NodeValidator) *CompositeValidator {
 	return &CompositeValidator{validators}
 }
 
-// VerifyAndUpdate passes apinetmap.NodeInfo to wrapped validators.
+// VerifyAndUpdate passes apinetmap.NodeInfo to wrapped validators
+// in the order they were passed to the constructor. Each validator
+// receives the information updated by the previous ones.
 //
-// If error appears, returns it immediately.
+// If error appears, returns it immediately, the rest validators
+// are not called.
 func (c *CompositeValidator) VerifyAndUpdate(ni *apinetmap.NodeInfo) error {
+	_, err := c.VerifyAndReport(ni)
+	return err
+}
+
+// VerifyAndReport works like VerifyAndUpdate and additionally returns
+// the outcomes of the called validators, so the validator rejected the
+// candidate is the last one.
+func (c *CompositeValidator) VerifyAndReport(ni *apinetmap.NodeInfo) ([]netmap.ValidatorOutcome, error) {
+	outcomes := make([]netmap.ValidatorOutcome, 0, len(c.validators))
+
 	for _, v := range c.validators {
-		if err := v.VerifyAndUpdate(ni); err != nil {
-			return err
+		err := v.VerifyAndUpdate(ni)
+
+		outcomes = append(outcomes, netmap.ValidatorOutcome{
+			Validator: netmap.DescribeValidator(v),
+			Error:     err,
+		})
+
+		if err != nil {
+			return outcomes, err
 		}
 	}
 
-	return nil
+	return outcomes, nil
+}
+
+// String returns the descriptions of the wrapped validators.
+func (c *CompositeValidator) String() string {
+	names := make([]string, len(c.validators))
+
+	for i := range c.validators {
+		names[i] = netmap.DescribeValidator(c.validators[i])
+	}
+
+	return "composite(" + strings.Join(names, ", ") + ")"
+}
+
+// Commit passes the accepted apinetmap.NodeInfo to the wrapped
+// validators implementing netmap.NodeCommitter in the order they
+// were passed to the constructor.
+func (c *CompositeValidator) Commit(ni *apinetmap.NodeInfo) {
+	for _, v := range c.validators {
+		if cm, ok := v.(netmap.NodeCommitter); ok {
+			cm.Commit(ni)
+		}
+	}
+}
+
+// Prune passes the network map to the wrapped validators
+// implementing netmap.NodePruner.
+func (c *CompositeValidator) Prune(nm *apinetmap.Netmap) {
+	for _, v := range c.validators {
+		if p, ok := v.(netmap.NodePruner); ok {
+			p.Prune(nm)
+		}
+	}
 }
@@ -0,0 +1,153 @@
+package nodevalidation_test
+
+import (
+	"errors"
+	"testing"
+
+	apinetmap "github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/netmap/nodevalidation"
+	"github.com/stretchr/testify/require"
+)
+
+type validatorFunc func(*apinetmap.NodeInfo) error
+
+func (f validatorFunc) VerifyAndUpdate(n *apinetmap.NodeInfo) error {
+	return f(n)
+}
+
+func TestCompositeValidator_VerifyAndUpdate(t *testing.T) {
+	var calls []string
+
+	// appends an attribute of the named validator, so
+	// the later validators can see the earlier mutations
+	appender := func(name string, err error) netmap.NodeValidator {
+		return validatorFunc(func(n *apinetmap.NodeInfo) error {
+			var seen []string
+			for _, a := range n.Attributes() {
+				seen = append(seen, a.Key())
+			}
+
+			require.Equal(t, calls, seen)
+
+			calls = append(calls, name)
+
+			a := apinetmap.NewNodeAttribute()
+			a.SetKey(name)
+			a.SetValue("true")
+
+			n.SetAttributes(append(n.Attributes(), a)...)
+
+			return err
+		})
+	}
+
+	t.Run("all passed", func(t *testing.T) {
+		calls = nil
+
+		v := nodevalidation.New(
+			appender("first", nil),
+			appender("second", nil),
+			appender("third", nil),
+		)
+
+		n := apinetmap.NewNodeInfo()
+
+		require.NoError(t, v.VerifyAndUpdate(n))
+		require.Equal(t, []string{"first", "second", "third"}, calls)
+		require.Len(t, n.Attributes(), 3)
+	})
+
+	t.Run("mid-chain error", func(t *testing.T) {
+		calls = nil
+
+		errTest := errors.New("test error")
+
+		v := nodevalidation.New(
+			appender("first", nil),
+			appender("second", errTest),
+			appender("third", nil),
+		)
+
+		require.True(t, errors.Is(v.VerifyAndUpdate(apinetmap.NewNodeInfo()), errTest))
+		require.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		require.NoError(t, nodevalidation.New().VerifyAndUpdate(apinetmap.NewNodeInfo()))
+	})
+}
+
+type statefulValidator struct {
+	validatorFunc
+
+	committed []*apinetmap.NodeInfo
+	pruned    []*apinetmap.Netmap
+}
+
+func (v *statefulValidator) Commit(n *apinetmap.NodeInfo) {
+	v.committed = append(v.committed, n)
+}
+
+func (v *statefulValidator) Prune(nm *apinetmap.Netmap) {
+	v.pruned = append(v.pruned, nm)
+}
+
+func TestCompositeValidator_Commit(t *testing.T) {
+	var (
+		pass     = validatorFunc(func(*apinetmap.NodeInfo) error { return nil })
+		stateful = &statefulValidator{validatorFunc: pass}
+		nested   = &statefulValidator{validatorFunc: pass}
+	)
+
+	v := nodevalidation.New(pass, stateful, nodevalidation.New(nested))
+
+	n := apinetmap.NewNodeInfo()
+	v.Commit(n)
+
+	require.Equal(t, []*apinetmap.NodeInfo{n}, stateful.committed)
+	require.Equal(t, []*apinetmap.NodeInfo{n}, nested.committed)
+
+	nm := new(apinetmap.Netmap)
+	v.Prune(nm)
+
+	require.Equal(t, []*apinetmap.Netmap{nm}, stateful.pruned)
+	require.Equal(t, []*apinetmap.Netmap{nm}, nested.pruned)
+}
+
+type namedValidator struct {
+	validatorFunc
+
+	name string
+}
+
+func (v namedValidator) String() string {
+	return v.name
+}
+
+func TestCompositeValidator_VerifyAndReport(t *testing.T) {
+	var (
+		errTest = errors.New("test error")
+		pass    = validatorFunc(func(*apinetmap.NodeInfo) error { return nil })
+		fail    = validatorFunc(func(*apinetmap.NodeInfo) error { return errTest })
+	)
+
+	v := nodevalidation.New(
+		namedValidator{pass, "first"},
+		pass,
+		namedValidator{fail, "third"},
+		namedValidator{pass, "fourth"},
+	)
+
+	require.Equal(t, "composite(first, nodevalidation_test.validatorFunc, third, fourth)", v.String())
+
+	outcomes, err := v.VerifyAndReport(apinetmap.NewNodeInfo())
+	require.True(t, errors.Is(err, errTest))
+
+	// validators after the rejected one are not called
+	require.Equal(t, []netmap.ValidatorOutcome{
+		{Validator: "first"},
+		{Validator: "nodevalidation_test.validatorFunc"},
+		{Validator: "third", Error: errTest},
+	}, outcomes)
+}
@@ -13,22 +13,13 @@ func (np *Processor) processNetmapCleanupTick(epoch uint64) {
 		return
 	}
 
-	err := np.netmapSnapshot.forEachRemoveCandidate(epoch, func(s string) error {
-		key, err := keys.NewPublicKeyFromString(s)
-		if err != nil {
-			np.log.Warn("can't decode public key of netmap node",
-				zap.String("key", s))
-
-			return nil
-		}
-
-		np.log.Info("vote to remove node from netmap", zap.String("key", s))
-
-		err = np.netmapClient.UpdatePeerState(key.Bytes(), netmap.NodeStateOffline)
-		if err != nil {
-			np.log.Error("can't invoke netmap.UpdateState", zap.Error(err))
-		}
+	if np.cleanupCoordinator != nil {
+		np.processCoordinatedCleanup(epoch)
+		return
+	}
 
+	err := np.netmapSnapshot.forEachRemoveCandidate(epoch, func(s string, lastEpoch uint64) error {
+		np.voteRemoveNode(s, lastEpoch)
 		return nil
 	})
 	if err != nil {
@@ -36,3 +27,47 @@ func (np *Processor) processNetmapCleanupTick(epoch uint64) {
 			zap.String("error", err.Error()))
 	}
 }
+
+// processCoordinatedCleanup votes to remove the local cleanup candidates
+// agreed by the quorum of the alphabet nodes. Candidates are not flagged
+// if there is no agreement, so they are handled again on the next tick.
+func (np *Processor) processCoordinatedCleanup(epoch uint64) {
+	candidates, err := np.agreedCleanupCandidates(epoch)
+	if err != nil {
+		np.log.Warn("can't agree on netmap cleanup candidates",
+			zap.Uint64("epoch", epoch),
+			zap.String("error", err.Error()))
+
+		return
+	}
+
+	for _, s := range candidates {
+		lastEpoch, _ := np.netmapSnapshot.lastEpoch(s)
+
+		np.netmapSnapshot.flag(s)
+		np.voteRemoveNode(s, lastEpoch)
+	}
+}
+
+func (np *Processor) voteRemoveNode(s string, lastEpoch uint64) {
+	key, err := keys.NewPublicKeyFromString(s)
+	if err != nil {
+		np.log.Warn("can't decode public key of netmap node",
+			zap.String("key", s))
+
+		return
+	}
+
+	np.log.Info("vote to remove node from netmap",
+		zap.String("key", s),
+		zap.Uint64("last epoch", lastEpoch))
+
+	if np.onNodeEvicted != nil {
+		np.onNodeEvicted(key.Bytes(), lastEpoch)
+	}
+
+	err = np.netmapClient.UpdatePeerState(key.Bytes(), netmap.NodeStateOffline)
+	if err != nil {
+		np.log.Error("can't invoke netmap.UpdateState", zap.Error(err))
+	}
+}
@@ -0,0 +1,80 @@
+package netmap
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_OnNodeEvicted(t *testing.T) {
+	keys := make([]string, 3)
+	for i := range keys {
+		keys[i] = hex.EncodeToString(genKey(t).PublicKey().Bytes())
+	}
+
+	type eviction struct {
+		key       string
+		lastEpoch uint64
+		voted     int // number of votes sent before the callback
+	}
+
+	newProcessor := func(board CleanupCoordinator) (*Processor, *[]eviction) {
+		var (
+			cli       = new(testNetmapClient)
+			evictions []eviction
+		)
+
+		np := &Processor{
+			log:                test.NewLogger(false),
+			alphabetState:      testAlphabetState(true),
+			netmapClient:       cli,
+			netmapSnapshot:     newCleanupTable(true, 1),
+			cleanupCoordinator: board,
+			onNodeEvicted: func(key []byte, lastEpoch uint64) {
+				evictions = append(evictions, eviction{
+					key:       hex.EncodeToString(key),
+					lastEpoch: lastEpoch,
+					voted:     len(cli.updated),
+				})
+			},
+		}
+
+		np.netmapSnapshot.touch(keys[0], 1)
+		np.netmapSnapshot.touch(keys[1], 2)
+		np.netmapSnapshot.touch(keys[2], 5)
+
+		return np, &evictions
+	}
+
+	expected := []eviction{
+		{key: keys[0], lastEpoch: 1, voted: 0},
+		{key: keys[1], lastEpoch: 2, voted: 1},
+	}
+
+	t.Run("local", func(t *testing.T) {
+		np, evictions := newProcessor(nil)
+
+		np.processNetmapCleanupTick(5)
+
+		require.Equal(t, expected, *evictions)
+	})
+
+	t.Run("coordinated", func(t *testing.T) {
+		np, evictions := newProcessor(&testCleanupBoard{quorum: 1})
+
+		np.processNetmapCleanupTick(5)
+
+		require.Equal(t, expected, *evictions)
+	})
+
+	t.Run("nil callback", func(t *testing.T) {
+		np, _ := newProcessor(nil)
+		np.onNodeEvicted = nil
+
+		np.processNetmapCleanupTick(5)
+
+		require.False(t, np.netmapSnapshot.active(keys[0]))
+	})
+}
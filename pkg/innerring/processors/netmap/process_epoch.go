@@ -1,6 +1,10 @@
 package netmap
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
 	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/audit"
 	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/governance"
 	"github.com/nspcc-dev/neofs-node/pkg/innerring/processors/settlement"
@@ -9,37 +13,95 @@ import (
 
 // Process new epoch notification by setting global epoch value and resetting
 // local epoch timer.
-func (np *Processor) processNewEpoch(epoch uint64) {
-	np.epochState.SetEpochCounter(epoch)
-	if err := np.epochTimer.ResetEpochTimer(); err != nil {
-		np.log.Warn("can't reset epoch timer",
+func (np *Processor) processNewEpoch(epoch uint64) error {
+	err := np.newEpoch(epoch, func(epoch uint64) {
+		np.handleCleanupTick(netmapCleanupTick{epoch: epoch})
+	})
+	if err != nil {
+		np.log.Warn("can't process new epoch",
+			zap.Uint64("epoch", epoch),
 			zap.String("error", err.Error()))
 	}
 
+	return err
+}
+
+// newEpoch applies new epoch to the Processor state and triggers the
+// dependent routines, cleanup is passed to cleanup function. Returns an
+// error if processing has been stopped or some routine failed.
+func (np *Processor) newEpoch(epoch uint64, cleanup func(uint64)) error {
+	np.epochState.SetEpochCounter(epoch)
+	np.resetEpochTimer()
+	np.addPeerDedup.reset()
+
 	// get new netmap snapshot
 	networkMap, err := np.netmapClient.Snapshot()
 	if err != nil {
-		np.log.Warn("can't get netmap snapshot to perform cleanup",
-			zap.String("error", err.Error()))
-
-		return
+		return fmt.Errorf("can't get netmap snapshot to perform cleanup: %w", err)
 	}
 
+	np.cacheSnapshot(networkMap, epoch)
+
+	var estimationErr error
+
 	if epoch > 0 { // estimates are invalid in genesis epoch
 		err = np.containerWrp.StartEstimation(epoch - 1)
-
 		if err != nil {
-			np.log.Warn("can't start container size estimation",
-				zap.Uint64("epoch", epoch),
-				zap.String("error", err.Error()))
+			// other routines do not depend on estimation
+			estimationErr = fmt.Errorf("can't start container size estimation: %w", err)
 		}
 	}
 
 	np.netmapSnapshot.update(networkMap, epoch)
-	np.handleCleanupTick(netmapCleanupTick{epoch: epoch})
+	np.storeState()
+	np.pruneNodeValidator(networkMap)
+	cleanup(epoch)
 	np.handleNewAudit(audit.NewAuditStartEvent(epoch))
 	np.handleAuditSettlements(settlement.NewAuditEvent(epoch))
 	np.handleAlphabetSync(governance.NewSyncEvent())
+
+	return estimationErr
+}
+
+// pruneNodeValidator drops the state of the nodes absent in the network map
+// if NodeValidator implements NodePruner.
+func (np *Processor) pruneNodeValidator(networkMap *netmap.Netmap) {
+	if p, ok := np.nodeValidator.(NodePruner); ok {
+		p.Prune(networkMap)
+	}
+}
+
+// resetEpochTimer resets epoch timer immediately or, if debounce period
+// is set, schedules the reset after it. Reset is postponed on each call
+// within the period, so the burst of the new epochs (e.g. during catch-up)
+// results in the single reset after the last one.
+func (np *Processor) resetEpochTimer() {
+	if np.epochTimerDebounce <= 0 {
+		np.doResetEpochTimer()
+		return
+	}
+
+	np.epochTimerMtx.Lock()
+	defer np.epochTimerMtx.Unlock()
+
+	if np.epochTimerReset != nil {
+		np.epochTimerReset.Stop()
+	}
+
+	// reset must not fire after the Close
+	if np.isClosed() {
+		np.epochTimerReset = nil
+		return
+	}
+
+	np.epochTimerReset = time.AfterFunc(np.epochTimerDebounce, np.doResetEpochTimer)
+}
+
+func (np *Processor) doResetEpochTimer() {
+	if err := np.epochTimer.ResetEpochTimer(); err != nil {
+		np.log.Warn("can't reset epoch timer",
+			zap.String("error", err.Error()))
+	}
 }
 
 // Process new epoch tick by invoking new epoch method in network map contract.
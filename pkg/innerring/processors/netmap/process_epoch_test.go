@@ -0,0 +1,66 @@
+package netmap
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+type testEpochTimer struct {
+	resets int32
+}
+
+func (t *testEpochTimer) ResetEpochTimer() error {
+	atomic.AddInt32(&t.resets, 1)
+	return nil
+}
+
+func (t *testEpochTimer) resetCount() int32 {
+	return atomic.LoadInt32(&t.resets)
+}
+
+func TestProcessor_EpochTimerResetDebounce(t *testing.T) {
+	const debounce = 100 * time.Millisecond
+
+	epoch := new(testEpochState)
+	timer := new(testEpochTimer)
+
+	np := &Processor{
+		log:        test.NewLogger(false),
+		epochState: epoch,
+		epochTimer: timer,
+		// snapshot failure stops processing right after the timer reset
+		netmapClient:       &testNetmapClient{err: errors.New("test error")},
+		epochTimerDebounce: debounce,
+	}
+
+	for i := uint64(1); i <= 5; i++ {
+		np.processNewEpoch(i)
+
+		// epoch counter advances on each event
+		require.EqualValues(t, i, epoch.EpochCounter())
+	}
+
+	require.Zero(t, timer.resetCount())
+
+	require.Eventually(t, func() bool {
+		return timer.resetCount() == 1
+	}, 10*debounce, debounce/10)
+
+	// no more resets after the burst
+	time.Sleep(2 * debounce)
+	require.EqualValues(t, 1, timer.resetCount())
+
+	t.Run("disabled", func(t *testing.T) {
+		np.epochTimerDebounce = 0
+
+		np.processNewEpoch(6)
+		np.processNewEpoch(7)
+
+		require.EqualValues(t, 3, timer.resetCount())
+	})
+}
@@ -1,7 +1,10 @@
 package netmap
 
 import (
+	"context"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"sort"
 	"strings"
 
@@ -12,10 +15,10 @@ import (
 
 // Process add peer notification by sanity check of new node
 // local epoch timer.
-func (np *Processor) processAddPeer(node []byte) {
+func (np *Processor) processAddPeer(node []byte) error {
 	if !np.alphabetState.IsAlphabet() {
 		np.log.Info("non alphabet mode, ignore new peer notification")
-		return
+		return nil
 	}
 
 	// unmarshal node info
@@ -23,20 +26,169 @@ func (np *Processor) processAddPeer(node []byte) {
 	if err := nodeInfo.Unmarshal(node); err != nil {
 		// it will be nice to have tx id at event structure to log it
 		np.log.Warn("can't parse network map candidate")
-		return
+		return fmt.Errorf("can't parse network map candidate: %w", err)
+	}
+
+	// keep the original version, data is already known to be correct
+	before := netmap.NewNodeInfo()
+	_ = before.Unmarshal(node)
+
+	decision := AdmissionDecision{
+		Candidate: nodeInfo,
+		Validator: DescribeValidator(np.nodeValidator),
 	}
 
+	defer func() {
+		decision.Time = np.now()
+		np.auditLog.Log(decision)
+	}()
+
 	// validate and update node info
-	err := np.nodeValidator.VerifyAndUpdate(nodeInfo)
+	var err error
+
+	if r, ok := np.nodeValidator.(NodeReporter); ok {
+		decision.Outcomes, err = r.VerifyAndReport(nodeInfo)
+	} else {
+		err = np.nodeValidator.VerifyAndUpdate(nodeInfo)
+	}
+
+	decision.Mutation = DiffNodeInfo(before, nodeInfo)
+
 	if err != nil {
 		np.log.Warn("could not verify and update information about network map candidate",
 			zap.String("error", err.Error()),
 		)
 
-		return
+		decision.ValidationError = err
+
+		np.rejectionSink.Record(nodeInfo, err)
+		np.recordAdmission(true)
+
+		return nil
+	}
+
+	np.recordAdmission(false)
+
+	if c, ok := np.nodeValidator.(NodeCommitter); ok {
+		c.Commit(nodeInfo)
 	}
 
-	// sort attributes to make it consistent
+	if np.onNodeMutated != nil && !decision.Mutation.Empty() {
+		np.onNodeMutated(before, nodeInfo)
+	}
+
+	sortAttributes(nodeInfo)
+
+	keyString := hex.EncodeToString(nodeInfo.PublicKey())
+
+	// candidate is compared with the network map regardless of the local
+	// view, so the changed information of the network map member is
+	// approved even if the member is already known, flagged nodes are
+	// approved again anyway since they are going to leave the network map
+	present, identical := np.compareWithNetmap(nodeInfo)
+	flagged := np.netmapSnapshot.contains(keyString) && !np.netmapSnapshot.active(keyString)
+
+	switch {
+	case identical && !flagged:
+		np.log.Info("network map candidate is already in the network map",
+			zap.String("key", keyString))
+
+		np.netmapSnapshot.touch(keyString, np.epochState.EpochCounter())
+
+		return nil
+	case !present && np.netmapSnapshot.approved(keyString):
+		// node is already approved, just remember its activity
+		np.netmapSnapshot.touch(keyString, np.epochState.EpochCounter())
+
+		return nil
+	}
+
+	np.log.Info("approving network map candidate",
+		zap.String("key", keyString))
+
+	err = np.netmapClient.AddPeer(nodeInfo)
+	if err != nil {
+		np.log.Error("can't invoke netmap.AddPeer", zap.Error(err))
+	}
+
+	decision.Approved = true
+	decision.ApprovalError = err
+
+	if err != nil {
+		return fmt.Errorf("can't invoke netmap.AddPeer: %w", err)
+	}
+
+	// node is remembered only after the successful approval, so the
+	// failed one is approved again on the repeated delivery
+	np.netmapSnapshot.touch(keyString, np.epochState.EpochCounter())
+
+	return nil
+}
+
+// Process add peer notification unless the same notification has been
+// already handled within the dedup window. Notification is remembered
+// only after successful processing, so the failed one is processed again
+// on the repeated delivery.
+func (np *Processor) processAddPeerOnce(node []byte) error {
+	if np.addPeerDedup.duplicate(node) {
+		np.log.Debug("repeated notification, skip",
+			zap.String("type", "add peer"))
+
+		return nil
+	}
+
+	if err := np.processAddPeer(node); err != nil {
+		return err
+	}
+
+	np.addPeerDedup.add(node)
+
+	return nil
+}
+
+// ForceAddPeer sends approval of the node to the network map contract
+// bypassing the node validator. It is a break-glass path for emergency
+// operations (e.g. re-admitting a known-good node rejected by the buggy
+// validator), and must not be used for the regular admission.
+//
+// Returns an error if Processor is not in alphabet mode.
+func (np *Processor) ForceAddPeer(ctx context.Context, nodeInfo *netmap.NodeInfo) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !np.alphabetState.IsAlphabet() {
+		return errors.New("non alphabet mode, forced admission is not allowed")
+	}
+
+	sortAttributes(nodeInfo)
+
+	keyString := hex.EncodeToString(nodeInfo.PublicKey())
+
+	np.log.Warn("FORCED ADMISSION: node validation is bypassed",
+		zap.String("key", keyString))
+
+	np.netmapSnapshot.touch(keyString, np.epochState.EpochCounter())
+
+	err := np.netmapClient.AddPeer(nodeInfo)
+
+	np.auditLog.Log(AdmissionDecision{
+		Time:          np.now(),
+		Candidate:     nodeInfo,
+		Approved:      true,
+		ApprovalError: err,
+		Forced:        true,
+	})
+
+	if err != nil {
+		return fmt.Errorf("can't invoke netmap.AddPeer: %w", err)
+	}
+
+	return nil
+}
+
+// sort attributes to make it consistent
+func sortAttributes(nodeInfo *netmap.NodeInfo) {
 	a := nodeInfo.Attributes()
 	sort.Slice(a, func(i, j int) bool {
 		switch strings.Compare(a[i].Key(), a[j].Key()) {
@@ -49,26 +201,18 @@ func (np *Processor) processAddPeer(node []byte) {
 		}
 	})
 	nodeInfo.SetAttributes(a...)
-
-	keyString := hex.EncodeToString(nodeInfo.PublicKey())
-
-	exists := np.netmapSnapshot.touch(keyString, np.epochState.EpochCounter())
-	if !exists {
-		np.log.Info("approving network map candidate",
-			zap.String("key", keyString))
-
-		err := np.netmapClient.AddPeer(nodeInfo)
-		if err != nil {
-			np.log.Error("can't invoke netmap.AddPeer", zap.Error(err))
-		}
-	}
 }
 
 // Process update peer notification by sending approval tx to the smart contract.
-func (np *Processor) processUpdatePeer(ev netmapEvent.UpdatePeer) {
+func (np *Processor) processUpdatePeer(ev netmapEvent.UpdatePeer) error {
 	if !np.alphabetState.IsAlphabet() {
 		np.log.Info("non alphabet mode, ignore update peer notification")
-		return
+		return nil
+	}
+
+	if ev.Maintenance() && np.netmapSnapshot.maintenanceGrace > 0 {
+		np.processMaintenance(ev)
+		return nil
 	}
 
 	// better use unified enum from neofs-api-go/v2/netmap package
@@ -77,7 +221,7 @@ func (np *Processor) processUpdatePeer(ev netmapEvent.UpdatePeer) {
 			zap.String("key", hex.EncodeToString(ev.PublicKey().Bytes())),
 			zap.Stringer("status", ev.Status()),
 		)
-		return
+		return fmt.Errorf("unknown node state %s", ev.Status())
 	}
 
 	// flag node to remove from local view, so it can be re-bootstrapped
@@ -87,5 +231,26 @@ func (np *Processor) processUpdatePeer(ev netmapEvent.UpdatePeer) {
 	err := np.netmapClient.UpdatePeerState(ev.PublicKey().Bytes(), ev.Status())
 	if err != nil {
 		np.log.Error("can't invoke netmap.UpdatePeer", zap.Error(err))
+		return fmt.Errorf("can't invoke netmap.UpdatePeer: %w", err)
+	}
+
+	return nil
+}
+
+// Process update peer notification with the maintenance state by exempting
+// the node from the cleanup. Network map contract does not support the state,
+// so nothing is sent to it.
+func (np *Processor) processMaintenance(ev netmapEvent.UpdatePeer) {
+	keyString := hex.EncodeToString(ev.PublicKey().Bytes())
+
+	if !np.netmapSnapshot.maintain(keyString, np.epochState.EpochCounter()) {
+		np.log.Info("unknown node proposes maintenance state, ignore",
+			zap.String("key", keyString))
+
+		return
 	}
+
+	np.log.Info("node is under maintenance",
+		zap.String("key", keyString),
+		zap.Uint64("grace", np.netmapSnapshot.maintenanceGrace))
 }
@@ -0,0 +1,483 @@
+package netmap
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/morph/event"
+	netmapEvent "github.com/nspcc-dev/neofs-node/pkg/morph/event/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+type testNodeValidator struct {
+	calls int
+}
+
+func (v *testNodeValidator) VerifyAndUpdate(*netmap.NodeInfo) error {
+	v.calls++
+	return errors.New("node is rejected")
+}
+
+func TestProcessor_ForceAddPeer(t *testing.T) {
+	epoch := testEpochState(1)
+	cli := new(testNetmapClient)
+	validator := new(testNodeValidator)
+	audits := new(memAuditLog)
+
+	np := &Processor{
+		log:            test.NewLogger(false),
+		epochState:     &epoch,
+		alphabetState:  testAlphabetState(true),
+		netmapClient:   cli,
+		netmapSnapshot: newCleanupTable(true, 1),
+		nodeValidator:  validator,
+		rejectionSink:  noopRejectionSink{},
+		auditLog:       audits,
+		now:            time.Now,
+	}
+
+	info := newNodeInfo(genKey(t).PublicKey())
+
+	t.Run("regular path rejects", func(t *testing.T) {
+		data, err := info.Marshal()
+		require.NoError(t, err)
+
+		np.processAddPeer(data)
+
+		require.Equal(t, 1, validator.calls)
+		require.Empty(t, cli.added)
+	})
+
+	t.Run("forced", func(t *testing.T) {
+		validator.calls = 0
+
+		require.NoError(t, np.ForceAddPeer(context.Background(), &info))
+
+		require.Zero(t, validator.calls)
+		require.Equal(t, []*netmap.NodeInfo{&info}, cli.added)
+		require.Contains(t, np.netmapSnapshot.lastAccess, hex.EncodeToString(info.PublicKey()))
+
+		require.Len(t, *audits, 2)
+
+		d := (*audits)[1]
+		require.True(t, d.Forced)
+		require.True(t, d.Approved)
+		require.NoError(t, d.ApprovalError)
+		require.Equal(t, info.PublicKey(), d.Candidate.PublicKey())
+
+		// regular decision is not forced
+		require.False(t, (*audits)[0].Forced)
+	})
+
+	t.Run("client failure", func(t *testing.T) {
+		testErr := errors.New("test error")
+		cli.err = testErr
+
+		err := np.ForceAddPeer(context.Background(), &info)
+		require.True(t, errors.Is(err, testErr))
+
+		d := (*audits)[len(*audits)-1]
+		require.True(t, d.Forced)
+		require.True(t, errors.Is(d.ApprovalError, testErr))
+
+		cli.err = nil
+	})
+
+	t.Run("non alphabet", func(t *testing.T) {
+		cli.added = nil
+		np.alphabetState = testAlphabetState(false)
+
+		logged := len(*audits)
+
+		require.Error(t, np.ForceAddPeer(context.Background(), &info))
+		require.Empty(t, cli.added)
+		require.Len(t, *audits, logged)
+
+		np.alphabetState = testAlphabetState(true)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := np.ForceAddPeer(ctx, &info)
+		require.True(t, errors.Is(err, context.Canceled))
+	})
+}
+
+type nodeValidatorFunc func(*netmap.NodeInfo) error
+
+func (f nodeValidatorFunc) VerifyAndUpdate(n *netmap.NodeInfo) error {
+	return f(n)
+}
+
+func nodeAttribute(key, val string) *netmap.NodeAttribute {
+	a := netmap.NewNodeAttribute()
+	a.SetKey(key)
+	a.SetValue(val)
+
+	return a
+}
+
+func TestProcessor_OnNodeMutated(t *testing.T) {
+	type mutation struct {
+		before, after *netmap.NodeInfo
+	}
+
+	var (
+		epoch     = testEpochState(1)
+		mutations []mutation
+		mutate    func(*netmap.NodeInfo)
+	)
+
+	np := &Processor{
+		log:            test.NewLogger(false),
+		epochState:     &epoch,
+		alphabetState:  testAlphabetState(true),
+		netmapClient:   new(testNetmapClient),
+		netmapSnapshot: newCleanupTable(true, 1),
+		nodeValidator: nodeValidatorFunc(func(n *netmap.NodeInfo) error {
+			mutate(n)
+			return nil
+		}),
+		onNodeMutated: func(before, after *netmap.NodeInfo) {
+			mutations = append(mutations, mutation{before: before, after: after})
+		},
+		auditLog: noopAuditLog{},
+		now:      time.Now,
+	}
+
+	addPeer := func(t *testing.T) {
+		info := newNodeInfo(genKey(t).PublicKey())
+		info.SetAddresses("/ip4/127.0.0.1/tcp/8080")
+		info.SetAttributes(
+			nodeAttribute("Capacity", "10"),
+			nodeAttribute("Continent", "Europe"),
+			nodeAttribute("Deprecated", "true"),
+		)
+
+		data, err := info.Marshal()
+		require.NoError(t, err)
+
+		np.processAddPeer(data)
+	}
+
+	t.Run("mutated", func(t *testing.T) {
+		mutations = nil
+		mutate = func(n *netmap.NodeInfo) {
+			n.SetAddresses("/dns4/localhost/tcp/8080")
+			n.SetAttributes(
+				nodeAttribute("Capacity", "100"),
+				nodeAttribute("Continent", "Europe"),
+				nodeAttribute("Probation", "true"),
+			)
+		}
+
+		addPeer(t)
+
+		require.Len(t, mutations, 1)
+
+		before, after := mutations[0].before, mutations[0].after
+
+		require.Equal(t, before.PublicKey(), after.PublicKey())
+		require.Equal(t, []string{"/ip4/127.0.0.1/tcp/8080"}, nodeAddresses(before))
+		require.Equal(t, []string{"/dns4/localhost/tcp/8080"}, nodeAddresses(after))
+
+		require.Equal(t, NodeInfoDiff{
+			Addresses:         true,
+			AddedAttributes:   []string{"Probation"},
+			RemovedAttributes: []string{"Deprecated"},
+			ChangedAttributes: []string{"Capacity"},
+		}, DiffNodeInfo(before, after))
+	})
+
+	t.Run("not mutated", func(t *testing.T) {
+		mutations = nil
+
+		for _, f := range []func(*netmap.NodeInfo){
+			func(*netmap.NodeInfo) {},
+			func(n *netmap.NodeInfo) {
+				// reordering is not a mutation
+				a := n.Attributes()
+				n.SetAttributes(a[2], a[0], a[1])
+			},
+		} {
+			mutate = f
+
+			addPeer(t)
+		}
+
+		require.Empty(t, mutations)
+	})
+}
+
+type rejection struct {
+	info *netmap.NodeInfo
+	err  error
+}
+
+type testRejectionSink struct {
+	rejections []rejection
+}
+
+func (s *testRejectionSink) Record(info *netmap.NodeInfo, err error) {
+	s.rejections = append(s.rejections, rejection{info: info, err: err})
+}
+
+func TestProcessor_RejectionSink(t *testing.T) {
+	var (
+		epoch  = testEpochState(1)
+		cli    = new(testNetmapClient)
+		sink   = new(testRejectionSink)
+		errBad = errors.New("bad candidate")
+	)
+
+	badKey := genKey(t).PublicKey().Bytes()
+
+	np := &Processor{
+		log:            test.NewLogger(false),
+		epochState:     &epoch,
+		alphabetState:  testAlphabetState(true),
+		netmapClient:   cli,
+		netmapSnapshot: newCleanupTable(true, 1),
+		nodeValidator: nodeValidatorFunc(func(n *netmap.NodeInfo) error {
+			if bytes.Equal(n.PublicKey(), badKey) {
+				return fmt.Errorf("validation: %w", errBad)
+			}
+
+			return nil
+		}),
+		rejectionSink: sink,
+		auditLog:      noopAuditLog{},
+		now:           time.Now,
+	}
+
+	for _, key := range [][]byte{genKey(t).PublicKey().Bytes(), badKey} {
+		info := netmap.NewNodeInfo()
+		info.SetPublicKey(key)
+		info.SetAttributes(nodeAttribute("Price", "10"))
+
+		data, err := info.Marshal()
+		require.NoError(t, err)
+
+		np.processAddPeer(data)
+	}
+
+	require.Len(t, cli.added, 1)
+	require.Len(t, sink.rejections, 1)
+
+	r := sink.rejections[0]
+	require.Equal(t, badKey, r.info.PublicKey())
+	require.Equal(t, "Price", r.info.Attributes()[0].Key())
+	require.True(t, errors.Is(r.err, errBad))
+}
+
+func TestProcessor_AddPeerInNetmap(t *testing.T) {
+	var (
+		unchanged = newNodeInfo(genKey(t).PublicKey())
+		changed   = newNodeInfo(genKey(t).PublicKey())
+		flagged   = newNodeInfo(genKey(t).PublicKey())
+	)
+
+	unchanged.SetAttributes(nodeAttribute("Price", "10"))
+	changed.SetAttributes(nodeAttribute("Price", "10"))
+	flagged.SetAttributes(nodeAttribute("Price", "10"))
+
+	nm, err := netmap.NewNetmap(netmap.NodesFromInfo([]netmap.NodeInfo{unchanged, changed, flagged}))
+	require.NoError(t, err)
+
+	epoch := testEpochState(5)
+	cli := &testNetmapClient{snapshot: nm}
+
+	np := &Processor{
+		log:            test.NewLogger(false),
+		epochState:     &epoch,
+		alphabetState:  testAlphabetState(true),
+		netmapClient:   cli,
+		netmapSnapshot: newCleanupTable(true, 1),
+		nodeValidator:  nodeValidatorFunc(func(*netmap.NodeInfo) error { return nil }),
+		rejectionSink:  noopRejectionSink{},
+		auditLog:       noopAuditLog{},
+		now:            time.Now,
+	}
+
+	// all the nodes are known to the local view
+	np.netmapSnapshot.update(nm, 5)
+	np.netmapSnapshot.flag(hex.EncodeToString(flagged.PublicKey()))
+
+	addPeer := func(info netmap.NodeInfo) {
+		data, err := info.Marshal()
+		require.NoError(t, err)
+
+		np.processAddPeer(data)
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		addPeer(unchanged)
+
+		require.Empty(t, cli.added)
+		require.Contains(t, np.netmapSnapshot.lastAccess, hex.EncodeToString(unchanged.PublicKey()))
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		var info netmap.NodeInfo
+		info.SetPublicKey(changed.PublicKey())
+		info.SetAttributes(nodeAttribute("Price", "20"))
+
+		addPeer(info)
+
+		require.Len(t, cli.added, 1)
+		require.Equal(t, changed.PublicKey(), cli.added[0].PublicKey())
+	})
+
+	t.Run("flagged", func(t *testing.T) {
+		addPeer(flagged)
+
+		require.Len(t, cli.added, 2)
+		require.Equal(t, flagged.PublicKey(), cli.added[1].PublicKey())
+	})
+
+	t.Run("unknown to the local view", func(t *testing.T) {
+		// e.g. after the restart
+		np.netmapSnapshot = newCleanupTable(true, 1)
+		defer func() { np.netmapSnapshot.update(nm, 5) }()
+
+		addPeer(unchanged)
+
+		require.Len(t, cli.added, 2)
+		require.True(t, np.netmapSnapshot.active(hex.EncodeToString(unchanged.PublicKey())))
+	})
+
+	// snapshot is fetched once per epoch
+	require.Equal(t, 1, cli.snapshots)
+
+	epoch = 6
+	addPeer(newNodeInfo(genKey(t).PublicKey()))
+
+	require.Equal(t, 2, cli.snapshots)
+	require.Len(t, cli.added, 3)
+}
+
+func TestProcessor_UpdatePeerMaintenance(t *testing.T) {
+	var (
+		epoch = testEpochState(2)
+		cli   = new(testNetmapClient)
+		key   = genKey(t).PublicKey().Bytes()
+	)
+
+	np := &Processor{
+		log:            test.NewLogger(false),
+		epochState:     &epoch,
+		alphabetState:  testAlphabetState(true),
+		netmapClient:   cli,
+		netmapSnapshot: newCleanupTable(true, 1),
+	}
+
+	keyString := hex.EncodeToString(key)
+	np.netmapSnapshot.touch(keyString, 1)
+
+	ev, err := netmapEvent.ParseUpdatePeer([]stackitem.Item{
+		stackitem.NewBigInteger(big.NewInt(netmapEvent.NodeStateMaintenance)),
+		stackitem.NewByteArray(key),
+	})
+	require.NoError(t, err)
+
+	t.Run("not supported", func(t *testing.T) {
+		require.Error(t, np.processUpdatePeer(ev.(netmapEvent.UpdatePeer)))
+		require.Equal(t, []string{keyString}, np.netmapSnapshot.removeCandidates(5))
+	})
+
+	t.Run("grace", func(t *testing.T) {
+		np.netmapSnapshot.maintenanceGrace = 5
+
+		require.NoError(t, np.processUpdatePeer(ev.(netmapEvent.UpdatePeer)))
+
+		// state is not sent to the contract
+		require.Empty(t, cli.updated)
+
+		// node is past the threshold, but not scheduled for removal
+		require.Empty(t, np.netmapSnapshot.removeCandidates(5))
+		require.Equal(t, []string{keyString}, np.netmapSnapshot.removeCandidates(8))
+	})
+}
+
+type statefulNodeValidator struct {
+	nodeValidatorFunc
+
+	committed [][]byte
+	pruned    []*netmap.Netmap
+}
+
+func (v *statefulNodeValidator) Commit(n *netmap.NodeInfo) {
+	v.committed = append(v.committed, n.PublicKey())
+}
+
+func (v *statefulNodeValidator) Prune(nm *netmap.Netmap) {
+	v.pruned = append(v.pruned, nm)
+}
+
+func TestProcessor_StatefulNodeValidator(t *testing.T) {
+	var (
+		epoch     = testEpochState(0)
+		badKey    = genKey(t).PublicKey().Bytes()
+		validator = &statefulNodeValidator{
+			nodeValidatorFunc: func(n *netmap.NodeInfo) error {
+				if bytes.Equal(n.PublicKey(), badKey) {
+					return errors.New("bad candidate")
+				}
+
+				return nil
+			},
+		}
+		snapshot = new(netmap.Netmap)
+		noop     = func(event.Event) {}
+	)
+
+	np := &Processor{
+		log:                    test.NewLogger(false),
+		epochState:             &epoch,
+		epochTimer:             new(testEpochTimer),
+		alphabetState:          testAlphabetState(true),
+		netmapClient:           &testNetmapClient{snapshot: snapshot},
+		netmapSnapshot:         newCleanupTable(true, 1),
+		nodeValidator:          validator,
+		rejectionSink:          noopRejectionSink{},
+		auditLog:               noopAuditLog{},
+		now:                    time.Now,
+		stateStore:             noopStateStore{},
+		handleNewAudit:         noop,
+		handleAuditSettlements: noop,
+		handleAlphabetSync:     noop,
+	}
+
+	addPeer := func(key []byte) {
+		info := netmap.NewNodeInfo()
+		info.SetPublicKey(key)
+
+		data, err := info.Marshal()
+		require.NoError(t, err)
+
+		require.NoError(t, np.processAddPeer(data))
+	}
+
+	key := genKey(t).PublicKey().Bytes()
+
+	addPeer(key)
+	addPeer(badKey)
+
+	// rejected candidate is not committed
+	require.Equal(t, [][]byte{key}, validator.committed)
+
+	// genesis epoch does not start the estimation
+	require.NoError(t, np.newEpoch(0, func(uint64) {}))
+	require.Equal(t, []*netmap.Netmap{snapshot}, validator.pruned)
+}
@@ -0,0 +1,56 @@
+package netmap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+)
+
+// ReconcileReport groups the changes applied to the local
+// network map snapshot during the reconciliation.
+type ReconcileReport struct {
+	// Hex-encoded public keys of the nodes that were presented in the
+	// contract network map but missing in the local snapshot.
+	Added []string
+
+	// Hex-encoded public keys of the nodes that were presented in the
+	// local snapshot but missing in the contract network map.
+	Removed []string
+}
+
+// Reconcile fetches the actual network map from the contract and corrects
+// the local snapshot used by the cleanup routine: missing nodes are added
+// with the current epoch stamp, phantom nodes are removed.
+//
+// Local snapshot can drift from the contract state because of missed
+// notifications, so it is safe to call Reconcile periodically.
+func (np *Processor) Reconcile(ctx context.Context) (ReconcileReport, error) {
+	var report ReconcileReport
+
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+
+	networkMap, err := np.netmapClient.Snapshot()
+	if err != nil {
+		return report, fmt.Errorf("could not get netmap snapshot: %w", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+
+	report.Added, report.Removed = np.netmapSnapshot.reconcile(networkMap, np.epochState.EpochCounter())
+
+	sort.Strings(report.Added)
+	sort.Strings(report.Removed)
+
+	np.log.Info("netmap snapshot reconciled",
+		zap.Int("added", len(report.Added)),
+		zap.Int("removed", len(report.Removed)),
+	)
+
+	return report, nil
+}
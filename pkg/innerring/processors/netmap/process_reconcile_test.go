@@ -0,0 +1,141 @@
+package netmap
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+type testNetmapClient struct {
+	contract util.Uint160
+
+	snapshot *netmap.Netmap
+	err      error
+
+	added     []*netmap.NodeInfo
+	updated   [][]byte
+	epochs    []uint64
+	snapshots int
+}
+
+func (c *testNetmapClient) Snapshot() (*netmap.Netmap, error) {
+	c.snapshots++
+	return c.snapshot, c.err
+}
+
+func (c *testNetmapClient) AddPeer(info *netmap.NodeInfo) error {
+	c.added = append(c.added, info)
+	return c.err
+}
+
+func (c *testNetmapClient) UpdatePeerState(key []byte, _ netmap.NodeState) error {
+	c.updated = append(c.updated, key)
+	return c.err
+}
+
+func (c *testNetmapClient) NewEpoch(epoch uint64) error {
+	c.epochs = append(c.epochs, epoch)
+	return c.err
+}
+
+func (c *testNetmapClient) ContractHash() util.Uint160 {
+	return c.contract
+}
+
+type testEpochState uint64
+
+func (s *testEpochState) SetEpochCounter(epoch uint64) {
+	*s = testEpochState(epoch)
+}
+
+func (s *testEpochState) EpochCounter() uint64 {
+	return uint64(*s)
+}
+
+func TestProcessor_Reconcile(t *testing.T) {
+	infos := []netmap.NodeInfo{
+		newNodeInfo(genKey(t).PublicKey()),
+		newNodeInfo(genKey(t).PublicKey()),
+		newNodeInfo(genKey(t).PublicKey()),
+	}
+
+	keys := make([]string, len(infos))
+	for i := range infos {
+		keys[i] = hex.EncodeToString(infos[i].PublicKey())
+	}
+
+	// local view knows about first two nodes only
+	localMap, err := netmap.NewNetmap(netmap.NodesFromInfo(infos[:2]))
+	require.NoError(t, err)
+
+	// contract knows about last two nodes only
+	contractMap, err := netmap.NewNetmap(netmap.NodesFromInfo(infos[1:]))
+	require.NoError(t, err)
+
+	epoch := testEpochState(5)
+	cli := &testNetmapClient{snapshot: contractMap}
+
+	np := &Processor{
+		log:            test.NewLogger(false),
+		epochState:     &epoch,
+		netmapClient:   cli,
+		netmapSnapshot: newCleanupTable(true, 1),
+	}
+
+	np.netmapSnapshot.update(localMap, 1)
+
+	t.Run("divergent netmap", func(t *testing.T) {
+		report, err := np.Reconcile(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []string{keys[2]}, report.Added)
+		require.Equal(t, []string{keys[0]}, report.Removed)
+
+		actual := make([]string, 0, len(np.netmapSnapshot.lastAccess))
+		for k := range np.netmapSnapshot.lastAccess {
+			actual = append(actual, k)
+		}
+
+		expected := []string{keys[1], keys[2]}
+
+		sort.Strings(actual)
+		sort.Strings(expected)
+
+		require.Equal(t, expected, actual)
+
+		// existing node keeps its stamp, new node gets current epoch
+		require.EqualValues(t, 1, np.netmapSnapshot.lastAccess[keys[1]].epoch)
+		require.EqualValues(t, 5, np.netmapSnapshot.lastAccess[keys[2]].epoch)
+	})
+
+	t.Run("consistent netmap", func(t *testing.T) {
+		report, err := np.Reconcile(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, report.Added)
+		require.Empty(t, report.Removed)
+	})
+
+	t.Run("client failure", func(t *testing.T) {
+		testErr := errors.New("test error")
+		cli.err = testErr
+
+		_, err := np.Reconcile(context.Background())
+		require.True(t, errors.Is(err, testErr))
+
+		cli.err = nil
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := np.Reconcile(ctx)
+		require.True(t, errors.Is(err, context.Canceled))
+	})
+}
@@ -3,11 +3,12 @@ package netmap
 import (
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
 	container "github.com/nspcc-dev/neofs-node/pkg/morph/client/container/wrapper"
-	nmWrapper "github.com/nspcc-dev/neofs-node/pkg/morph/client/netmap/wrapper"
 	"github.com/nspcc-dev/neofs-node/pkg/morph/event"
 	netmapEvent "github.com/nspcc-dev/neofs-node/pkg/morph/event/netmap"
 	"github.com/panjf2000/ants/v2"
@@ -46,6 +47,45 @@ type (
 		VerifyAndUpdate(*netmap.NodeInfo) error
 	}
 
+	// NodeCommitter is an optional interface of the stateful NodeValidator.
+	// Commit is called with the candidate accepted by the NodeValidator, so
+	// the state is not updated by the candidates rejected by the validators
+	// later in the chain.
+	NodeCommitter interface {
+		Commit(*netmap.NodeInfo)
+	}
+
+	// NodePruner is an optional interface of the stateful NodeValidator.
+	// Prune is called with the network map of each new epoch, so the state
+	// of the nodes that left the network map can be dropped.
+	NodePruner interface {
+		Prune(*netmap.Netmap)
+	}
+
+	// NodeReporter is an optional interface of the NodeValidator wrapping
+	// the other validators (e.g. the composite one). VerifyAndReport must
+	// behave like VerifyAndUpdate and additionally return the outcomes of
+	// the called wrapped validators in the order of the calls.
+	NodeReporter interface {
+		VerifyAndReport(*netmap.NodeInfo) ([]ValidatorOutcome, error)
+	}
+
+	// NetmapClient is an interface of the network map contract
+	// client used by the Processor.
+	NetmapClient interface {
+		Snapshot() (*netmap.Netmap, error)
+		AddPeer(*netmap.NodeInfo) error
+		UpdatePeerState([]byte, netmap.NodeState) error
+		NewEpoch(uint64) error
+		ContractHash() util.Uint160
+	}
+
+	// estimationStarter is an interface of the container contract
+	// client which starts container size estimations.
+	estimationStarter interface {
+		StartEstimation(epoch uint64) error
+	}
+
 	// Processor of events produced by network map contract
 	// and new epoch ticker, because it is related to contract.
 	Processor struct {
@@ -56,37 +96,193 @@ type (
 		epochState     EpochState
 		alphabetState  AlphabetState
 
-		netmapClient *nmWrapper.Wrapper
-		containerWrp *container.Wrapper
+		netmapClient NetmapClient
+		containerWrp estimationStarter
+
+		netmapSnapshot     cleanupTable
+		cleanupCoordinator CleanupCoordinator
+		onNodeEvicted      func(key []byte, lastEpoch uint64)
 
-		netmapSnapshot cleanupTable
+		stateStore    StateStore
+		stateStoreMtx sync.Mutex
+
+		// network map of the current epoch
+		snapshotCacheMtx   sync.Mutex
+		snapshotCache      *netmap.Netmap
+		snapshotCacheEpoch uint64
 
 		handleNewAudit         event.Handler
 		handleAuditSettlements event.Handler
 		handleAlphabetSync     event.Handler
 
 		nodeValidator NodeValidator
+		onNodeMutated func(before, after *netmap.NodeInfo)
+		rejectionSink RejectionSink
+		auditLog      AuditLog
+
+		rejections       *rejectionRate
+		onRejectionSpike func(rate float64)
+
+		// nil if AddPeer notifications are not deduplicated
+		addPeerDedup *dedupCache
+
+		chainHeightLag    func() int
+		throttleThreshold int
+		throttleDelay     time.Duration
+		sleep             func(time.Duration)
+
+		metrics Metrics
+
+		retryAttempts int
+		retryBackoff  time.Duration
+		retryQueue    chan struct{}
+
+		// submitted tasks awaited by Close
+		tasks        sync.WaitGroup
+		closeMtx     sync.RWMutex
+		closed       bool
+		closeTimeout time.Duration
+
+		epochDuration          time.Duration
+		epochDurationTolerance time.Duration
+		lastEpochMtx           sync.Mutex
+		lastEpochAt            time.Time
+		now                    func() time.Time
+
+		epochTimerDebounce time.Duration
+		epochTimerMtx      sync.Mutex
+		epochTimerReset    *time.Timer
+
+		notificationSource NotificationSource
+		replayMtx          sync.Mutex
+		replayed           bool
+		replayedHeight     uint32
 	}
 
 	// Params of the processor constructor.
 	Params struct {
-		Log      *zap.Logger
-		PoolSize int
-		// TODO(@fyrchik): add `ContractHash` method to the NetmapClient and remove this parameter.
-		NetmapContract   util.Uint160
-		NetmapClient     *nmWrapper.Wrapper
+		Log              *zap.Logger
+		PoolSize         int
+		NetmapClient     NetmapClient
 		EpochTimer       EpochTimerReseter
 		EpochState       EpochState
 		AlphabetState    AlphabetState
 		CleanupEnabled   bool
 		CleanupThreshold uint64 // in epochs
+		// Number of epochs since the node proposed the maintenance state
+		// during which the node is not voted to be removed. Maintenance
+		// state is not supported if zero.
+		MaintenanceGrace uint64
+		// Max number of the nodes voted to be removed per cleanup tick,
+		// the longest absent nodes go first. If CleanupCoordinator is set,
+		// limit is applied to the agreed nodes. Not limited if not positive.
+		CleanupLimit int
+		// Max number of the nodes absent in the network map (i.e. candidates)
+		// tracked in the local view of the network map which is used to skip
+		// repeated candidates within the epoch. Least recently accessed ones
+		// are evicted when the limit is exceeded, so the flood of the distinct
+		// candidates can not exhaust the memory. Nodes presented in the network
+		// map are never evicted. Not limited if not positive.
+		MaxTrackedNodes  int
 		ContainerWrapper *container.Wrapper
 
+		// Durable storage of the local view of the network map, so the
+		// epochs of the last activity of the nodes survive the restarts.
+		// State is loaded in New and stored on each new epoch. Optional.
+		StateStore StateStore
+
+		// Callback called with the public key of each node voted to be
+		// removed by the cleanup routine and the epoch of its last
+		// activity, before the vote is sent. Optional.
+		OnNodeEvicted func(key []byte, lastEpoch uint64)
+
+		// Coordinator of the cleanup between the alphabet nodes. If set,
+		// only the candidates agreed by the quorum are voted to be removed.
+		// Optional.
+		CleanupCoordinator CleanupCoordinator
+
 		HandleAudit             event.Handler
 		AuditSettlementsHandler event.Handler
 		AlphabetSyncHandler     event.Handler
 
 		NodeValidator NodeValidator
+
+		// Callback called with the original and the resulting information
+		// about the network map candidate if NodeValidator modified it
+		// (see DiffNodeInfo). Optional.
+		OnNodeMutated func(before, after *netmap.NodeInfo)
+
+		// Storage of the candidates rejected by NodeValidator. Optional.
+		RejectionSink RejectionSink
+
+		// Log of the admission decisions of the network map candidates.
+		// Optional.
+		AuditLog AuditLog
+
+		// Number of the last validated candidates over which the rate of
+		// the NodeValidator rejections is calculated (see Metrics). Rate
+		// is not tracked if not positive.
+		RejectionRateWindow int
+		// Rate of the rejections starting from which the spike is reported.
+		// Spike is reported once the window is filled, and again only after
+		// the rate has fallen below the threshold. Spikes are not reported
+		// if not positive.
+		RejectionSpikeThreshold float64
+		// Callback called with the rejection rate on each spike. Optional.
+		OnRejectionSpike func(rate float64)
+
+		// Period during which the repeated deliveries of the same AddPeer
+		// notification (i.e. the same public key and the information about
+		// the node) are skipped. Seen notifications are forgotten on each
+		// new epoch. Notifications are not deduplicated if not positive.
+		DedupWindow time.Duration
+		// Max number of the remembered AddPeer notifications, the oldest
+		// one is evicted on overflow. If not positive, defaultDedupMaxEntries
+		// is used.
+		DedupMaxEntries int
+
+		// ChainHeightLag returns number of blocks the node is behind
+		// the chain. Event handling is not throttled if nil.
+		ChainHeightLag func() int
+		// Lag in blocks starting from which event handling is throttled.
+		ThrottleLagThreshold int
+		// Delay before each event handling while the node is behind the
+		// chain. If not positive, defaultThrottleDelay is used.
+		ThrottleDelay time.Duration
+
+		// Collector of the event handling statistics. Optional.
+		Metrics Metrics
+
+		// Number of the attempts to resubmit the event rejected by the
+		// saturated worker pool before it is dropped. Events are dropped
+		// right away if not positive.
+		RetryAttempts int
+		// Delay before the first resubmission, it grows linearly with
+		// each next attempt. If not positive, defaultRetryBackoff is used.
+		RetryBackoff time.Duration
+		// Max number of the events awaiting resubmission at the same time,
+		// events rejected above it are dropped right away. If not positive,
+		// defaultRetryQueueSize is used.
+		RetryQueueSize int
+
+		// Max time Close waits for the events being handled. If not
+		// positive, defaultCloseTimeout is used.
+		CloseTimeout time.Duration
+
+		// Expected wall-clock interval between the new epochs. If positive,
+		// warning is logged when the actual interval differs from the expected
+		// one more than EpochDurationTolerance.
+		ExpectedEpochDuration  time.Duration
+		EpochDurationTolerance time.Duration
+
+		// If positive, epoch timer is reset only once after the burst
+		// of the new epochs: when there were no new epochs during
+		// the specified period.
+		EpochTimerResetDebounce time.Duration
+
+		// Source of the historical notifications of the network map
+		// contract used by ReplayFrom. Optional.
+		NotificationSource NotificationSource
 	}
 )
 
@@ -94,6 +290,12 @@ const (
 	newEpochNotification        = "NewEpoch"
 	addPeerNotification         = "AddPeer"
 	updatePeerStateNotification = "UpdateState"
+
+	// names of the internal events used in metrics
+	newEpochTickEvent      = "NewEpochTick"
+	netmapCleanupTickEvent = "NetmapCleanupTick"
+
+	defaultThrottleDelay = 100 * time.Millisecond
 )
 
 // New creates network map contract processor instance.
@@ -101,6 +303,8 @@ func New(p *Params) (*Processor, error) {
 	switch {
 	case p.Log == nil:
 		return nil, errors.New("ir/netmap: logger is not set")
+	case p.NetmapClient == nil:
+		return nil, errors.New("ir/netmap: netmap client is not set")
 	case p.EpochTimer == nil:
 		return nil, errors.New("ir/netmap: epoch itmer is not set")
 	case p.EpochState == nil:
@@ -126,24 +330,121 @@ func New(p *Params) (*Processor, error) {
 		return nil, fmt.Errorf("ir/netmap: can't create worker pool: %w", err)
 	}
 
-	return &Processor{
+	throttleDelay := p.ThrottleDelay
+	if throttleDelay <= 0 {
+		throttleDelay = defaultThrottleDelay
+	}
+
+	retryBackoff := p.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultRetryBackoff
+	}
+
+	retryQueueSize := p.RetryQueueSize
+	if retryQueueSize <= 0 {
+		retryQueueSize = defaultRetryQueueSize
+	}
+
+	closeTimeout := p.CloseTimeout
+	if closeTimeout <= 0 {
+		closeTimeout = defaultCloseTimeout
+	}
+
+	metrics := p.Metrics
+	if metrics == nil {
+		metrics = noopMetrics{}
+	}
+
+	rejectionSink := p.RejectionSink
+	if rejectionSink == nil {
+		rejectionSink = noopRejectionSink{}
+	}
+
+	auditLog := p.AuditLog
+	if auditLog == nil {
+		auditLog = noopAuditLog{}
+	}
+
+	stateStore := p.StateStore
+	if stateStore == nil {
+		stateStore = noopStateStore{}
+	}
+
+	var addPeerDedup *dedupCache
+	if p.DedupWindow > 0 {
+		addPeerDedup = newDedupCache(p.DedupWindow, p.DedupMaxEntries, func() {
+			metrics.StateEvicted(addPeerDedupStructure)
+		})
+	}
+
+	var rejections *rejectionRate
+	if p.RejectionRateWindow > 0 {
+		rejections = newRejectionRate(p.RejectionRateWindow, p.RejectionSpikeThreshold)
+	}
+
+	netmapSnapshot := newCleanupTable(p.CleanupEnabled, p.CleanupThreshold)
+	netmapSnapshot.limit = p.CleanupLimit
+	netmapSnapshot.maintenanceGrace = p.MaintenanceGrace
+	netmapSnapshot.setMaxEntries(p.MaxTrackedNodes, func(string) {
+		metrics.StateEvicted(cleanupTableStructure)
+	})
+
+	np := &Processor{
 		log:            p.Log,
 		pool:           pool,
-		netmapContract: p.NetmapContract,
+		netmapContract: p.NetmapClient.ContractHash(),
 		epochTimer:     p.EpochTimer,
 		epochState:     p.EpochState,
 		alphabetState:  p.AlphabetState,
 		netmapClient:   p.NetmapClient,
 		containerWrp:   p.ContainerWrapper,
-		netmapSnapshot: newCleanupTable(p.CleanupEnabled, p.CleanupThreshold),
+		netmapSnapshot: netmapSnapshot,
 		handleNewAudit: p.HandleAudit,
 
+		cleanupCoordinator: p.CleanupCoordinator,
+		onNodeEvicted:      p.OnNodeEvicted,
+
+		stateStore: stateStore,
+
 		handleAuditSettlements: p.AuditSettlementsHandler,
 
 		handleAlphabetSync: p.AlphabetSyncHandler,
 
 		nodeValidator: p.NodeValidator,
-	}, nil
+		onNodeMutated: p.OnNodeMutated,
+		rejectionSink: rejectionSink,
+		auditLog:      auditLog,
+
+		rejections:       rejections,
+		onRejectionSpike: p.OnRejectionSpike,
+
+		addPeerDedup: addPeerDedup,
+
+		chainHeightLag:    p.ChainHeightLag,
+		throttleThreshold: p.ThrottleLagThreshold,
+		throttleDelay:     throttleDelay,
+		sleep:             time.Sleep,
+
+		metrics: metrics,
+
+		retryAttempts: p.RetryAttempts,
+		retryBackoff:  retryBackoff,
+		retryQueue:    make(chan struct{}, retryQueueSize),
+
+		closeTimeout: closeTimeout,
+
+		epochDuration:          p.ExpectedEpochDuration,
+		epochDurationTolerance: p.EpochDurationTolerance,
+		now:                    time.Now,
+
+		epochTimerDebounce: p.EpochTimerResetDebounce,
+
+		notificationSource: p.NotificationSource,
+	}
+
+	np.loadState()
+
+	return np, nil
 }
 
 // ListenerParsers for the 'event.Listener' event producer.
@@ -0,0 +1,20 @@
+package netmap
+
+import (
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+)
+
+// RejectionSink is an interface of the durable storage of the network map
+// candidates rejected by the NodeValidator (e.g. contract, database or
+// audit log).
+type RejectionSink interface {
+	// Record is called with the rejected candidate and the validation error.
+	//
+	// Record is called synchronously from the event handler,
+	// so it should not block for long.
+	Record(info *netmap.NodeInfo, err error)
+}
+
+type noopRejectionSink struct{}
+
+func (noopRejectionSink) Record(*netmap.NodeInfo, error) {}
@@ -0,0 +1,93 @@
+package netmap
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// rejectionRate is a rolling rate of the candidates rejected by the node
+// validator over the fixed number of the last admissions.
+type rejectionRate struct {
+	mtx sync.Mutex
+
+	// ring buffer of the admission outcomes: true if rejected
+	window []bool
+
+	next, size, rejected int
+
+	threshold float64
+
+	// true while the rate is not less than the threshold
+	spiking bool
+}
+
+func newRejectionRate(window int, threshold float64) *rejectionRate {
+	return &rejectionRate{
+		window:    make([]bool, window),
+		threshold: threshold,
+	}
+}
+
+// record adds the admission outcome to the window and returns the current
+// rate. Spike is reported once when the rate of the full window reaches
+// the threshold, and again only after the rate has fallen below it.
+func (r *rejectionRate) record(rejected bool) (rate float64, spike bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.size == len(r.window) {
+		if r.window[r.next] {
+			r.rejected--
+		}
+	} else {
+		r.size++
+	}
+
+	r.window[r.next] = rejected
+	r.next = (r.next + 1) % len(r.window)
+
+	if rejected {
+		r.rejected++
+	}
+
+	rate = float64(r.rejected) / float64(r.size)
+
+	if r.threshold <= 0 || r.size < len(r.window) {
+		return rate, false
+	}
+
+	if rate < r.threshold {
+		r.spiking = false
+		return rate, false
+	}
+
+	spike = !r.spiking
+	r.spiking = true
+
+	return rate, spike
+}
+
+// recordAdmission updates the rejection rate with the outcome
+// of the candidate validation.
+func (np *Processor) recordAdmission(rejected bool) {
+	if np.rejections == nil {
+		return
+	}
+
+	rate, spike := np.rejections.record(rejected)
+
+	np.metrics.RejectionRate(rate)
+
+	if !spike {
+		return
+	}
+
+	np.log.Warn("spike of network map candidate rejections",
+		zap.Float64("rate", rate),
+		zap.Float64("threshold", np.rejections.threshold))
+
+	if np.onRejectionSpike != nil {
+		np.onRejectionSpike(rate)
+	}
+}
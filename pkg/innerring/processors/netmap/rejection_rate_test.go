@@ -0,0 +1,98 @@
+package netmap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRejectionRate(t *testing.T) {
+	r := newRejectionRate(4, 0.5)
+
+	for _, tc := range []struct {
+		rejected bool
+		rate     float64
+		spike    bool
+	}{
+		// window is not filled yet
+		{true, 1, false},
+		{true, 1, false},
+		{false, 2.0 / 3, false},
+		{false, 0.5, true},
+		// spike is reported once
+		{true, 0.5, false},
+		// rate falls below the threshold
+		{false, 0.25, false},
+		{false, 0.25, false},
+		{true, 0.5, true},
+	} {
+		rate, spike := r.record(tc.rejected)
+		require.Equal(t, tc.rate, rate)
+		require.Equal(t, tc.spike, spike)
+	}
+}
+
+func TestProcessor_RejectionSpike(t *testing.T) {
+	const window = 10
+
+	var (
+		epoch   = testEpochState(1)
+		metrics = new(testMetrics)
+		spikes  []float64
+		reject  bool
+	)
+
+	np := &Processor{
+		log:            test.NewLogger(false),
+		epochState:     &epoch,
+		alphabetState:  testAlphabetState(true),
+		netmapClient:   new(testNetmapClient),
+		netmapSnapshot: newCleanupTable(true, 1),
+		nodeValidator: nodeValidatorFunc(func(*netmap.NodeInfo) error {
+			if reject {
+				return errors.New("bad candidate")
+			}
+
+			return nil
+		}),
+		rejectionSink: noopRejectionSink{},
+		auditLog:      noopAuditLog{},
+		now:           time.Now,
+		metrics:       metrics,
+		rejections:    newRejectionRate(window, 0.3),
+		onRejectionSpike: func(rate float64) {
+			spikes = append(spikes, rate)
+		},
+	}
+
+	addPeer := func() {
+		info := newNodeInfo(genKey(t).PublicKey())
+
+		data, err := info.Marshal()
+		require.NoError(t, err)
+
+		np.processAddPeer(data)
+	}
+
+	for i := 0; i < window; i++ {
+		addPeer()
+	}
+
+	require.Empty(t, spikes)
+	require.Len(t, metrics.rejectionRates, window)
+	require.Zero(t, metrics.rejectionRates[window-1])
+
+	// burst of rejections
+	reject = true
+
+	for i := 0; i < window/2; i++ {
+		addPeer()
+	}
+
+	require.Equal(t, []float64{0.3}, spikes)
+	require.Equal(t, 0.5, metrics.rejectionRates[len(metrics.rejectionRates)-1])
+}
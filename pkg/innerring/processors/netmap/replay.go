@@ -0,0 +1,303 @@
+package netmap
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/morph/client"
+	"github.com/nspcc-dev/neofs-node/pkg/morph/event"
+	netmapEvent "github.com/nspcc-dev/neofs-node/pkg/morph/event/netmap"
+	"go.uber.org/zap"
+)
+
+type (
+	// Notification is a contract notification thrown in the particular block.
+	Notification struct {
+		// Height of the block in which notification was thrown.
+		Height uint32
+
+		*state.NotificationEvent
+	}
+
+	// NotificationSource is an interface of the storage of the
+	// historical contract notifications.
+	NotificationSource interface {
+		// Notifications must return notifications of the contract thrown
+		// in the blocks starting from the specified height. Notifications
+		// must be ordered by the block height and by the order of emission
+		// within the block.
+		Notifications(ctx context.Context, contract util.Uint160, fromHeight uint32) ([]Notification, error)
+	}
+)
+
+var errNoNotificationSource = errors.New("notification source is not set")
+
+// ReplayFrom re-fetches network map contract notifications thrown starting
+// from the specified block height and applies them to the local state of
+// the processor. It is used to restore the state of the processor after the
+// missed events (e.g. the downtime of the node).
+//
+// Replayed events are processed synchronously bypassing the worker pool, so
+// the events are not dropped if the pool is drained. New epoch events only
+// update the local epoch and, once per replay, the local view of the network
+// map: container size estimation, audit, settlements and alphabet sync are
+// not triggered for the missed epochs. Events that have already been applied
+// or that are outdated are skipped:
+//   - new epoch events not greater than the current epoch;
+//   - offline state of the nodes that are unknown or already flagged;
+//   - candidates superseded by the later new epoch or the state update
+//     of the same node;
+//   - candidates that are already known or repeated within the dedup
+//     window (as for the regular events).
+//
+// Events that failed to be applied are logged and do not stop the replay,
+// the error of the first one is returned along with the number of the
+// failures.
+//
+// Blocks processed by the previous replays are not replayed again. Blocks
+// starting from the one with the first failed event are replayed again by
+// the next replay.
+//
+// Since the known nodes are taken from the local view of the network map,
+// it should be synchronized with the chain (see Reconcile) before the replay.
+func (np *Processor) ReplayFrom(ctx context.Context, fromHeight uint32) error {
+	if np.notificationSource == nil {
+		return errNoNotificationSource
+	}
+
+	np.replayMtx.Lock()
+	defer np.replayMtx.Unlock()
+
+	if np.replayed && fromHeight <= np.replayedHeight {
+		fromHeight = np.replayedHeight + 1
+	}
+
+	ntfs, err := np.notificationSource.Notifications(ctx, np.netmapContract, fromHeight)
+	if err != nil {
+		return fmt.Errorf("could not fetch netmap contract notifications: %w", err)
+	}
+
+	evs := np.parseReplayed(ntfs, fromHeight)
+	superseded := supersededCandidates(evs)
+	epoch := np.epochState.EpochCounter()
+
+	var (
+		failed   int
+		firstErr error
+	)
+
+	for i := range evs {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		// all notifications of the previous blocks are processed
+		if i > 0 && evs[i].height > evs[i-1].height && firstErr == nil {
+			np.markReplayed(evs[i-1].height)
+		}
+
+		if superseded[i] {
+			np.log.Debug("skip superseded network map candidate on replay",
+				zap.Uint32("height", evs[i].height))
+
+			continue
+		}
+
+		if rErr := np.replayEvent(evs[i].ev); rErr != nil {
+			np.log.Warn("could not apply replayed notification",
+				zap.Uint32("height", evs[i].height),
+				zap.String("error", rErr.Error()))
+
+			if firstErr == nil {
+				firstErr = rErr
+			}
+
+			failed++
+		}
+	}
+
+	if replayedEpoch := np.epochState.EpochCounter(); replayedEpoch > epoch {
+		np.replayNewEpoch(replayedEpoch)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if firstErr != nil {
+		return fmt.Errorf("could not apply %d replayed notifications: %w", failed, firstErr)
+	}
+
+	if len(ntfs) > 0 {
+		np.markReplayed(ntfs[len(ntfs)-1].Height)
+	}
+
+	return nil
+}
+
+type replayedEvent struct {
+	height uint32
+
+	ev event.Event
+}
+
+// parseReplayed returns the events of the network map contract notifications
+// thrown starting from the specified height. Unknown and invalid notifications
+// are skipped.
+func (np *Processor) parseReplayed(ntfs []Notification, fromHeight uint32) []replayedEvent {
+	parsers := make(map[string]event.Parser)
+
+	for _, p := range np.ListenerParsers() {
+		parsers[p.GetType().String()] = p.Parser()
+	}
+
+	evs := make([]replayedEvent, 0, len(ntfs))
+
+	for i := range ntfs {
+		if ntfs[i].Height < fromHeight || !ntfs[i].ScriptHash.Equals(np.netmapContract) {
+			continue
+		}
+
+		parser, ok := parsers[ntfs[i].Name]
+		if !ok {
+			np.log.Debug("skip unknown netmap notification on replay",
+				zap.String("name", ntfs[i].Name))
+
+			continue
+		}
+
+		arr, err := client.ArrayFromStackItem(ntfs[i].Item)
+		if err != nil {
+			np.log.Warn("could not get parameters of replayed notification",
+				zap.String("name", ntfs[i].Name),
+				zap.Uint32("height", ntfs[i].Height),
+				zap.String("error", err.Error()))
+
+			continue
+		}
+
+		ev, err := parser(arr)
+		if err != nil {
+			np.log.Warn("could not parse replayed notification",
+				zap.String("name", ntfs[i].Name),
+				zap.Uint32("height", ntfs[i].Height),
+				zap.String("error", err.Error()))
+
+			continue
+		}
+
+		evs = append(evs, replayedEvent{
+			height: ntfs[i].Height,
+			ev:     ev,
+		})
+	}
+
+	return evs
+}
+
+// supersededCandidates marks the add peer events followed by the new epoch
+// event or by the state update of the same node. Such candidates have been
+// either already handled by the network map contract or outdated, so they
+// must not be approved again.
+func supersededCandidates(evs []replayedEvent) []bool {
+	var (
+		res = make([]bool, len(evs))
+
+		laterEpoch bool
+		// hex-encoded public keys of the nodes updated later
+		laterUpdates = make(map[string]struct{})
+	)
+
+	for i := len(evs) - 1; i >= 0; i-- {
+		switch e := evs[i].ev.(type) {
+		case netmapEvent.NewEpoch:
+			laterEpoch = true
+		case netmapEvent.UpdatePeer:
+			laterUpdates[hex.EncodeToString(e.PublicKey().Bytes())] = struct{}{}
+		case netmapEvent.AddPeer:
+			if laterEpoch {
+				res[i] = true
+				break
+			}
+
+			nodeInfo := netmap.NewNodeInfo()
+			if err := nodeInfo.Unmarshal(e.Node()); err != nil {
+				// invalid candidate is rejected on processing
+				break
+			}
+
+			_, res[i] = laterUpdates[hex.EncodeToString(nodeInfo.PublicKey())]
+		}
+	}
+
+	return res
+}
+
+func (np *Processor) markReplayed(height uint32) {
+	if !np.replayed || height > np.replayedHeight {
+		np.replayed = true
+		np.replayedHeight = height
+	}
+}
+
+func (np *Processor) replayEvent(ev event.Event) error {
+	switch e := ev.(type) {
+	case netmapEvent.NewEpoch:
+		if e.EpochNumber() <= np.epochState.EpochCounter() {
+			return nil
+		}
+
+		np.log.Info("replay notification",
+			zap.String("type", "new epoch"),
+			zap.Uint64("value", e.EpochNumber()))
+
+		np.epochState.SetEpochCounter(e.EpochNumber())
+		np.addPeerDedup.reset()
+	case netmapEvent.AddPeer:
+		np.log.Info("replay notification",
+			zap.String("type", "add peer"))
+
+		// known and repeated candidates are not approved again
+		return np.processAddPeerOnce(e.Node())
+	case netmapEvent.UpdatePeer:
+		keyString := hex.EncodeToString(e.PublicKey().Bytes())
+
+		if !np.netmapSnapshot.active(keyString) {
+			return nil
+		}
+
+		np.log.Info("replay notification",
+			zap.String("type", "update peer state"),
+			zap.String("key", keyString))
+
+		return np.processUpdatePeer(e)
+	}
+
+	return nil
+}
+
+// replayNewEpoch applies the last replayed epoch to the local state: resets
+// the epoch timer and updates the local view of the network map. Unlike the
+// regular new epoch, no dependent routines are triggered.
+func (np *Processor) replayNewEpoch(epoch uint64) {
+	np.resetEpochTimer()
+
+	networkMap, err := np.netmapClient.Snapshot()
+	if err != nil {
+		np.log.Warn("can't get netmap snapshot to update local view on replay",
+			zap.Uint64("epoch", epoch),
+			zap.String("error", err.Error()))
+
+		return
+	}
+
+	np.cacheSnapshot(networkMap, epoch)
+	np.netmapSnapshot.update(networkMap, epoch)
+	np.storeState()
+	np.pruneNodeValidator(networkMap)
+}
@@ -0,0 +1,186 @@
+package netmap
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	v2netmap "github.com/nspcc-dev/neofs-api-go/v2/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+type testNotificationSource struct {
+	ntfs []Notification
+
+	requested []uint32
+}
+
+func (s *testNotificationSource) Notifications(_ context.Context, _ util.Uint160, from uint32) ([]Notification, error) {
+	s.requested = append(s.requested, from)
+
+	var res []Notification
+
+	for i := range s.ntfs {
+		if s.ntfs[i].Height >= from {
+			res = append(res, s.ntfs[i])
+		}
+	}
+
+	return res, nil
+}
+
+func (s *testNotificationSource) add(height uint32, name string, items ...stackitem.Item) {
+	s.ntfs = append(s.ntfs, Notification{
+		Height: height,
+		NotificationEvent: &state.NotificationEvent{
+			Name: name,
+			Item: stackitem.NewArray(items),
+		},
+	})
+}
+
+func (s *testNotificationSource) addNewEpoch(height uint32, epoch uint64) {
+	s.add(height, newEpochNotification,
+		stackitem.NewBigInteger(new(big.Int).SetUint64(epoch)))
+}
+
+func (s *testNotificationSource) addPeer(t *testing.T, height uint32, info netmap.NodeInfo) {
+	data, err := info.Marshal()
+	require.NoError(t, err)
+
+	s.add(height, addPeerNotification, stackitem.NewByteArray(data))
+}
+
+func (s *testNotificationSource) addOffline(height uint32, key *keys.PublicKey) {
+	s.add(height, updatePeerStateNotification,
+		stackitem.NewBigInteger(big.NewInt(int64(v2netmap.Offline))),
+		stackitem.NewByteArray(key.Bytes()))
+}
+
+func TestProcessor_ReplayFrom(t *testing.T) {
+	var (
+		known   = newNodeInfo(genKey(t).PublicKey())
+		flagged = newNodeInfo(genKey(t).PublicKey())
+		fresh   = newNodeInfo(genKey(t).PublicKey())
+	)
+
+	epoch := testEpochState(5)
+	cli := &testNetmapClient{snapshot: new(netmap.Netmap)}
+	src := new(testNotificationSource)
+
+	np := &Processor{
+		log:                test.NewLogger(false),
+		epochTimer:         new(testEpochTimer),
+		epochState:         &epoch,
+		alphabetState:      testAlphabetState(true),
+		netmapClient:       cli,
+		netmapSnapshot:     newCleanupTable(true, 1),
+		nodeValidator:      nodeValidatorFunc(func(*netmap.NodeInfo) error { return nil }),
+		rejectionSink:      noopRejectionSink{},
+		auditLog:           noopAuditLog{},
+		now:                time.Now,
+		notificationSource: src,
+		stateStore:         noopStateStore{},
+	}
+
+	np.netmapSnapshot.touch(hex.EncodeToString(known.PublicKey()), 5)
+	np.netmapSnapshot.touch(hex.EncodeToString(flagged.PublicKey()), 5)
+	np.netmapSnapshot.flag(hex.EncodeToString(flagged.PublicKey()))
+
+	t.Run("no source", func(t *testing.T) {
+		np := &Processor{}
+
+		require.True(t, errors.Is(np.ReplayFrom(context.Background(), 0), errNoNotificationSource))
+	})
+
+	src.addNewEpoch(9, 4)
+	src.addNewEpoch(10, 5)
+	src.addPeer(t, 10, known)
+	src.addPeer(t, 11, fresh)
+	src.addOffline(11, flagged.PublicKey())
+	src.addOffline(12, known.PublicKey())
+	src.add(12, "Unknown")
+	src.addNewEpoch(13, 6)
+
+	require.NoError(t, np.ReplayFrom(context.Background(), 10))
+
+	require.EqualValues(t, 6, epoch)
+	// candidates thrown before the new epoch are superseded by it
+	require.Empty(t, cli.added)
+	require.Equal(t, [][]byte{known.PublicKey()}, cli.updated)
+	// dependent routines are not triggered, only the local state is updated
+	require.Empty(t, cli.epochs)
+	require.Equal(t, 1, cli.snapshots)
+
+	t.Run("overlapping range", func(t *testing.T) {
+		src.addNewEpoch(14, 7)
+
+		require.NoError(t, np.ReplayFrom(context.Background(), 10))
+
+		require.Equal(t, []uint32{10, 14}, src.requested)
+		require.EqualValues(t, 7, epoch)
+		require.Empty(t, cli.added)
+		require.Len(t, cli.updated, 1)
+	})
+
+	t.Run("not superseded candidates", func(t *testing.T) {
+		np.addPeerDedup = newDedupCache(time.Hour, 0, nil)
+		defer func() { np.addPeerDedup = nil }()
+
+		updated := newNodeInfo(genKey(t).PublicKey())
+
+		src.addPeer(t, 15, fresh)
+		src.addPeer(t, 15, updated)
+		src.addPeer(t, 16, fresh)
+		src.addOffline(16, updated.PublicKey())
+
+		require.NoError(t, np.ReplayFrom(context.Background(), 15))
+
+		require.Len(t, cli.added, 1)
+		require.Equal(t, fresh.PublicKey(), cli.added[0].PublicKey())
+
+		data, err := fresh.Marshal()
+		require.NoError(t, err)
+		require.True(t, np.addPeerDedup.duplicate(data))
+	})
+
+	t.Run("failed notifications", func(t *testing.T) {
+		testErr := errors.New("test error")
+		cli.err = testErr
+
+		failed := newNodeInfo(genKey(t).PublicKey())
+
+		src.addPeer(t, 17, failed)
+
+		err := np.ReplayFrom(context.Background(), 0)
+		require.True(t, errors.Is(err, testErr), err)
+		require.Len(t, cli.added, 2)
+
+		// block with the failed notification is replayed again
+		cli.err = nil
+
+		require.NoError(t, np.ReplayFrom(context.Background(), 0))
+		require.Equal(t, []uint32{17, 17}, src.requested[len(src.requested)-2:])
+		require.Len(t, cli.added, 3)
+		require.Equal(t, failed.PublicKey(), cli.added[2].PublicKey())
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		src.addNewEpoch(18, 8)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		require.True(t, errors.Is(np.ReplayFrom(ctx, 0), context.Canceled))
+		require.EqualValues(t, 7, epoch)
+	})
+}
@@ -0,0 +1,111 @@
+package netmap
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRetryBackoff   = 100 * time.Millisecond
+	defaultRetryQueueSize = 16
+)
+
+// submit sends the task to the worker pool. If the pool is saturated,
+// submission is retried in the background (see Params.RetryAttempts),
+// so the caller (i.e. event listener) is never blocked. Task is dropped
+// if all the attempts have failed or the retry queue is full.
+//
+// Submitted tasks are awaited by Close, tasks are ignored after it.
+func (np *Processor) submit(event string, handle func()) {
+	np.closeMtx.RLock()
+	defer np.closeMtx.RUnlock()
+
+	if np.closed {
+		np.log.Debug("netmap processor is closed, ignore event",
+			zap.String("event", event))
+
+		return
+	}
+
+	np.tasks.Add(1)
+
+	task := func() {
+		defer np.tasks.Done()
+		handle()
+	}
+
+	err := np.pool.Submit(task)
+	if err == nil {
+		return
+	}
+
+	np.logPoolDrained(event, 0, err)
+
+	if np.retryAttempts <= 0 || !np.acquireRetrySlot() {
+		np.dropEvent(event)
+		return
+	}
+
+	np.retrySubmit(event, task, 1)
+}
+
+// retrySubmit schedules the attempt to submit the task after the delay
+// which grows linearly with the attempt number. Retry slot is released
+// once the task is submitted or dropped.
+func (np *Processor) retrySubmit(event string, task func(), attempt int) {
+	time.AfterFunc(time.Duration(attempt)*np.retryBackoff, func() {
+		if np.isClosed() {
+			<-np.retryQueue
+			np.dropEvent(event)
+
+			return
+		}
+
+		err := np.pool.Submit(task)
+		if err == nil {
+			<-np.retryQueue
+			return
+		}
+
+		np.logPoolDrained(event, attempt, err)
+
+		if attempt >= np.retryAttempts {
+			<-np.retryQueue
+			np.dropEvent(event)
+
+			return
+		}
+
+		np.retrySubmit(event, task, attempt+1)
+	})
+}
+
+func (np *Processor) acquireRetrySlot() bool {
+	select {
+	case np.retryQueue <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (np *Processor) logPoolDrained(event string, attempt int, err error) {
+	// there system can be moved into controlled degradation stage
+	np.log.Warn("netmap worker pool drained",
+		zap.String("event", event),
+		zap.Int("attempt", attempt),
+		zap.Int("capacity", np.pool.Cap()),
+		zap.String("error", err.Error()))
+}
+
+// dropEvent reports the event which task has not been submitted,
+// so the task is no longer awaited by Close.
+func (np *Processor) dropEvent(event string) {
+	np.log.Warn("netmap event dropped",
+		zap.String("event", event))
+
+	np.metrics.PoolRejected(event)
+
+	np.tasks.Done()
+}
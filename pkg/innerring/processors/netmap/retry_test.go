@@ -0,0 +1,84 @@
+package netmap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_RetrySubmit(t *testing.T) {
+	key := genKey(t).PublicKey().Bytes()
+
+	newProcessor := func(t *testing.T, attempts, queueSize int) (*Processor, *eventMetrics, chan struct{}) {
+		pool := newTestPool(t)
+
+		// occupy the only worker
+		release := make(chan struct{})
+		require.NoError(t, pool.Submit(func() { <-release }))
+
+		metrics := newEventMetrics()
+
+		return &Processor{
+			log:            test.NewLogger(false),
+			pool:           pool,
+			alphabetState:  testAlphabetState(true),
+			netmapClient:   new(testNetmapClient),
+			netmapSnapshot: newCleanupTable(true, 1),
+			metrics:        metrics,
+			retryAttempts:  attempts,
+			retryBackoff:   10 * time.Millisecond,
+			retryQueue:     make(chan struct{}, queueSize),
+		}, metrics, release
+	}
+
+	t.Run("retries disabled", func(t *testing.T) {
+		np, metrics, release := newProcessor(t, 0, 1)
+		defer close(release)
+
+		np.handleUpdateState(updatePeerEvent(t, netmap.NodeStateOffline, key))
+
+		require.Equal(t, 1, metrics.count(metrics.rejected, updatePeerStateNotification))
+	})
+
+	t.Run("submitted on retry", func(t *testing.T) {
+		np, metrics, release := newProcessor(t, 10, 1)
+
+		np.handleUpdateState(updatePeerEvent(t, netmap.NodeStateOffline, key))
+		close(release)
+
+		require.Eventually(t, func() bool {
+			return metrics.count(metrics.handled, updatePeerStateNotification) == 1
+		}, time.Second, time.Millisecond)
+		require.Zero(t, metrics.count(metrics.rejected, updatePeerStateNotification))
+		require.Empty(t, np.retryQueue)
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		np, metrics, release := newProcessor(t, 2, 1)
+		defer close(release)
+
+		np.handleUpdateState(updatePeerEvent(t, netmap.NodeStateOffline, key))
+
+		// listener is not blocked by the retries
+		require.Zero(t, metrics.count(metrics.rejected, updatePeerStateNotification))
+
+		require.Eventually(t, func() bool {
+			return metrics.count(metrics.rejected, updatePeerStateNotification) == 1
+		}, time.Second, time.Millisecond)
+		require.Zero(t, metrics.count(metrics.handled, updatePeerStateNotification))
+		require.Empty(t, np.retryQueue)
+	})
+
+	t.Run("queue is full", func(t *testing.T) {
+		np, metrics, release := newProcessor(t, 10, 1)
+		defer close(release)
+
+		np.handleUpdateState(updatePeerEvent(t, netmap.NodeStateOffline, key))
+		np.handleUpdateState(updatePeerEvent(t, netmap.NodeStateOffline, key))
+
+		require.Equal(t, 1, metrics.count(metrics.rejected, updatePeerStateNotification))
+	})
+}
@@ -0,0 +1,20 @@
+package netmap
+
+// SimulateNewEpoch processes new epoch notification with the specified epoch
+// number synchronously: updates the epoch state, resets the epoch timer,
+// updates the local view of the network map, votes to remove the absent
+// nodes and triggers the dependent routines the same way as the NewEpoch
+// notification of the network map contract does.
+//
+// SimulateNewEpoch is intended for the integration tests of the components
+// relying on the Processor, so they can trigger epoch handling without the
+// event listener. It MUST NOT be used in production code.
+func (np *Processor) SimulateNewEpoch(epoch uint64) error {
+	np.checkEpochDuration(epoch)
+
+	return np.newEpoch(epoch, func(epoch uint64) {
+		if np.netmapSnapshot.enabled {
+			np.processNetmapCleanupTick(epoch)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package netmap
+
+import (
+	"encoding/hex"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/morph/event"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/panjf2000/ants/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type testEstimationStarter struct {
+	epochs []uint64
+}
+
+func (s *testEstimationStarter) StartEstimation(epoch uint64) error {
+	s.epochs = append(s.epochs, epoch)
+	return nil
+}
+
+// lockedNetmapClient is a testNetmapClient which votes
+// can be read concurrently with the processing.
+type lockedNetmapClient struct {
+	testNetmapClient
+
+	mtx sync.Mutex
+}
+
+func (c *lockedNetmapClient) UpdatePeerState(key []byte, st netmap.NodeState) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.testNetmapClient.UpdatePeerState(key, st)
+}
+
+func (c *lockedNetmapClient) votes() [][]byte {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return append([][]byte(nil), c.updated...)
+}
+
+// epochState groups the state of the Processor
+// changed by the new epoch processing.
+type epochState struct {
+	epoch      uint64
+	resets     int32
+	estimation []uint64
+	votes      [][]byte
+	events     int
+}
+
+func TestProcessor_SimulateNewEpoch(t *testing.T) {
+	const newEpoch = 5
+
+	var (
+		present = newNodeInfo(genKey(t).PublicKey())
+		absent  = newNodeInfo(genKey(t).PublicKey())
+	)
+
+	nm, err := netmap.NewNetmap(netmap.NodesFromInfo([]netmap.NodeInfo{present}))
+	require.NoError(t, err)
+
+	// newProcessor returns processor which knows both nodes since epoch 1,
+	// and function that returns processor state after the expected number
+	// of votes is sent
+	newProcessor := func(t *testing.T) (*Processor, func(votes int) epochState) {
+		var (
+			epoch  = testEpochState(1)
+			timer  = new(testEpochTimer)
+			cli    = &lockedNetmapClient{testNetmapClient: testNetmapClient{snapshot: nm}}
+			est    = new(testEstimationStarter)
+			events = make(chan struct{}, 3)
+		)
+
+		// new epoch triggers audit, settlements and alphabet sync
+		handler := func(event.Event) { events <- struct{}{} }
+
+		// one worker for the epoch, one for the cleanup
+		pool, err := ants.NewPool(2, ants.WithNonblocking(true))
+		require.NoError(t, err)
+
+		np := &Processor{
+			log:                    test.NewLogger(false),
+			pool:                   pool,
+			epochTimer:             timer,
+			epochState:             &epoch,
+			alphabetState:          testAlphabetState(true),
+			netmapClient:           cli,
+			containerWrp:           est,
+			netmapSnapshot:         newCleanupTable(true, 1),
+			handleNewAudit:         handler,
+			handleAuditSettlements: handler,
+			handleAlphabetSync:     handler,
+			metrics:                noopMetrics{},
+			stateStore:             noopStateStore{},
+		}
+
+		np.netmapSnapshot.touch(hex.EncodeToString(present.PublicKey()), 1)
+		np.netmapSnapshot.touch(hex.EncodeToString(absent.PublicKey()), 1)
+
+		return np, func(votes int) epochState {
+			for i := 0; i < cap(events); i++ {
+				<-events
+			}
+
+			require.Eventually(t, func() bool {
+				return len(cli.votes()) == votes
+			}, time.Second, 10*time.Millisecond)
+
+			return epochState{
+				epoch:      epoch.EpochCounter(),
+				resets:     timer.resetCount(),
+				estimation: est.epochs,
+				votes:      cli.votes(),
+				events:     cap(events),
+			}
+		}
+	}
+
+	np, wait := newProcessor(t)
+	np.handleNewEpoch(newEpochEvent(t, newEpoch))
+
+	expected := wait(1)
+
+	np, wait = newProcessor(t)
+	require.NoError(t, np.SimulateNewEpoch(newEpoch))
+
+	actual := wait(1)
+
+	require.Equal(t, expected, actual)
+
+	require.Equal(t, epochState{
+		epoch:      newEpoch,
+		resets:     1,
+		estimation: []uint64{newEpoch - 1},
+		votes:      [][]byte{absent.PublicKey()},
+		events:     3,
+	}, actual)
+
+	t.Run("failure", func(t *testing.T) {
+		np, _ := newProcessor(t)
+
+		errSnapshot := errors.New("snapshot error")
+		np.netmapClient.(*lockedNetmapClient).err = errSnapshot
+
+		require.True(t, errors.Is(np.SimulateNewEpoch(newEpoch), errSnapshot))
+		require.EqualValues(t, newEpoch, np.epochState.EpochCounter())
+	})
+}
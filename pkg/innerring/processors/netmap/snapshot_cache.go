@@ -0,0 +1,76 @@
+package netmap
+
+import (
+	"bytes"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"go.uber.org/zap"
+)
+
+func (np *Processor) cacheSnapshot(nm *netmap.Netmap, epoch uint64) {
+	np.snapshotCacheMtx.Lock()
+	defer np.snapshotCacheMtx.Unlock()
+
+	np.snapshotCache = nm
+	np.snapshotCacheEpoch = epoch
+}
+
+// snapshot returns the network map of the current epoch. Network map is
+// fetched from the contract once per epoch.
+func (np *Processor) snapshot() (*netmap.Netmap, error) {
+	epoch := np.epochState.EpochCounter()
+
+	np.snapshotCacheMtx.Lock()
+	defer np.snapshotCacheMtx.Unlock()
+
+	if np.snapshotCache != nil && np.snapshotCacheEpoch == epoch {
+		return np.snapshotCache, nil
+	}
+
+	nm, err := np.netmapClient.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	np.snapshotCache = nm
+	np.snapshotCacheEpoch = epoch
+
+	return nm, nil
+}
+
+// compareWithNetmap checks if the node is presented in the network map
+// of the current epoch and if the presented information is byte-identical
+// to the specified one.
+func (np *Processor) compareWithNetmap(nodeInfo *netmap.NodeInfo) (present, identical bool) {
+	nm, err := np.snapshot()
+	if err != nil {
+		np.log.Debug("can't get netmap snapshot to compare network map candidate",
+			zap.String("error", err.Error()))
+
+		return false, false
+	}
+
+	if nm == nil {
+		return false, false
+	}
+
+	for i := range nm.Nodes {
+		if !bytes.Equal(nm.Nodes[i].PublicKey(), nodeInfo.PublicKey()) {
+			continue
+		}
+
+		actual, err := nm.Nodes[i].NodeInfo.Marshal()
+		if err != nil {
+			return true, false
+		}
+
+		candidate, err := nodeInfo.Marshal()
+		if err != nil {
+			return true, false
+		}
+
+		return true, bytes.Equal(actual, candidate)
+	}
+
+	return false, false
+}
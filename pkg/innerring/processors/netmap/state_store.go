@@ -0,0 +1,112 @@
+package netmap
+
+import (
+	"sort"
+
+	"go.uber.org/zap"
+)
+
+// StateStore is an interface of the durable storage of the Processor's
+// state which should survive the restarts (e.g. file or key-value
+// database).
+//
+// State is a set of the epochs of the last activity of the network map
+// nodes by hex-encoded public keys of the nodes.
+type StateStore interface {
+	// Load returns the last stored state. Must return empty state
+	// without an error if nothing has stored yet.
+	Load() (map[string]uint64, error)
+	// Store replaces the stored state with the provided one.
+	Store(map[string]uint64) error
+}
+
+type noopStateStore struct{}
+
+func (noopStateStore) Load() (map[string]uint64, error) { return nil, nil }
+
+func (noopStateStore) Store(map[string]uint64) error { return nil }
+
+// loadState restores the local view of the network map from the state
+// store. Processor starts with the empty view if state can not be loaded.
+func (np *Processor) loadState() {
+	state, err := np.stateStore.Load()
+	if err != nil {
+		np.log.Warn("can't load netmap processor state",
+			zap.String("error", err.Error()))
+
+		return
+	}
+
+	np.netmapSnapshot.restore(state)
+
+	np.log.Debug("netmap processor state loaded",
+		zap.Int("nodes", len(state)))
+}
+
+// storeState flushes the local view of the network map to the state store.
+func (np *Processor) storeState() {
+	// state is taken under the lock, so the concurrent calls
+	// can not overwrite the stored state with the outdated one
+	np.stateStoreMtx.Lock()
+	defer np.stateStoreMtx.Unlock()
+
+	if err := np.stateStore.Store(np.netmapSnapshot.state()); err != nil {
+		np.log.Warn("can't store netmap processor state",
+			zap.String("error", err.Error()))
+	}
+}
+
+// Return the epochs of the last activity of the tracked nodes.
+func (c *cleanupTable) state() map[string]uint64 {
+	c.RLock()
+	defer c.RUnlock()
+
+	state := make(map[string]uint64, len(c.lastAccess))
+
+	for keyString, access := range c.lastAccess {
+		state[keyString] = access.epoch
+	}
+
+	return state
+}
+
+// Restore the epochs of the last activity of the nodes. Nodes already
+// tracked keep the latest epoch. Restored nodes are considered absent in
+// the network map until the next update, so if the number of the tracked
+// nodes is limited, the most recently active nodes are kept. Restored
+// nodes are not considered approved until they are accessed, since they
+// may have left the network map while the Processor was down.
+func (c *cleanupTable) restore(state map[string]uint64) {
+	c.Lock()
+	defer c.Unlock()
+
+	keys := make([]string, 0, len(state))
+	for keyString := range state {
+		keys = append(keys, keyString)
+	}
+
+	// the most recently active nodes are accessed last
+	sort.Slice(keys, func(i, j int) bool {
+		ei, ej := state[keys[i]], state[keys[j]]
+		if ei != ej {
+			return ei < ej
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	for _, keyString := range keys {
+		access, ok := c.lastAccess[keyString]
+		if !ok {
+			access.restored = true
+		}
+
+		if state[keyString] > access.epoch {
+			access.epoch = state[keyString]
+		}
+
+		c.lastAccess[keyString] = access
+
+		c.accessed(keyString)
+	}
+}
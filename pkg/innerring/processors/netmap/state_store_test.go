@@ -0,0 +1,168 @@
+package netmap
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStateStore struct {
+	state map[string]uint64
+
+	loadErr, storeErr error
+
+	stores int
+}
+
+func (s *memoryStateStore) Load() (map[string]uint64, error) {
+	return s.state, s.loadErr
+}
+
+func (s *memoryStateStore) Store(state map[string]uint64) error {
+	s.stores++
+
+	if s.storeErr != nil {
+		return s.storeErr
+	}
+
+	s.state = state
+
+	return nil
+}
+
+func TestCleanupTable_Restore(t *testing.T) {
+	c := newCleanupTable(true, 1)
+
+	c.touch("a", 5)
+	c.touch("b", 7)
+
+	c.restore(map[string]uint64{
+		"a": 3, // older than tracked
+		"b": 9,
+		"c": 4,
+	})
+
+	require.Equal(t, map[string]uint64{"a": 5, "b": 9, "c": 4}, c.state())
+
+	// only the nodes unknown before are marked as restored
+	require.True(t, c.approved("a"))
+	require.False(t, c.approved("c"))
+	require.False(t, c.touch("c", 10))
+	require.True(t, c.approved("c"))
+
+	t.Run("limited", func(t *testing.T) {
+		c := newCleanupTable(true, 1)
+		c.setMaxEntries(2, nil)
+
+		c.restore(map[string]uint64{"a": 3, "b": 1, "c": 2})
+
+		// least recently active node is evicted
+		require.Equal(t, map[string]uint64{"a": 3, "c": 2}, c.state())
+	})
+}
+
+func TestProcessor_StateStore(t *testing.T) {
+	infos := []netmap.NodeInfo{
+		newNodeInfo(genKey(t).PublicKey()),
+		newNodeInfo(genKey(t).PublicKey()),
+	}
+
+	keys := make([]string, len(infos))
+	for i := range infos {
+		keys[i] = hex.EncodeToString(infos[i].PublicKey())
+	}
+
+	store := new(memoryStateStore)
+
+	newProcessor := func() *Processor {
+		np := &Processor{
+			log:            test.NewLogger(false),
+			netmapSnapshot: newCleanupTable(true, 1),
+			stateStore:     store,
+		}
+
+		np.loadState()
+
+		return np
+	}
+
+	np := newProcessor()
+
+	np.netmapSnapshot.touch(keys[0], 3)
+	np.netmapSnapshot.touch(keys[1], 10)
+	np.storeState()
+
+	require.Equal(t, map[string]uint64{keys[0]: 3, keys[1]: 10}, store.state)
+
+	t.Run("restart", func(t *testing.T) {
+		np := newProcessor()
+
+		require.True(t, np.netmapSnapshot.contains(keys[0]))
+		require.True(t, np.netmapSnapshot.contains(keys[1]))
+
+		// offline detection continues from the stored epochs
+		nm, err := netmap.NewNetmap(netmap.NodesFromInfo(infos))
+		require.NoError(t, err)
+
+		np.netmapSnapshot.update(nm, 11)
+
+		require.Equal(t, []string{keys[0]}, np.netmapSnapshot.removeCandidates(11))
+	})
+
+	t.Run("re-registration after restart", func(t *testing.T) {
+		epoch := testEpochState(11)
+
+		// the first node has left the network map while the Processor was down
+		nm, err := netmap.NewNetmap(netmap.NodesFromInfo(infos[1:]))
+		require.NoError(t, err)
+
+		cli := &testNetmapClient{snapshot: nm}
+
+		np := newProcessor()
+		np.epochState = &epoch
+		np.alphabetState = testAlphabetState(true)
+		np.netmapClient = cli
+		np.nodeValidator = nodeValidatorFunc(func(*netmap.NodeInfo) error { return nil })
+		np.rejectionSink = noopRejectionSink{}
+		np.auditLog = noopAuditLog{}
+		np.now = time.Now
+
+		require.False(t, np.netmapSnapshot.approved(keys[0]))
+
+		data, err := infos[0].Marshal()
+		require.NoError(t, err)
+
+		require.NoError(t, np.processAddPeer(data))
+
+		require.Len(t, cli.added, 1)
+		require.Equal(t, infos[0].PublicKey(), cli.added[0].PublicKey())
+		require.True(t, np.netmapSnapshot.approved(keys[0]))
+	})
+
+	t.Run("load failure", func(t *testing.T) {
+		store.loadErr = errors.New("test error")
+		defer func() { store.loadErr = nil }()
+
+		np := newProcessor()
+
+		require.False(t, np.netmapSnapshot.contains(keys[0]))
+	})
+
+	t.Run("store failure", func(t *testing.T) {
+		store.storeErr = errors.New("test error")
+		defer func() { store.storeErr = nil }()
+
+		stores := store.stores
+
+		np.netmapSnapshot.touch(keys[0], 12)
+		np.storeState()
+
+		require.Equal(t, stores+1, store.stores)
+		require.EqualValues(t, 3, store.state[keys[0]])
+	})
+}
@@ -0,0 +1,28 @@
+package netmap
+
+import (
+	"go.uber.org/zap"
+)
+
+// throttle slows down notification handling while the node is significantly
+// behind the chain, so the contract is not flooded by the reaction to the
+// backlogged events. Handling speed returns to normal once the node catches up.
+//
+// Must be called from the worker pool task, so the listener goroutine shared
+// by all the handlers is not blocked by the delay.
+func (np *Processor) throttle() {
+	if np.chainHeightLag == nil {
+		return
+	}
+
+	lag := np.chainHeightLag()
+	if lag <= np.throttleThreshold {
+		return
+	}
+
+	np.log.Debug("node is behind the chain, throttle event handling",
+		zap.Int("lag", lag),
+		zap.Duration("delay", np.throttleDelay))
+
+	np.sleep(np.throttleDelay)
+}
@@ -3,6 +3,7 @@ package netmap
 import (
 	"errors"
 
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
 	"github.com/nspcc-dev/neofs-node/pkg/morph/client"
 )
@@ -112,6 +113,11 @@ func New(c *client.StaticClient, opts ...Option) (*Client, error) {
 	return res, nil
 }
 
+// ContractAddress returns the address of the associated contract.
+func (c *Client) ContractAddress() util.Uint160 {
+	return c.client.ContractAddress()
+}
+
 // WithAddPeerMethod returns a client constructor option that
 // specifies the method name of adding peer operation.
 //
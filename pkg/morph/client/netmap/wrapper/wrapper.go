@@ -36,3 +36,8 @@ func NewFromMorph(cli *client.Client, contract util.Uint160, fee fixedn.Fixed8,
 
 	return &Wrapper{client: enhancedNetmapClient}, nil
 }
+
+// ContractHash returns the script hash of the netmap contract.
+func (w *Wrapper) ContractHash() util.Uint160 {
+	return w.client.ContractAddress()
+}
@@ -71,6 +71,11 @@ func NewStatic(client *Client, scriptHash util.Uint160, fee fixedn.Fixed8, opts
 	}, nil
 }
 
+// ContractAddress returns the address of the associated contract.
+func (s StaticClient) ContractAddress() util.Uint160 {
+	return s.scScriptHash
+}
+
 // Invoke calls Invoke method of Client with static internal script hash and fee.
 // Supported args types are the same as in Client.
 //
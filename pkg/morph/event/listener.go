@@ -295,7 +295,7 @@ func (s listener) SetParser(p ParserInfo) {
 		zap.Stringer("event type", p.getType()),
 	)
 
-	parser := p.parser()
+	parser := p.Parser()
 	if parser == nil {
 		log.Info("ignore nil event parser")
 		return
@@ -312,7 +312,7 @@ func (s listener) SetParser(p ParserInfo) {
 
 	// add event parser
 	if _, ok := s.parsers[p.scriptHashWithType]; !ok {
-		s.parsers[p.scriptHashWithType] = p.parser()
+		s.parsers[p.scriptHashWithType] = p.Parser()
 	}
 
 	log.Info("registered new event parser")
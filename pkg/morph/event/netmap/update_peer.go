@@ -15,8 +15,16 @@ import (
 type UpdatePeer struct {
 	publicKey *keys.PublicKey
 	status    netmap.NodeState
+
+	maintenance bool
 }
 
+// NodeStateMaintenance is a value of the node state in UpdateState
+// notification proposed by the node which is temporarily unavailable
+// due to the maintenance. It is not supported by netmap.NodeState,
+// so such notification has zero Status (see Maintenance).
+const NodeStateMaintenance = 3
+
 // MorphEvent implements Neo:Morph Event interface.
 func (UpdatePeer) MorphEvent() {}
 
@@ -28,6 +36,11 @@ func (s UpdatePeer) PublicKey() *keys.PublicKey {
 	return s.publicKey
 }
 
+// Maintenance returns true if the node proposes NodeStateMaintenance.
+func (s UpdatePeer) Maintenance() bool {
+	return s.maintenance
+}
+
 func ParseUpdatePeer(prms []stackitem.Item) (event.Event, error) {
 	var (
 		ev  UpdatePeer
@@ -56,6 +69,7 @@ func ParseUpdatePeer(prms []stackitem.Item) (event.Event, error) {
 	}
 
 	ev.status = netmap.NodeStateFromV2(v2netmap.NodeState(st))
+	ev.maintenance = st == NodeStateMaintenance
 
 	return ev, nil
 }
@@ -57,5 +57,16 @@ func TestParseUpdatePeer(t *testing.T) {
 			publicKey: publicKey,
 			status:    state,
 		}, ev)
+		require.False(t, ev.(UpdatePeer).Maintenance())
+	})
+
+	t.Run("maintenance", func(t *testing.T) {
+		ev, err := ParseUpdatePeer([]stackitem.Item{
+			stackitem.NewBigInteger(big.NewInt(NodeStateMaintenance)),
+			stackitem.NewByteArray(publicKey.Bytes()),
+		})
+		require.NoError(t, err)
+
+		require.True(t, ev.(UpdatePeer).Maintenance())
 	})
 }
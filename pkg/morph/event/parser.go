@@ -40,7 +40,8 @@ func (s *ParserInfo) SetParser(v Parser) {
 	s.p = v
 }
 
-func (s ParserInfo) parser() Parser {
+// Parser returns an event parser.
+func (s ParserInfo) Parser() Parser {
 	return s.p
 }
 
@@ -0,0 +1,79 @@
+package searchsvc
+
+import (
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"go.uber.org/zap"
+)
+
+// Aggregates groups statistics over the objects matched by the search.
+type Aggregates struct {
+	// Number of the matched objects.
+	Objects uint64
+
+	// Total payload size of the matched objects.
+	PayloadSize uint64
+
+	// Number of the matched objects of each type.
+	ByType map[objectSDK.Type]uint64
+
+	// Number of the matched objects of each owner.
+	// Keys are string representations of the owner IDs,
+	// empty key is used for objects without an owner.
+	ByOwner map[string]uint64
+}
+
+// AggregateWriter is an interface of target component
+// to write statistics over the matched objects.
+type AggregateWriter interface {
+	WriteAggregates(*Aggregates) error
+}
+
+// aggregate calculates statistics over the object headers.
+func aggregate(hdrs []*object.Object) *Aggregates {
+	res := &Aggregates{
+		ByType:  make(map[objectSDK.Type]uint64),
+		ByOwner: make(map[string]uint64),
+	}
+
+	for i := range hdrs {
+		res.Objects++
+		res.PayloadSize += hdrs[i].PayloadSize()
+		res.ByType[hdrs[i].Type()]++
+
+		var key string
+		if ownerID := hdrs[i].OwnerID(); ownerID != nil {
+			key = ownerID.String()
+		}
+
+		res.ByOwner[key]++
+	}
+
+	return res
+}
+
+// writeAggregates writes statistics over the selected objects
+// and returns true on success.
+func (exec *execCtx) writeAggregates(ids []*objectSDK.ID) bool {
+	hdrs := exec.localHeaders(ids)
+	if exec.interrupted() {
+		return false
+	}
+
+	err := exec.prm.aggregateWriter.WriteAggregates(aggregate(hdrs))
+	if err != nil {
+		exec.status = statusUndefined
+		exec.err = err
+
+		exec.log.Debug("could not write search aggregates",
+			zap.String("error", err.Error()),
+		)
+
+		return false
+	}
+
+	exec.status = statusOK
+	exec.err = nil
+
+	return true
+}
@@ -0,0 +1,118 @@
+package searchsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cidtest "github.com/nspcc-dev/neofs-api-go/pkg/container/id/test"
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-api-go/pkg/owner"
+	ownertest "github.com/nspcc-dev/neofs-api-go/pkg/owner/test"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/util"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+type aggregateWriter struct {
+	res []*Aggregates
+
+	err error
+}
+
+func (w *aggregateWriter) WriteAggregates(a *Aggregates) error {
+	w.res = append(w.res, a)
+	return w.err
+}
+
+func TestGetLocalAggregates(t *testing.T) {
+	ctx := context.Background()
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	owners := []*owner.ID{ownertest.Generate(), ownertest.Generate()}
+	types := []objectSDK.Type{objectSDK.TypeRegular, objectSDK.TypeTombstone, objectSDK.TypeStorageGroup}
+
+	var (
+		hdrs     []*object.RawObject
+		expected = &Aggregates{
+			ByType:  make(map[objectSDK.Type]uint64),
+			ByOwner: make(map[string]uint64),
+		}
+	)
+
+	for i := 0; i < 10; i++ {
+		hdr := generateHeader(owners[i%len(owners)])
+		hdr.SetType(types[i%len(types)])
+		hdr.SetPayloadSize(uint64(i * 100))
+
+		hdrs = append(hdrs, hdr)
+	}
+
+	// manual computation
+	for i := range hdrs {
+		expected.Objects++
+		expected.PayloadSize += hdrs[i].PayloadSize()
+		expected.ByType[hdrs[i].Type()]++
+		expected.ByOwner[hdrs[i].OwnerID().String()]++
+	}
+
+	ids := storage.addHeaders(hdrs...)
+
+	cid := cidtest.Generate()
+	storage.addResult(cid, ids, nil)
+
+	newPrm := func(localOnly, withIDs bool) (Prm, *aggregateWriter, *simpleIDWriter) {
+		aw := new(aggregateWriter)
+		w := new(simpleIDWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.SetAggregateWriter(aw, withIDs)
+		p.common = new(util.CommonPrm).WithLocalOnly(localOnly)
+
+		return p, aw, w
+	}
+
+	t.Run("instead of IDs", func(t *testing.T) {
+		p, aw, w := newPrm(true, false)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, []*Aggregates{expected}, aw.res)
+		require.Empty(t, w.ids)
+
+		require.EqualValues(t, 4500, aw.res[0].PayloadSize)
+		require.EqualValues(t, 4, aw.res[0].ByType[objectSDK.TypeRegular])
+		require.EqualValues(t, 5, aw.res[0].ByOwner[owners[0].String()])
+	})
+
+	t.Run("alongside IDs", func(t *testing.T) {
+		p, aw, w := newPrm(true, true)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, []*Aggregates{expected}, aw.res)
+		require.Equal(t, ids, w.ids)
+	})
+
+	t.Run("writer failure", func(t *testing.T) {
+		p, aw, w := newPrm(true, true)
+		aw.err = errors.New("test error")
+
+		err := svc.Search(ctx, p)
+		require.True(t, errors.Is(err, aw.err))
+		require.Empty(t, w.ids)
+	})
+
+	t.Run("non-local", func(t *testing.T) {
+		p, _, _ := newPrm(false, false)
+
+		err := svc.Search(ctx, p)
+		require.True(t, errors.Is(err, errHeaderModeNotLocal))
+	})
+}
@@ -0,0 +1,66 @@
+package searchsvc
+
+import (
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// collapseToParents replaces identifiers of the selected split-chain
+// children with the identifiers of their parents. Each parent is
+// written once at the position of its first matched child.
+//
+// Parent of the child is taken from its header. Since only some children
+// of the chain (e.g. the last one and the linking object) carry the parent
+// header, parent of the other children is resolved by the split ID from the
+// selected children of the same chain. Children which parent can not be
+// resolved are kept as is.
+//
+// Headers of the collapsed children are returned grouped by the string
+// form of the parent identifier in the order of selection.
+func (exec *execCtx) collapseToParents(ids []*objectSDK.ID) ([]*objectSDK.ID, map[string][]*object.Object) {
+	var (
+		hdrs  = exec.localHeaders(ids)
+		res   = make([]*objectSDK.ID, 0, len(hdrs))
+		parts = make(map[string][]*object.Object)
+
+		// split ID -> parent ID
+		splitParents = make(map[string]*objectSDK.ID)
+		written      = make(map[string]struct{}, len(hdrs))
+	)
+
+	for i := range hdrs {
+		if par := hdrs[i].Parent(); par != nil && par.ID() != nil {
+			if splitID := hdrs[i].SplitID(); splitID != nil {
+				splitParents[splitID.String()] = par.ID()
+			}
+		}
+	}
+
+	for i := range hdrs {
+		var (
+			id        = hdrs[i].ID()
+			collapsed bool
+		)
+
+		if par := hdrs[i].Parent(); par != nil && par.ID() != nil {
+			id, collapsed = par.ID(), true
+		} else if splitID := hdrs[i].SplitID(); splitID != nil {
+			if parID, ok := splitParents[splitID.String()]; ok {
+				id, collapsed = parID, true
+			}
+		}
+
+		key := id.String()
+
+		if collapsed {
+			parts[key] = append(parts[key], hdrs[i])
+		}
+
+		if _, ok := written[key]; !ok {
+			written[key] = struct{}{}
+			res = append(res, id)
+		}
+	}
+
+	return res, parts
+}
@@ -0,0 +1,211 @@
+package searchsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cidtest "github.com/nspcc-dev/neofs-api-go/pkg/container/id/test"
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/util"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLocalCollapseToParent(t *testing.T) {
+	ctx := context.Background()
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	par := generateHeader(nil)
+	splitID := objectSDK.NewSplitID()
+
+	// first and middle children do not carry the parent header
+	var children []*object.RawObject
+
+	for i := 0; i < 3; i++ {
+		child := generateHeader(nil)
+		child.SetSplitID(splitID)
+
+		children = append(children, child)
+	}
+
+	children[2].SetParent(par.SDK().Object())
+
+	link := generateHeader(nil)
+	link.SetSplitID(splitID)
+	link.SetParent(par.SDK().Object())
+
+	// other chain which children carry no parent
+	orphan := generateHeader(nil)
+	orphan.SetSplitID(objectSDK.NewSplitID())
+
+	regular := generateHeader(nil)
+
+	ids := storage.addHeaders(append(children, regular, link, orphan)...)
+
+	cid := cidtest.Generate()
+	storage.addResult(cid, ids, nil)
+
+	newPrm := func(collapse bool) (Prm, *simpleIDWriter) {
+		w := new(simpleIDWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.SetCollapseToParent(collapse)
+		p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+		return p, w
+	}
+
+	t.Run("collapse", func(t *testing.T) {
+		p, w := newPrm(true)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, []*objectSDK.ID{par.ID(), regular.ID(), orphan.ID()}, w.ids)
+	})
+
+	t.Run("children", func(t *testing.T) {
+		p, w := newPrm(false)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, ids, w.ids)
+	})
+}
+
+type matchedParts struct {
+	parent *objectSDK.ID
+	parts  []MatchedPart
+}
+
+type matchedPartsWriter struct {
+	written []matchedParts
+}
+
+func (w *matchedPartsWriter) WriteMatchedParts(parent *objectSDK.ID, parts []MatchedPart) error {
+	w.written = append(w.written, matchedParts{parent: parent, parts: parts})
+	return nil
+}
+
+func TestGetLocalMatchedParts(t *testing.T) {
+	ctx := context.Background()
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	const tagKey, tagValue = "Tag", "hot"
+
+	tag := objectSDK.NewAttribute()
+	tag.SetKey(tagKey)
+	tag.SetValue(tagValue)
+
+	// chain of 4 children, the 2nd and the last ones carry the tag
+	par := generateHeader(nil)
+	splitID := objectSDK.NewSplitID()
+
+	var children []*object.RawObject
+
+	for i := 0; i < 4; i++ {
+		child := generateHeader(nil)
+		child.SetSplitID(splitID)
+
+		if i > 0 {
+			child.SetPreviousID(children[i-1].ID())
+		}
+
+		children = append(children, child)
+	}
+
+	children[1].SetAttributes(tag)
+	children[3].SetAttributes(tag)
+	children[3].SetParent(par.SDK().Object())
+
+	link := generateHeader(nil)
+	link.SetSplitID(splitID)
+	link.SetParent(par.SDK().Object())
+	link.SetChildren(children[0].ID(), children[1].ID(), children[2].ID(), children[3].ID())
+	link.SetAttributes(tag)
+
+	// chain which first child is missing locally
+	otherPar := generateHeader(nil)
+	missing := generateHeader(nil)
+
+	last := generateHeader(nil)
+	last.SetSplitID(objectSDK.NewSplitID())
+	last.SetPreviousID(missing.ID())
+	last.SetParent(otherPar.SDK().Object())
+	last.SetAttributes(tag)
+
+	regular := generateHeader(nil)
+	regular.SetAttributes(tag)
+
+	ids := storage.addHeaders(append(children, link, regular, last)...)
+
+	cid := cidtest.Generate()
+	storage.addResult(cid, append(ids, missing.ID()), nil)
+
+	newPrm := func(collapse bool) (Prm, *simpleIDWriter, *matchedPartsWriter) {
+		w := new(simpleIDWriter)
+		pw := new(matchedPartsWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.SetQuery(query.New(query.NewAttributeMatcher(tagKey, tagValue)))
+		p.SetCollapseToParent(collapse)
+		p.SetMatchedPartsWriter(pw)
+		p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+		return p, w, pw
+	}
+
+	t.Run("collapse", func(t *testing.T) {
+		p, w, pw := newPrm(true)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, []*objectSDK.ID{par.ID(), regular.ID(), otherPar.ID()}, w.ids)
+
+		require.Equal(t, []matchedParts{
+			{
+				parent: par.ID(),
+				parts: []MatchedPart{
+					{ID: children[1].ID(), Index: 1},
+					{ID: children[3].ID(), Index: 3},
+					{ID: link.ID(), Index: -1},
+				},
+			},
+			{
+				parent: otherPar.ID(),
+				parts: []MatchedPart{
+					{ID: last.ID(), Index: -1},
+				},
+			},
+		}, pw.written)
+	})
+
+	t.Run("without collapse", func(t *testing.T) {
+		p, w, pw := newPrm(false)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, []*objectSDK.ID{children[1].ID(), children[3].ID(), link.ID(), regular.ID(), last.ID()}, w.ids)
+		require.Empty(t, pw.written)
+	})
+
+	t.Run("non-local", func(t *testing.T) {
+		p, _, _ := newPrm(false)
+		p.SetQuery(nil)
+		p.common = new(util.CommonPrm).WithLocalOnly(false)
+
+		require.True(t, errors.Is(svc.Search(ctx, p), errHeaderModeNotLocal))
+	})
+}
@@ -0,0 +1,136 @@
+package searchsvc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"sort"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"go.uber.org/zap"
+)
+
+var errInvalidCursor = errors.New("invalid search cursor")
+
+func idBytes(id *objectSDK.ID) []byte {
+	return id.ToV2().GetValue()
+}
+
+// sortIDs sorts identifiers in ascending order of their binary form
+// to make the order of the results stable.
+func sortIDs(ids []*objectSDK.ID) {
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(idBytes(ids[i]), idBytes(ids[j])) < 0
+	})
+}
+
+// idsAfterCursor returns sorted identifiers that follow the cursor.
+//
+// Cursor is a binary form of the last written object identifier, it is
+// not a position in the storage, so ids must contain all the matched
+// objects (see Prm.SetCursor).
+func idsAfterCursor(ids []*objectSDK.ID, cursor []byte) ([]*objectSDK.ID, error) {
+	if cursor != nil && len(cursor) != sha256.Size {
+		return nil, errInvalidCursor
+	}
+
+	sortIDs(ids)
+
+	if cursor == nil {
+		return ids, nil
+	}
+
+	start := sort.Search(len(ids), func(i int) bool {
+		return bytes.Compare(idBytes(ids[i]), cursor) > 0
+	})
+
+	return ids[start:], nil
+}
+
+func (exec *execCtx) writeCursorBatches(ids []*objectSDK.ID) {
+	ids, err := idsAfterCursor(ids, exec.prm.cursor)
+	if err != nil {
+		exec.status = statusUndefined
+		exec.err = err
+
+		return
+	}
+
+	batchSize := exec.prm.batchSize
+	if batchSize <= 0 {
+		batchSize = len(ids)
+	}
+
+	for len(ids) > 0 {
+		if exec.interrupted() {
+			return
+		}
+
+		ln := batchSize
+		if ln > len(ids) {
+			ln = len(ids)
+		}
+
+		batch := ids[:ln]
+		ids = ids[ln:]
+
+		err = exec.prm.cursorWriter.WriteIDsWithCursor(batch, idBytes(batch[ln-1]))
+		if err != nil {
+			exec.status = statusUndefined
+			exec.err = err
+
+			exec.log.Debug("could not write object identifiers",
+				zap.String("error", err.Error()),
+			)
+
+			return
+		}
+	}
+
+	exec.status = statusOK
+	exec.err = nil
+}
+
+// writeCursorPage writes the page of the identifiers that follow the cursor.
+// Identifiers are streamed up to the first one after the page, which is
+// only used to report that there are more identifiers.
+func (exec *execCtx) writeCursorPage(ids []*objectSDK.ID) {
+	ids, err := idsAfterCursor(ids, exec.prm.cursor)
+	if err != nil {
+		exec.status = statusUndefined
+		exec.err = err
+
+		return
+	}
+
+	size := exec.prm.pageSize
+	if size <= 0 {
+		size = len(ids)
+	}
+
+	page := limitIDs(ids, size+1)
+
+	more := len(page) > size
+	if more {
+		page = page[:size]
+	}
+
+	next := exec.prm.cursor
+	if len(page) > 0 {
+		next = idBytes(page[len(page)-1])
+	}
+
+	if err := exec.prm.pageWriter.WriteIDPage(page, next, more); err != nil {
+		exec.status = statusUndefined
+		exec.err = err
+
+		exec.log.Debug("could not write page of object identifiers",
+			zap.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	exec.status = statusOK
+	exec.err = nil
+}
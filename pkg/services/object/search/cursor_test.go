@@ -0,0 +1,206 @@
+package searchsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cidtest "github.com/nspcc-dev/neofs-api-go/pkg/container/id/test"
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/util"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+var errStopBatches = errors.New("stop")
+
+// batchWriter accepts limited number of batches like
+// a client which reads results in several calls.
+type batchWriter struct {
+	limit int
+
+	batches [][]*objectSDK.ID
+
+	cursor []byte
+}
+
+func (w *batchWriter) WriteIDsWithCursor(ids []*objectSDK.ID, cursor []byte) error {
+	if len(w.batches) == w.limit {
+		return errStopBatches
+	}
+
+	w.batches = append(w.batches, ids)
+	w.cursor = cursor
+
+	return nil
+}
+
+func TestGetLocalWithCursor(t *testing.T) {
+	const (
+		objNum    = 10
+		batchSize = 3
+	)
+
+	ctx := context.Background()
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	cid := cidtest.Generate()
+	ids := generateIDs(objNum)
+	storage.addResult(cid, ids, nil)
+
+	var (
+		cursor []byte
+		read   []*objectSDK.ID
+	)
+
+	// every call reads single batch
+	for i := 0; i < objNum; i++ {
+		w := &batchWriter{limit: 1}
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetCursorWriter(w, batchSize)
+		p.SetCursor(cursor)
+		p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+		err := svc.Search(ctx, p)
+		if len(w.batches) == 0 {
+			require.NoError(t, err)
+			break
+		}
+
+		require.Len(t, w.batches, 1)
+		require.LessOrEqual(t, len(w.batches[0]), batchSize)
+
+		read = append(read, w.batches[0]...)
+		cursor = w.cursor
+	}
+
+	// no gaps or duplicates
+	require.Len(t, read, objNum)
+
+	for _, id := range ids {
+		require.Contains(t, read, id)
+	}
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetCursorWriter(new(batchWriter), batchSize)
+		p.SetCursor([]byte{1, 2, 3})
+		p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+		require.True(t, errors.Is(svc.Search(ctx, p), errInvalidCursor))
+	})
+}
+
+// pageWriter records the written page.
+type pageWriter struct {
+	ids []*objectSDK.ID
+
+	next []byte
+
+	more bool
+}
+
+func (w *pageWriter) WriteIDPage(ids []*objectSDK.ID, next []byte, more bool) error {
+	w.ids, w.next, w.more = ids, next, more
+	return nil
+}
+
+func TestGetLocalPages(t *testing.T) {
+	const (
+		objNum   = 25
+		pageSize = 7
+	)
+
+	ctx := context.Background()
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	cid := cidtest.Generate()
+	ids := generateIDs(objNum)
+	storage.addResult(cid, ids, nil)
+
+	readPage := func(cursor []byte, size int) *pageWriter {
+		w := new(pageWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetPageWriter(w, size)
+		p.SetCursor(cursor)
+		p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+		require.NoError(t, svc.Search(ctx, p))
+
+		return w
+	}
+
+	var (
+		cursor []byte
+		read   []*objectSDK.ID
+		sizes  []int
+	)
+
+	for {
+		w := readPage(cursor, pageSize)
+
+		read = append(read, w.ids...)
+		sizes = append(sizes, len(w.ids))
+		cursor = w.next
+
+		if !w.more {
+			break
+		}
+
+		require.Len(t, w.ids, pageSize)
+	}
+
+	require.Equal(t, []int{7, 7, 7, 4}, sizes)
+
+	// pages follow the stable order without gaps or duplicates
+	expected := append([]*objectSDK.ID(nil), ids...)
+	sortIDs(expected)
+
+	require.Equal(t, expected, read)
+
+	t.Run("after the last page", func(t *testing.T) {
+		w := readPage(cursor, pageSize)
+
+		require.Empty(t, w.ids)
+		require.False(t, w.more)
+		require.Equal(t, cursor, w.next)
+	})
+
+	t.Run("exact page boundary", func(t *testing.T) {
+		w := readPage(nil, objNum)
+
+		require.Len(t, w.ids, objNum)
+		require.False(t, w.more)
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		w := readPage(nil, 0)
+
+		require.Equal(t, expected, w.ids)
+		require.False(t, w.more)
+	})
+
+	t.Run("non-local", func(t *testing.T) {
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetPageWriter(new(pageWriter), pageSize)
+		p.common = new(util.CommonPrm).WithLocalOnly(false)
+
+		require.True(t, errors.Is(svc.Search(ctx, p), errHeaderModeNotLocal))
+	})
+}
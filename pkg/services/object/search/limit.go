@@ -0,0 +1,55 @@
+package searchsvc
+
+import (
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"go.uber.org/zap"
+)
+
+// TotalCountWriter is an interface of target component to write
+// the number of all the matched objects when the result is limited.
+type TotalCountWriter interface {
+	WriteTotalCount(uint64) error
+}
+
+// limitIDs returns no more than limit first identifiers.
+func limitIDs(ids []*objectSDK.ID, limit int) []*objectSDK.ID {
+	if limit < len(ids) {
+		return ids[:limit]
+	}
+
+	return ids
+}
+
+func (exec *execCtx) writeLimitedIDList(ids []*objectSDK.ID) {
+	if exec.prm.totalWriter != nil {
+		if err := exec.prm.totalWriter.WriteTotalCount(uint64(len(ids))); err != nil {
+			exec.status = statusUndefined
+			exec.err = err
+
+			exec.log.Debug("could not write total count of the matched objects",
+				zap.String("error", err.Error()),
+			)
+
+			return
+		}
+	}
+
+	exec.writeLocalIDList(limitIDs(ids, exec.prm.limit))
+}
+
+// writeCount writes the number of the matched objects in count-only mode.
+func (exec *execCtx) writeCount(n uint64) {
+	if err := exec.prm.countWriter.WriteTotalCount(n); err != nil {
+		exec.status = statusUndefined
+		exec.err = err
+
+		exec.log.Debug("could not write count of the matched objects",
+			zap.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	exec.status = statusOK
+	exec.err = nil
+}
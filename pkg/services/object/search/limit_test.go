@@ -0,0 +1,174 @@
+package searchsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cidtest "github.com/nspcc-dev/neofs-api-go/pkg/container/id/test"
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/util"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+type totalCountWriter struct {
+	counts []uint64
+
+	err error
+}
+
+func (w *totalCountWriter) WriteTotalCount(n uint64) error {
+	w.counts = append(w.counts, n)
+	return w.err
+}
+
+func TestLimitIDs(t *testing.T) {
+	ids := generateIDs(10)
+
+	require.Equal(t, ids[:3], limitIDs(ids, 3))
+	require.Equal(t, ids, limitIDs(ids, 10))
+	require.Equal(t, ids, limitIDs(ids, 20))
+}
+
+func TestGetLocalWithLimit(t *testing.T) {
+	ctx := context.Background()
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	cid := cidtest.Generate()
+	ids := generateIDs(10)
+	storage.addResult(cid, ids, nil)
+
+	newPrm := func(localOnly bool, limit int, total TotalCountWriter) (Prm, *simpleIDWriter) {
+		w := new(simpleIDWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.SetLimit(limit, total)
+		p.common = new(util.CommonPrm).WithLocalOnly(localOnly)
+
+		return p, w
+	}
+
+	t.Run("with total count", func(t *testing.T) {
+		tw := new(totalCountWriter)
+		p, w := newPrm(true, 4, tw)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, ids[:4], w.ids)
+		require.Equal(t, []uint64{10}, tw.counts)
+	})
+
+	t.Run("without total count", func(t *testing.T) {
+		p, w := newPrm(true, 4, nil)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, ids[:4], w.ids)
+	})
+
+	t.Run("writer failure", func(t *testing.T) {
+		tw := &totalCountWriter{err: errors.New("test error")}
+		p, w := newPrm(true, 4, tw)
+
+		require.True(t, errors.Is(svc.Search(ctx, p), tw.err))
+		require.Empty(t, w.ids)
+	})
+
+	t.Run("non-local", func(t *testing.T) {
+		p, _ := newPrm(false, 4, nil)
+
+		require.True(t, errors.Is(svc.Search(ctx, p), errHeaderModeNotLocal))
+	})
+}
+
+func TestGetLocalCountOnly(t *testing.T) {
+	ctx := context.Background()
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	par := generateHeader(nil)
+	splitID := objectSDK.NewSplitID()
+
+	var hdrs []*object.RawObject
+
+	// 3 children and the linking object of the single logical object
+	for i := 0; i < 4; i++ {
+		child := generateHeader(nil)
+		child.SetSplitID(splitID)
+
+		hdrs = append(hdrs, child)
+	}
+
+	hdrs[2].SetParent(par.SDK().Object())
+	hdrs[3].SetParent(par.SDK().Object())
+
+	hdrs = append(hdrs, generateHeader(nil), generateHeader(nil))
+
+	cid := cidtest.Generate()
+	storage.addResult(cid, storage.addHeaders(hdrs...), nil)
+
+	newPrm := func(localOnly, collapse bool, cw TotalCountWriter) (Prm, *simpleIDWriter) {
+		w := new(simpleIDWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.SetCountOnly(cw)
+		p.SetCollapseToParent(collapse)
+		p.common = new(util.CommonPrm).WithLocalOnly(localOnly)
+
+		return p, w
+	}
+
+	t.Run("children", func(t *testing.T) {
+		cw := new(totalCountWriter)
+		p, w := newPrm(true, false, cw)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, []uint64{6}, cw.counts)
+		require.Empty(t, w.ids)
+	})
+
+	t.Run("logical objects", func(t *testing.T) {
+		cw := new(totalCountWriter)
+		p, w := newPrm(true, true, cw)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, []uint64{3}, cw.counts)
+		require.Empty(t, w.ids)
+	})
+
+	t.Run("with query", func(t *testing.T) {
+		cw := new(totalCountWriter)
+		p, _ := newPrm(true, true, cw)
+		p.SetQuery(query.New(query.NewSplitChildMatcher()))
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, []uint64{1}, cw.counts)
+	})
+
+	t.Run("writer failure", func(t *testing.T) {
+		cw := &totalCountWriter{err: errors.New("test error")}
+		p, _ := newPrm(true, false, cw)
+
+		require.True(t, errors.Is(svc.Search(ctx, p), cw.err))
+	})
+
+	t.Run("non-local", func(t *testing.T) {
+		p, _ := newPrm(false, false, new(totalCountWriter))
+
+		require.True(t, errors.Is(svc.Search(ctx, p), errHeaderModeNotLocal))
+	})
+}
@@ -1,11 +1,18 @@
 package searchsvc
 
 import (
+	"fmt"
+	"sort"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-api-go/pkg/owner"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
 	"go.uber.org/zap"
 )
 
 func (exec *execCtx) executeLocal() {
-	ids, err := exec.svc.localStorage.search(exec)
+	ids, err := exec.searchLocal()
 
 	if err != nil {
 		exec.status = statusUndefined
@@ -18,5 +25,294 @@ func (exec *execCtx) executeLocal() {
 		return
 	}
 
-	exec.writeIDList(ids)
+	if exec.prm.query != nil {
+		ids = exec.filterQuery(ids)
+
+		if exec.interrupted() {
+			return
+		}
+	}
+
+	if exec.prm.collapseToParent {
+		var parts map[string][]*object.Object
+
+		ids, parts = exec.collapseToParents(ids)
+
+		if exec.interrupted() {
+			return
+		}
+
+		if exec.prm.partsWriter != nil && !exec.writeMatchedParts(ids, parts) {
+			return
+		}
+	}
+
+	if exec.prm.countWriter != nil {
+		exec.writeCount(uint64(len(ids)))
+		return
+	}
+
+	if !exec.prm.sort.isDefault() {
+		ids = exec.sortResult(ids)
+
+		if exec.interrupted() {
+			return
+		}
+	}
+
+	if exec.prm.aggregateWriter != nil {
+		if !exec.writeAggregates(ids) || !exec.prm.aggregateWithIDs {
+			return
+		}
+	}
+
+	switch {
+	case exec.prm.ownerWriter != nil:
+		if hdrs := exec.localHeaders(ids); !exec.interrupted() {
+			exec.writeOwnerGroups(hdrs)
+		}
+
+		return
+	case exec.prm.cursorWriter != nil:
+		exec.writeCursorBatches(ids)
+		return
+	case exec.prm.pageWriter != nil:
+		exec.writeCursorPage(ids)
+		return
+	case exec.prm.ndjsonWriter != nil:
+		if hdrs := exec.localHeaders(ids); !exec.interrupted() {
+			exec.writeNDJSON(hdrs)
+		}
+
+		return
+	}
+
+	if exec.prm.limit > 0 {
+		exec.writeLimitedIDList(ids)
+		return
+	}
+
+	exec.writeLocalIDList(ids)
+}
+
+// writeLocalIDList writes identifiers of the selected objects in batches
+// of the configured size (see WithLocalBatchSize), so the results are
+// passed to the writer in parts. Last batch can be smaller.
+func (exec *execCtx) writeLocalIDList(ids []*objectSDK.ID) {
+	batchSize := exec.svc.localBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultLocalBatchSize
+	}
+
+	for {
+		if exec.interrupted() {
+			return
+		}
+
+		ln := batchSize
+		if ln > len(ids) {
+			ln = len(ids)
+		}
+
+		// empty result is written too
+		exec.writeIDList(ids[:ln])
+
+		ids = ids[ln:]
+
+		if exec.status != statusOK || len(ids) == 0 {
+			return
+		}
+	}
+}
+
+// interrupted returns true if the context of the local operation is done.
+// In this case execution is failed with the error wrapping the context
+// error (context.DeadlineExceeded or context.Canceled).
+func (exec *execCtx) interrupted() bool {
+	err := exec.context().Err()
+	if err == nil {
+		return false
+	}
+
+	exec.status = statusUndefined
+	exec.err = fmt.Errorf("local search interrupted: %w", err)
+
+	exec.log.Debug("local operation interrupted",
+		zap.String("error", err.Error()),
+	)
+
+	return true
+}
+
+// localHeaders reads headers of the selected objects from the local storage.
+// Objects that could not be read (e.g. removed after selection) are skipped.
+//
+// Reading is stopped if the context of the operation is done, so the
+// callers must check it (see interrupted) before using the result.
+func (exec *execCtx) localHeaders(ids []*objectSDK.ID) []*object.Object {
+	hdrs := make([]*object.Object, 0, len(ids))
+
+	for i := range ids {
+		if exec.context().Err() != nil {
+			break
+		}
+		addr := objectSDK.NewAddress()
+		addr.SetContainerID(exec.containerID())
+		addr.SetObjectID(ids[i])
+
+		hdr, err := exec.svc.localStorage.head(addr)
+		if err != nil {
+			exec.log.Debug("could not read header of the selected object",
+				zap.Stringer("id", ids[i]),
+				zap.String("error", err.Error()),
+			)
+
+			continue
+		}
+
+		hdrs = append(hdrs, hdr)
+	}
+
+	return hdrs
+}
+
+// searchQueryFilter checks object headers against the search query.
+type searchQueryFilter struct {
+	query *query.Query
+
+	explainWriter QueryExplanationWriter
+
+	explainRate, checked uint
+
+	// scores of the passed objects, nil if ranking is disabled
+	scores map[string]int
+}
+
+// Pass returns true if the object matches the query.
+//
+// Sampled objects are explained to the explanation writer. Scores
+// of the passed objects are recorded if ranking is enabled.
+func (f *searchQueryFilter) Pass(obj *object.Object) bool {
+	if !f.match(obj) {
+		return false
+	}
+
+	if f.scores != nil {
+		f.scores[obj.ID().String()] = f.query.Score(obj)
+	}
+
+	return true
+}
+
+func (f *searchQueryFilter) match(obj *object.Object) bool {
+	if f.explainWriter == nil {
+		return f.query.Match(obj)
+	}
+
+	f.checked++
+
+	if f.explainRate > 1 && (f.checked-1)%f.explainRate != 0 {
+		return f.query.Match(obj)
+	}
+
+	res := f.query.Explain(obj)
+
+	f.explainWriter.WriteQueryExplanation(obj.ID(), res)
+
+	for i := range res {
+		if !res[i].Passed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filterQuery returns identifiers of the selected objects
+// which headers match the search query.
+func (exec *execCtx) filterQuery(ids []*objectSDK.ID) []*objectSDK.ID {
+	var (
+		filter = &searchQueryFilter{
+			query:         exec.prm.query,
+			explainWriter: exec.prm.explainWriter,
+			explainRate:   exec.prm.explainRate,
+		}
+		hdrs = exec.localHeaders(ids)
+		res  = make([]*objectSDK.ID, 0, len(hdrs))
+	)
+
+	if exec.prm.rankByScore {
+		filter.scores = make(map[string]int, len(hdrs))
+	}
+
+	for i := range hdrs {
+		// explanation writer can block
+		if exec.context().Err() != nil {
+			break
+		}
+
+		if filter.Pass(hdrs[i]) {
+			res = append(res, hdrs[i].ID())
+		}
+	}
+
+	if filter.scores != nil {
+		sort.SliceStable(res, func(i, j int) bool {
+			return filter.scores[res[i].String()] > filter.scores[res[j].String()]
+		})
+	}
+
+	return res
+}
+
+func (exec *execCtx) writeOwnerGroups(hdrs []*object.Object) {
+	type ownerGroup struct {
+		owner *owner.ID
+		ids   []*objectSDK.ID
+	}
+
+	mGroups := make(map[string]*ownerGroup)
+
+	for i := range hdrs {
+		ownerID := hdrs[i].OwnerID()
+
+		var key string
+		if ownerID != nil {
+			key = ownerID.String()
+		}
+
+		g, ok := mGroups[key]
+		if !ok {
+			g = &ownerGroup{owner: ownerID}
+			mGroups[key] = g
+		}
+
+		g.ids = append(g.ids, hdrs[i].ID())
+	}
+
+	keys := make([]string, 0, len(mGroups))
+	for key := range mGroups {
+		keys = append(keys, key)
+	}
+
+	// write groups in a stable order
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		g := mGroups[key]
+
+		if err := exec.prm.ownerWriter.WriteOwnerIDs(g.owner, g.ids); err != nil {
+			exec.status = statusUndefined
+			exec.err = err
+
+			exec.log.Debug("could not write object identifiers",
+				zap.String("error", err.Error()),
+			)
+
+			return
+		}
+	}
+
+	exec.status = statusOK
+	exec.err = nil
 }
@@ -0,0 +1,63 @@
+package searchsvc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"go.uber.org/zap"
+)
+
+// Record is a JSON record of the object matched by the search
+// written by the NDJSON writer (see Prm.SetNDJSONWriter).
+type Record struct {
+	// String representation of the object ID.
+	ID string `json:"id"`
+
+	// String representation of the owner ID,
+	// omitted for objects without an owner.
+	Owner string `json:"owner,omitempty"`
+
+	// Payload size of the object.
+	Size uint64 `json:"size"`
+
+	// String representation of the object type.
+	Type string `json:"type"`
+}
+
+// newRecord returns JSON record of the object header.
+func newRecord(hdr *object.Object) Record {
+	r := Record{
+		ID:   hdr.ID().String(),
+		Size: hdr.PayloadSize(),
+		Type: hdr.Type().String(),
+	}
+
+	if ownerID := hdr.OwnerID(); ownerID != nil {
+		r.Owner = ownerID.String()
+	}
+
+	return r
+}
+
+// writeNDJSON writes records of the selected objects as newline-delimited JSON.
+func (exec *execCtx) writeNDJSON(hdrs []*object.Object) {
+	enc := json.NewEncoder(exec.prm.ndjsonWriter)
+
+	for i := range hdrs {
+		// Encode terminates each record with a newline
+		if err := enc.Encode(newRecord(hdrs[i])); err != nil {
+			exec.status = statusUndefined
+			exec.err = fmt.Errorf("could not write search record: %w", err)
+
+			exec.log.Debug("could not write search record",
+				zap.String("error", err.Error()),
+			)
+
+			return
+		}
+	}
+
+	exec.status = statusOK
+	exec.err = nil
+}
@@ -0,0 +1,124 @@
+package searchsvc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	cidtest "github.com/nspcc-dev/neofs-api-go/pkg/container/id/test"
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	ownertest "github.com/nspcc-dev/neofs-api-go/pkg/owner/test"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/util"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
+
+func TestGetLocalNDJSON(t *testing.T) {
+	ctx := context.Background()
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	ownerID := ownertest.Generate()
+
+	hdrs := []*object.RawObject{
+		generateHeader(ownerID),
+		generateHeader(ownerID),
+		generateHeader(nil),
+	}
+
+	hdrs[0].SetPayloadSize(100)
+	hdrs[1].SetType(objectSDK.TypeTombstone)
+	hdrs[2].SetType(objectSDK.TypeStorageGroup)
+	hdrs[2].SetPayloadSize(200)
+
+	ids := storage.addHeaders(hdrs...)
+
+	cid := cidtest.Generate()
+	storage.addResult(cid, ids, nil)
+
+	newPrm := func(localOnly bool) (Prm, *bytes.Buffer, *simpleIDWriter) {
+		buf := new(bytes.Buffer)
+		w := new(simpleIDWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.SetNDJSONWriter(buf)
+		p.common = new(util.CommonPrm).WithLocalOnly(localOnly)
+
+		return p, buf, w
+	}
+
+	t.Run("records", func(t *testing.T) {
+		p, buf, w := newPrm(true)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Empty(t, w.ids)
+
+		var (
+			sc      = bufio.NewScanner(buf)
+			records []map[string]interface{}
+		)
+
+		for sc.Scan() {
+			var r map[string]interface{}
+
+			require.NoError(t, json.Unmarshal(sc.Bytes(), &r), sc.Text())
+
+			records = append(records, r)
+		}
+
+		require.NoError(t, sc.Err())
+
+		require.Equal(t, []map[string]interface{}{
+			{
+				"id":    ids[0].String(),
+				"owner": ownerID.String(),
+				"size":  float64(100),
+				"type":  objectSDK.TypeRegular.String(),
+			},
+			{
+				"id":    ids[1].String(),
+				"owner": ownerID.String(),
+				"size":  float64(0),
+				"type":  objectSDK.TypeTombstone.String(),
+			},
+			{
+				"id":   ids[2].String(),
+				"size": float64(200),
+				"type": objectSDK.TypeStorageGroup.String(),
+			},
+		}, records)
+	})
+
+	t.Run("writer failure", func(t *testing.T) {
+		p, _, _ := newPrm(true)
+
+		errWrite := errors.New("test error")
+		p.SetNDJSONWriter(failingWriter{err: errWrite})
+
+		require.True(t, errors.Is(svc.Search(ctx, p), errWrite))
+	})
+
+	t.Run("non-local", func(t *testing.T) {
+		p, _, _ := newPrm(false)
+
+		require.True(t, errors.Is(svc.Search(ctx, p), errHeaderModeNotLocal))
+	})
+}
@@ -0,0 +1,194 @@
+package searchsvc
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+type orderKind uint8
+
+const (
+	orderDefault orderKind = iota
+	orderInsertion
+	orderAttribute
+)
+
+// Order is a hint of the local search result ordering.
+//
+// Zero Order means the default order of the storage.
+type Order struct {
+	kind orderKind
+
+	attr string
+
+	desc bool
+}
+
+// OrderByInsertion returns Order by the time of the object
+// insertion into the storage.
+func OrderByInsertion(desc bool) Order {
+	return Order{
+		kind: orderInsertion,
+		desc: desc,
+	}
+}
+
+// OrderByAttribute returns Order by the value of the object attribute
+// indexed by the storage.
+func OrderByAttribute(key string, desc bool) Order {
+	return Order{
+		kind: orderAttribute,
+		attr: key,
+		desc: desc,
+	}
+}
+
+func (o Order) isDefault() bool {
+	return o.kind == orderDefault
+}
+
+// errOrderNotSupported is returned by orderedStorage
+// if it can not honor the requested order.
+var errOrderNotSupported = errors.New("search order is not supported")
+
+// orderedStorage is an interface of the local storage
+// that is able to select objects in the requested order.
+//
+// It is not implemented by the storage engine yet.
+type orderedStorage interface {
+	searchOrdered(*execCtx, Order) ([]*objectSDK.ID, error)
+}
+
+// searchLocal selects objects from the local storage in the requested
+// order. If storage can not honor the order, objects are selected in the
+// default order and the fallback callback is called. In this case objects
+// are sorted in memory by the attribute if the order is by attribute
+// (see sortByAttribute).
+func (exec *execCtx) searchLocal() ([]*objectSDK.ID, error) {
+	order := exec.prm.order
+	if order.isDefault() {
+		return exec.svc.localStorage.search(exec)
+	}
+
+	if s, ok := exec.svc.localStorage.(orderedStorage); ok {
+		ids, err := s.searchOrdered(exec, order)
+		if !errors.Is(err, errOrderNotSupported) {
+			return ids, err
+		}
+	}
+
+	exec.log.Debug("requested order is not supported by the local storage, fallback to default")
+
+	if exec.prm.orderFallback != nil {
+		exec.prm.orderFallback()
+	}
+
+	ids, err := exec.svc.localStorage.search(exec)
+	if err != nil || order.kind != orderAttribute {
+		return ids, err
+	}
+
+	return exec.sortByAttribute(ids, order), nil
+}
+
+// sortByAttribute sorts identifiers of the selected objects by the value
+// of the order attribute read from the object headers (see
+// compareAttributeValues).
+//
+// Objects without the attribute (or which headers could not be read) are
+// placed last in any direction. Objects with equal values keep the order of
+// the local storage.
+func (exec *execCtx) sortByAttribute(ids []*objectSDK.ID, order Order) []*objectSDK.ID {
+	items := exec.attributeValues(ids, order.attr)
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].has || !items[j].has {
+			return items[i].has && !items[j].has
+		}
+
+		if order.desc {
+			return compareAttributeValues(items[j].val, items[i].val) < 0
+		}
+
+		return compareAttributeValues(items[i].val, items[j].val) < 0
+	})
+
+	return sortedIDs(items)
+}
+
+// attributeValue is a value of the object attribute.
+type attributeValue struct {
+	id *objectSDK.ID
+
+	val string
+
+	// false if object has no attribute or its header could not be read
+	has bool
+}
+
+// attributeValues reads values of the attribute of the selected objects.
+// Result is in the order of the identifiers.
+func (exec *execCtx) attributeValues(ids []*objectSDK.ID, key string) []attributeValue {
+	var (
+		items = make([]attributeValue, len(ids))
+		hdrs  = make(map[string]*object.Object, len(ids))
+	)
+
+	for _, hdr := range exec.localHeaders(ids) {
+		hdrs[hdr.ID().String()] = hdr
+	}
+
+	for i := range ids {
+		items[i].id = ids[i]
+
+		hdr, ok := hdrs[ids[i].String()]
+		if !ok {
+			continue
+		}
+
+		for _, a := range hdr.Attributes() {
+			if a.Key() == key {
+				items[i].val, items[i].has = a.Value(), true
+				break
+			}
+		}
+	}
+
+	return items
+}
+
+func sortedIDs(items []attributeValue) []*objectSDK.ID {
+	res := make([]*objectSDK.ID, len(items))
+	for i := range items {
+		res[i] = items[i].id
+	}
+
+	return res
+}
+
+// compareAttributeValues compares attribute values as integers if both
+// of them are decimal numbers, and as strings otherwise.
+func compareAttributeValues(a, b string) int {
+	x, errX := strconv.ParseInt(a, 10, 64)
+	y, errY := strconv.ParseInt(b, 10, 64)
+
+	switch {
+	case errX != nil || errY != nil:
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+
+	return 0
+}
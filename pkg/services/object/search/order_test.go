@@ -0,0 +1,196 @@
+package searchsvc
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"testing"
+
+	cidtest "github.com/nspcc-dev/neofs-api-go/pkg/container/id/test"
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	ownertest "github.com/nspcc-dev/neofs-api-go/pkg/owner/test"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/util"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+// orderedTestStorage is a testStorage which supports
+// the orders with the predefined results.
+type orderedTestStorage struct {
+	*testStorage
+
+	ordered map[Order][]*objectSDK.ID
+}
+
+func (s *orderedTestStorage) searchOrdered(_ *execCtx, o Order) ([]*objectSDK.ID, error) {
+	ids, ok := s.ordered[o]
+	if !ok {
+		return nil, errOrderNotSupported
+	}
+
+	return ids, nil
+}
+
+func reversedIDs(ids []*objectSDK.ID) []*objectSDK.ID {
+	res := make([]*objectSDK.ID, len(ids))
+	for i := range ids {
+		res[len(ids)-1-i] = ids[i]
+	}
+
+	return res
+}
+
+func TestGetLocalWithOrder(t *testing.T) {
+	ctx := context.Background()
+
+	cid := cidtest.Generate()
+	ids := generateIDs(5)
+
+	// insertion order, default order and attribute order differ
+	inserted := ids
+	defaultOrder := []*objectSDK.ID{ids[2], ids[0], ids[4], ids[1], ids[3]}
+	byAttribute := []*objectSDK.ID{ids[4], ids[3], ids[2], ids[1], ids[0]}
+
+	storage := &orderedTestStorage{
+		testStorage: newTestStorage(),
+		ordered: map[Order][]*objectSDK.ID{
+			OrderByInsertion(false):         inserted,
+			OrderByInsertion(true):          reversedIDs(inserted),
+			OrderByAttribute("Size", false): byAttribute,
+		},
+	}
+
+	storage.addResult(cid, defaultOrder, nil)
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	search := func(t *testing.T, o Order, localOnly bool) ([]*objectSDK.ID, bool, error) {
+		var fallback bool
+
+		w := new(simpleIDWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.SetOrder(o, func() { fallback = true })
+		p.common = new(util.CommonPrm).WithLocalOnly(localOnly)
+
+		err := svc.Search(ctx, p)
+
+		return w.ids, fallback, err
+	}
+
+	for _, tc := range []struct {
+		name     string
+		order    Order
+		expected []*objectSDK.ID
+		fallback bool
+	}{
+		{name: "default", order: Order{}, expected: defaultOrder},
+		{name: "insertion ascending", order: OrderByInsertion(false), expected: inserted},
+		{name: "insertion descending", order: OrderByInsertion(true), expected: reversedIDs(inserted)},
+		{name: "attribute", order: OrderByAttribute("Size", false), expected: byAttribute},
+		{name: "unsupported attribute", order: OrderByAttribute("Size", true), expected: defaultOrder, fallback: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, fallback, err := search(t, tc.order, true)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, res)
+			require.Equal(t, tc.fallback, fallback)
+		})
+	}
+
+	t.Run("storage w/o ordering", func(t *testing.T) {
+		svc.localStorage = storage.testStorage
+		defer func() { svc.localStorage = storage }()
+
+		res, fallback, err := search(t, OrderByInsertion(false), true)
+		require.NoError(t, err)
+		require.Equal(t, defaultOrder, res)
+		require.True(t, fallback)
+	})
+
+	t.Run("non-local", func(t *testing.T) {
+		_, _, err := search(t, OrderByInsertion(false), false)
+		require.True(t, errors.Is(err, errHeaderModeNotLocal))
+	})
+}
+
+func TestGetLocalOrderedByAttribute(t *testing.T) {
+	ctx := context.Background()
+
+	const priorityKey = "Priority"
+
+	cid := cidtest.Generate()
+	storage := newTestStorage()
+
+	var (
+		ids    []*objectSDK.ID
+		values = []int{10, 9, 100, 2, 33}
+	)
+
+	for _, v := range values {
+		hdr := generateHeader(ownertest.Generate())
+
+		a := objectSDK.NewAttribute()
+		a.SetKey(priorityKey)
+		a.SetValue(strconv.Itoa(v))
+
+		hdr.SetAttributes(a)
+
+		ids = append(ids, storage.addHeaders(hdr)...)
+	}
+
+	storage.addResult(cid, ids, nil)
+
+	sorted := make([]*objectSDK.ID, len(ids))
+	copy(sorted, ids)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return values[indexOfID(ids, sorted[i])] < values[indexOfID(ids, sorted[j])]
+	})
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+	svc.localBatchSize = 2
+
+	for _, desc := range []bool{false, true} {
+		t.Run("desc="+strconv.FormatBool(desc), func(t *testing.T) {
+			var fallback bool
+
+			w := new(batchSizeWriter)
+
+			p := Prm{}
+			p.WithContainerID(cid)
+			p.SetWriter(w)
+			p.SetOrder(OrderByAttribute(priorityKey, desc), func() { fallback = true })
+			p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+			require.NoError(t, svc.Search(ctx, p))
+
+			// objects are sorted in memory
+			require.True(t, fallback)
+			require.Equal(t, []int{2, 2, 1}, w.batches)
+
+			if desc {
+				require.Equal(t, reversedIDs(sorted), w.ids)
+			} else {
+				require.Equal(t, sorted, w.ids)
+			}
+		})
+	}
+}
+
+func indexOfID(ids []*objectSDK.ID, id *objectSDK.ID) int {
+	for i := range ids {
+		if ids[i].Equal(id) {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,94 @@
+package searchsvc
+
+import (
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"go.uber.org/zap"
+)
+
+// MatchedPart describes the matched child of the split-chain.
+type MatchedPart struct {
+	// Identifier of the child object.
+	ID *objectSDK.ID
+
+	// Zero-based position of the child in the split-chain.
+	//
+	// Negative if the position is unknown: the child is a linking
+	// object or some of the preceding children are missing in the
+	// local storage.
+	Index int
+}
+
+// MatchedPartsWriter is an interface of target component to write
+// the matched children of the parent objects.
+type MatchedPartsWriter interface {
+	WriteMatchedParts(parent *objectSDK.ID, parts []MatchedPart) error
+}
+
+// writeMatchedParts writes the collapsed children of the parents from ids.
+// Returns false if writing failed.
+func (exec *execCtx) writeMatchedParts(ids []*objectSDK.ID, parts map[string][]*object.Object) bool {
+	for i := range ids {
+		hdrs, ok := parts[ids[i].String()]
+		if !ok {
+			continue
+		}
+
+		res := make([]MatchedPart, len(hdrs))
+
+		for j := range hdrs {
+			res[j] = MatchedPart{
+				ID:    hdrs[j].ID(),
+				Index: exec.partIndex(hdrs[j]),
+			}
+		}
+
+		if err := exec.prm.partsWriter.WriteMatchedParts(ids[i], res); err != nil {
+			exec.status = statusUndefined
+			exec.err = err
+
+			exec.log.Debug("could not write matched parts",
+				zap.String("error", err.Error()),
+			)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// partIndex returns position of the child in the split-chain by walking
+// the chain back to its first child through the local storage.
+func (exec *execCtx) partIndex(hdr *object.Object) int {
+	if len(hdr.Children()) > 0 {
+		return -1
+	}
+
+	visited := make(map[string]struct{})
+
+	idx := 0
+
+	for prev := hdr.PreviousID(); prev != nil; idx++ {
+		key := prev.String()
+		if _, ok := visited[key]; ok {
+			// broken chain with a loop
+			return -1
+		}
+
+		visited[key] = struct{}{}
+
+		addr := objectSDK.NewAddress()
+		addr.SetContainerID(exec.containerID())
+		addr.SetObjectID(prev)
+
+		prevHdr, err := exec.svc.localStorage.head(addr)
+		if err != nil {
+			return -1
+		}
+
+		prev = prevHdr.PreviousID()
+	}
+
+	return idx
+}
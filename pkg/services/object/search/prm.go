@@ -1,14 +1,39 @@
 package searchsvc
 
 import (
+	"errors"
+	"fmt"
+	"io"
+
 	"github.com/nspcc-dev/neofs-api-go/pkg/client"
 	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-api-go/pkg/owner"
 	coreclient "github.com/nspcc-dev/neofs-node/pkg/core/client"
 	"github.com/nspcc-dev/neofs-node/pkg/network"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object/util"
 )
 
 // Prm groups parameters of Get service call.
+//
+// By default, identifiers of the selected objects are written to the
+// IDListWriter. Result can be written to one of the other targets
+// instead, these modes are mutually exclusive:
+//   - count of the matched objects (SetCountOnly);
+//   - identifiers grouped by owner (SetOwnerGroupWriter);
+//   - batches with cursors (SetCursorWriter);
+//   - page with the next cursor (SetPageWriter);
+//   - NDJSON records (SetNDJSONWriter);
+//   - aggregates without identifiers (SetAggregateWriter).
+//
+// Aggregates with the identifiers can be combined with any mode except
+// count-only one. Limit (SetLimit) is applied to the IDListWriter only,
+// so it can not be combined with the modes above except the aggregates
+// with the identifiers. Total count writer requires positive limit.
+//
+// Query, collapsing, sorting and ordering are applied before the writing
+// and can be combined with any mode. Search fails with an error if
+// parameters are combined in the other way.
 type Prm struct {
 	writer IDListWriter
 
@@ -17,6 +42,48 @@ type Prm struct {
 	client.SearchObjectParams
 
 	forwarder RequestForwarder
+
+	ownerWriter OwnerIDListWriter
+
+	cursor []byte
+
+	cursorWriter CursorIDListWriter
+
+	batchSize int
+
+	pageWriter CursorPageWriter
+
+	pageSize int
+
+	query *query.Query
+
+	explainWriter QueryExplanationWriter
+
+	explainRate uint
+
+	rankByScore bool
+
+	order Order
+
+	orderFallback func()
+
+	aggregateWriter AggregateWriter
+
+	aggregateWithIDs bool
+
+	limit int
+
+	totalWriter TotalCountWriter
+
+	ndjsonWriter io.Writer
+
+	collapseToParent bool
+
+	partsWriter MatchedPartsWriter
+
+	countWriter TotalCountWriter
+
+	sort Sort
 }
 
 // IDListWriter is an interface of target component
@@ -25,6 +92,32 @@ type IDListWriter interface {
 	WriteIDs([]*objectSDK.ID) error
 }
 
+// OwnerIDListWriter is an interface of target component
+// to write lists of object identifiers grouped by owner.
+type OwnerIDListWriter interface {
+	WriteOwnerIDs(*owner.ID, []*objectSDK.ID) error
+}
+
+// CursorIDListWriter is an interface of target component to write
+// batches of object identifiers along with the cursor that allows
+// to resume the search right after the batch.
+type CursorIDListWriter interface {
+	WriteIDsWithCursor(ids []*objectSDK.ID, cursor []byte) error
+}
+
+// CursorPageWriter is an interface of target component to write a single
+// page of object identifiers along with the cursor of the next page.
+// The flag is set if there are more identifiers after the page.
+type CursorPageWriter interface {
+	WriteIDPage(ids []*objectSDK.ID, next []byte, more bool) error
+}
+
+// QueryExplanationWriter is an interface of target component
+// to write the outcomes of the query matchers evaluated over the object.
+type QueryExplanationWriter interface {
+	WriteQueryExplanation(*objectSDK.ID, []query.MatchResult)
+}
+
 // RequestForwarder is a callback for forwarding of the
 // original Search requests.
 type RequestForwarder func(network.AddressGroup, coreclient.Client) ([]*objectSDK.ID, error)
@@ -44,3 +137,244 @@ func (p *Prm) SetWriter(w IDListWriter) {
 func (p *Prm) SetRequestForwarder(f RequestForwarder) {
 	p.forwarder = f
 }
+
+// SetOwnerGroupWriter sets target component to write object identifiers
+// grouped by the object owner. If set, results are written to it instead
+// of the IDListWriter.
+//
+// Grouping requires object headers, so it is supported for local
+// operations only.
+func (p *Prm) SetOwnerGroupWriter(w OwnerIDListWriter) {
+	p.ownerWriter = w
+}
+
+// SetCursorWriter sets target component to write batches of object
+// identifiers with the cursors. Identifiers are written in a stable
+// order, batches contain no more than batchSize elements (all
+// identifiers in a single batch if not positive).
+//
+// Cursors are supported for local operations only.
+func (p *Prm) SetCursorWriter(w CursorIDListWriter, batchSize int) {
+	p.cursorWriter = w
+	p.batchSize = batchSize
+}
+
+// SetPageWriter sets target component to write a single page of no more
+// than pageSize object identifiers following the cursor (see SetCursor).
+// Identifiers are paged in the same stable order as the cursor batches
+// (see SetCursorWriter), so the pages do not overlap and skip nothing
+// if the selected objects do not change between the searches.
+//
+// Next cursor is the cursor of the last identifier of the page, or the
+// requested one if the page is empty. Non-positive size means no limit.
+//
+// Pagination is supported for local operations only.
+func (p *Prm) SetPageWriter(w CursorPageWriter, pageSize int) {
+	p.pageWriter = w
+	p.pageSize = pageSize
+}
+
+// SetCursor sets the cursor received along with the last processed
+// batch, so the search is resumed after that batch. Cursor is opaque
+// to the caller. Nil cursor means searching from the beginning.
+//
+// Cursor is processed only with CursorIDListWriter and CursorPageWriter.
+//
+// Local storage does not support the positioned selection, so each search
+// with the cursor still selects and sorts all the matched objects, and
+// the ones up to the cursor are skipped in memory. Resuming the search
+// is not cheaper than the search from the beginning.
+func (p *Prm) SetCursor(cursor []byte) {
+	p.cursor = cursor
+}
+
+// SetQuery sets the query which is additionally evaluated over
+// the headers of the objects selected by the search filters.
+//
+// Query requires object headers, so it is supported for local
+// operations only.
+func (p *Prm) SetQuery(q *query.Query) {
+	p.query = q
+}
+
+// SetQueryExplanationWriter sets target component to write the explanations
+// of the query evaluation for debugging purposes. Each rate-th object
+// checked against the query is explained (each object if rate is zero).
+//
+// Explanations are written only if query is set.
+func (p *Prm) SetQueryExplanationWriter(w QueryExplanationWriter, rate uint) {
+	p.explainWriter = w
+	p.explainRate = rate
+}
+
+// SetQueryRanking sets flag to sort the objects matched by the query in
+// descending order of their scores, i.e. the number of the optional query
+// matchers passed by the object (see query.Query.Score). Objects with
+// equal scores keep the order of the local storage.
+//
+// Ranking is processed only if query is set. Ranked results are written
+// to the IDListWriter, other writers order the results themselves.
+func (p *Prm) SetQueryRanking(rank bool) {
+	p.rankByScore = rank
+}
+
+// SetOrder sets the hint of the result ordering. If the local storage
+// can not honor the order, objects are written in the default order
+// and the fallback callback is called (if set). Objects are sorted
+// in memory in this case if the order is by attribute, which requires
+// all of the selected objects to be buffered.
+//
+// None of the local storages (storage engine and its partitions) supports
+// the ordered selection yet, so the fallback is always taken: the order
+// by insertion is not honored, and the order by attribute is honored
+// in memory only.
+//
+// Ordering is supported for local operations only.
+func (p *Prm) SetOrder(o Order, fallback func()) {
+	p.order = o
+	p.orderFallback = fallback
+}
+
+// SetAggregateWriter sets target component to write statistics over
+// the matched objects. If withIDs is false, statistics are written
+// instead of the object identifiers, otherwise identifiers are
+// additionally written to the configured writer.
+//
+// Aggregation requires object headers, so it is supported for local
+// operations only.
+func (p *Prm) SetAggregateWriter(w AggregateWriter, withIDs bool) {
+	p.aggregateWriter = w
+	p.aggregateWithIDs = withIDs
+}
+
+// SetLimit sets max number of object identifiers written to the
+// IDListWriter (not limited if not positive). If total is set, number
+// of all the matched objects is written to it along with the limited
+// page of the identifiers, so total requires positive limit.
+//
+// Limit is applied to the identifiers selected from the local storage,
+// so all the matched identifiers are still kept in memory.
+//
+// Limit is supported for local operations only.
+func (p *Prm) SetLimit(limit int, total TotalCountWriter) {
+	p.limit = limit
+	p.totalWriter = total
+}
+
+// SetSort sets sorting of the matched objects. Sorting is applied after
+// the query (including the ranking, see SetQueryRanking) and collapsing,
+// so the writers receive the objects in the sorted order. Objects are
+// not sorted by default and are written in the order of the local storage.
+//
+// Sorting requires all the matched identifiers to be buffered before
+// writing. Sorting by attribute additionally reads the header of each
+// matched object and keeps the attribute values in memory.
+//
+// Sorting is supported for local operations only.
+func (p *Prm) SetSort(s Sort) {
+	p.sort = s
+}
+
+// SetCountOnly sets target to write the number of the matched objects
+// instead of their identifiers: IDListWriter is not used, and the other
+// result writers must not be set (see Prm). If collapsing is enabled (see SetCollapseToParent),
+// each logical object is counted once regardless of the number of its
+// matched children.
+//
+// Objects are counted after the selection from the local storage, so
+// the identifiers of all the matched objects are still kept in memory.
+//
+// Count-only mode is supported for local operations only.
+func (p *Prm) SetCountOnly(w TotalCountWriter) {
+	p.countWriter = w
+}
+
+// SetNDJSONWriter sets target to write the matched objects as
+// newline-delimited JSON records (see Record) instead of the
+// IDListWriter, one record per object.
+//
+// Records require object headers, so they are supported
+// for local operations only.
+func (p *Prm) SetNDJSONWriter(w io.Writer) {
+	p.ndjsonWriter = w
+}
+
+// SetCollapseToParent sets flag to replace the matched children of the
+// split-chains with their parent (logical) objects. Each parent is written
+// once regardless of the number of its matched children.
+//
+// Collapsing requires object headers, so it is supported
+// for local operations only.
+func (p *Prm) SetCollapseToParent(collapse bool) {
+	p.collapseToParent = collapse
+}
+
+// SetMatchedPartsWriter sets target to write the matched children of each
+// parent object selected by collapsing (see SetCollapseToParent). Parts
+// of the parent are written before the list of identifiers.
+//
+// Writer is not used if collapsing is disabled. Parts require object
+// headers, so they are supported for local operations only.
+func (p *Prm) SetMatchedPartsWriter(w MatchedPartsWriter) {
+	p.partsWriter = w
+}
+
+var errHeaderModeNotLocal = errors.New("requested search mode is supported for local operations only")
+
+// localOnlyMode returns true if requested search mode
+// can be served by the local storage only.
+func (p *Prm) localOnlyMode() bool {
+	return p.ownerWriter != nil || p.cursorWriter != nil || p.query != nil ||
+		p.aggregateWriter != nil || !p.order.isDefault() || p.limit > 0 ||
+		p.ndjsonWriter != nil || p.collapseToParent || p.partsWriter != nil ||
+		p.countWriter != nil || !p.sort.isDefault() || p.pageWriter != nil
+}
+
+var errIncompatibleModes = errors.New("incompatible search modes")
+
+// exclusiveModes returns names of the requested mutually exclusive
+// modes of the result writing.
+func (p *Prm) exclusiveModes() []string {
+	var modes []string
+
+	for _, m := range [...]struct {
+		name string
+		set  bool
+	}{
+		{"count-only", p.countWriter != nil},
+		{"owner groups", p.ownerWriter != nil},
+		{"cursor batches", p.cursorWriter != nil},
+		{"cursor page", p.pageWriter != nil},
+		{"NDJSON records", p.ndjsonWriter != nil},
+		{"aggregates without IDs", p.aggregateWriter != nil && !p.aggregateWithIDs},
+	} {
+		if m.set {
+			modes = append(modes, m.name)
+		}
+	}
+
+	return modes
+}
+
+// validate checks that parameters are combined
+// in the supported way (see Prm).
+func (p *Prm) validate() error {
+	if p.localOnlyMode() && !p.common.LocalOnly() {
+		return errHeaderModeNotLocal
+	}
+
+	modes := p.exclusiveModes()
+
+	switch {
+	case len(modes) > 1:
+		return fmt.Errorf("%w: %s and %s", errIncompatibleModes, modes[0], modes[1])
+	case p.countWriter != nil && p.aggregateWriter != nil:
+		return fmt.Errorf("%w: count-only and aggregates", errIncompatibleModes)
+	case p.limit > 0 && len(modes) > 0:
+		return fmt.Errorf("%w: limit and %s", errIncompatibleModes, modes[0])
+	case p.totalWriter != nil && p.limit <= 0:
+		return fmt.Errorf("%w: total count without limit", errIncompatibleModes)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package searchsvc
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrm_Validate(t *testing.T) {
+	var (
+		countOnly = func(p *Prm) { p.SetCountOnly(new(totalCountWriter)) }
+		owners    = func(p *Prm) { p.SetOwnerGroupWriter(new(ownerGroupWriter)) }
+		batches   = func(p *Prm) { p.SetCursorWriter(new(batchWriter), 10) }
+		page      = func(p *Prm) { p.SetPageWriter(new(pageWriter), 10) }
+		ndjson    = func(p *Prm) { p.SetNDJSONWriter(new(bytes.Buffer)) }
+		aggrOnly  = func(p *Prm) { p.SetAggregateWriter(new(aggregateWriter), false) }
+		aggrIDs   = func(p *Prm) { p.SetAggregateWriter(new(aggregateWriter), true) }
+		limit     = func(p *Prm) { p.SetLimit(5, nil) }
+		total     = func(p *Prm) { p.SetLimit(5, new(totalCountWriter)) }
+		noLimit   = func(p *Prm) { p.SetLimit(0, new(totalCountWriter)) }
+		withQuery = func(p *Prm) { p.SetQuery(query.New(query.NewSplitChildMatcher())) }
+		collapse  = func(p *Prm) { p.SetCollapseToParent(true) }
+		sorted    = func(p *Prm) { p.SetSort(SortByID(false)) }
+	)
+
+	for _, tc := range []struct {
+		name  string
+		setup []func(*Prm)
+		valid bool
+	}{
+		{name: "default", valid: true},
+		{name: "count-only", setup: []func(*Prm){countOnly, withQuery, collapse}, valid: true},
+		{name: "NDJSON", setup: []func(*Prm){ndjson, sorted}, valid: true},
+		{name: "aggregates with IDs and owner groups", setup: []func(*Prm){aggrIDs, owners}, valid: true},
+		{name: "aggregates with IDs and limit", setup: []func(*Prm){aggrIDs, limit}, valid: true},
+		{name: "limit with total", setup: []func(*Prm){total, sorted}, valid: true},
+		{name: "count-only and owner groups", setup: []func(*Prm){countOnly, owners}},
+		{name: "cursor batches and page", setup: []func(*Prm){batches, page}},
+		{name: "NDJSON and owner groups", setup: []func(*Prm){ndjson, owners}},
+		{name: "aggregates only and cursor page", setup: []func(*Prm){aggrOnly, page}},
+		{name: "count-only and aggregates", setup: []func(*Prm){countOnly, aggrIDs}},
+		{name: "limit and count-only", setup: []func(*Prm){limit, countOnly}},
+		{name: "limit and aggregates only", setup: []func(*Prm){limit, aggrOnly}},
+		{name: "total without limit", setup: []func(*Prm){noLimit}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := Prm{}
+			p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+			for _, f := range tc.setup {
+				f(&p)
+			}
+
+			err := p.validate()
+			if tc.valid {
+				require.NoError(t, err)
+				return
+			}
+
+			require.True(t, errors.Is(err, errIncompatibleModes), err)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package query
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+type attributeMatcher struct {
+	key, val string
+
+	*matchCfg
+}
+
+// MatchOption is a constructor option of the attribute matchers.
+type MatchOption func(*matchCfg)
+
+type matchCfg struct {
+	normalize, fold bool
+}
+
+// WithNormalization returns option to compare attribute values in Unicode
+// Normalization Form C, so the composed and decomposed forms of the same
+// characters are equal.
+func WithNormalization() MatchOption {
+	return func(c *matchCfg) {
+		c.normalize = true
+	}
+}
+
+// WithCaseFolding returns option to compare attribute values with Unicode
+// case folding, so the values differing in case only are equal. Values
+// are compared in Unicode Normalization Form C as well.
+func WithCaseFolding() MatchOption {
+	return func(c *matchCfg) {
+		c.normalize = true
+		c.fold = true
+	}
+}
+
+// NewAttributeMatcher returns Matcher which passes the objects
+// with the attribute of the specified key and value.
+//
+// By default values are compared byte-by-byte.
+func NewAttributeMatcher(key, val string, opts ...MatchOption) Matcher {
+	c := new(matchCfg)
+
+	for i := range opts {
+		opts[i](c)
+	}
+
+	return &attributeMatcher{
+		key:      key,
+		val:      c.canonical(val),
+		matchCfg: c,
+	}
+}
+
+func (m *attributeMatcher) Pass(obj *object.Object) bool {
+	val, ok := attributeValue(obj, m.key)
+
+	return ok && m.canonical(val) == m.val
+}
+
+func (m *attributeMatcher) String() string {
+	return m.key + " == " + m.val
+}
+
+// canonical returns the form of the value used for comparison.
+func (c *matchCfg) canonical(val string) string {
+	if c.normalize {
+		val = norm.NFC.String(val)
+	}
+
+	if c.fold {
+		// Caser is stateful, so it is not shared
+		val = cases.Fold().String(val)
+	}
+
+	return val
+}
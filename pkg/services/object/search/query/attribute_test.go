@@ -0,0 +1,59 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributeMatcher(t *testing.T) {
+	const (
+		key = "City"
+
+		composed   = "Z\u00fcrich"  // ü as a single code point
+		decomposed = "Zu\u0308rich" // u + combining diaeresis
+	)
+
+	t.Run("byte-exact", func(t *testing.T) {
+		m := query.NewAttributeMatcher(key, composed)
+
+		require.True(t, m.Pass(objectWithAttributes(key, composed)))
+		require.False(t, m.Pass(objectWithAttributes(key, decomposed)))
+		require.False(t, m.Pass(objectWithAttributes(key, "zürich")))
+		require.False(t, m.Pass(objectWithAttributes("Other", composed)))
+	})
+
+	t.Run("normalization", func(t *testing.T) {
+		for _, val := range []string{composed, decomposed} {
+			m := query.NewAttributeMatcher(key, val, query.WithNormalization())
+
+			require.True(t, m.Pass(objectWithAttributes(key, composed)))
+			require.True(t, m.Pass(objectWithAttributes(key, decomposed)))
+
+			// case is still significant
+			require.False(t, m.Pass(objectWithAttributes(key, "ZÜRICH")))
+		}
+	})
+
+	t.Run("case folding", func(t *testing.T) {
+		for _, tc := range []struct {
+			val     string
+			matched []string
+		}{
+			{val: decomposed, matched: []string{composed, "zürich", "ZÜRICH"}},
+			{val: "Straße", matched: []string{"STRASSE", "strasse"}},
+			{val: "ΣΊΣΥΦΟΣ", matched: []string{"σίσυφος"}},
+		} {
+			m := query.NewAttributeMatcher(key, tc.val, query.WithCaseFolding())
+
+			require.True(t, m.Pass(objectWithAttributes(key, tc.val)))
+
+			for _, val := range tc.matched {
+				require.True(t, m.Pass(objectWithAttributes(key, val)), val)
+			}
+
+			require.False(t, m.Pass(objectWithAttributes(key, "Berlin")))
+		}
+	})
+}
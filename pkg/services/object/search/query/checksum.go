@@ -0,0 +1,30 @@
+package query
+
+import (
+	"github.com/nspcc-dev/neofs-api-go/pkg"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// size of the Tillich-Zémor hash in bytes
+const tzChecksumSize = 64
+
+type invalidHomomorphicHashMatcher struct{}
+
+// NewInvalidHomomorphicHashMatcher returns Matcher which passes the objects
+// without the valid homomorphic payload hash: the hash is missing, has the
+// type other than Tillich-Zémor or has the wrong length. Such objects were
+// written with the homomorphic hashing disabled or are corrupted, so they
+// need re-hashing.
+func NewInvalidHomomorphicHashMatcher() Matcher {
+	return invalidHomomorphicHashMatcher{}
+}
+
+func (invalidHomomorphicHashMatcher) Pass(obj *object.Object) bool {
+	cs := obj.PayloadHomomorphicHash()
+
+	return cs == nil || cs.Type() != pkg.ChecksumTZ || len(cs.Sum()) != tzChecksumSize
+}
+
+func (invalidHomomorphicHashMatcher) String() string {
+	return "invalid homomorphic hash"
+}
@@ -0,0 +1,37 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidHomomorphicHashMatcher(t *testing.T) {
+	m := query.NewInvalidHomomorphicHashMatcher()
+
+	withHash := func(cs *pkg.Checksum) *object.Object {
+		obj := object.NewRaw()
+		obj.SetPayloadHomomorphicHash(cs)
+
+		return obj.Object()
+	}
+
+	valid := pkg.NewChecksum()
+	valid.SetTillichZemor([64]byte{1})
+
+	wrongType := pkg.NewChecksum()
+	wrongType.SetSHA256([32]byte{1})
+
+	wrongLength := pkg.NewChecksum()
+	wrongLength.SetTillichZemor([64]byte{1})
+	wrongLength.ToV2().SetSum([]byte{1, 2, 3})
+
+	require.False(t, m.Pass(withHash(valid)))
+
+	require.True(t, m.Pass(object.NewRaw().Object()))
+	require.True(t, m.Pass(withHash(wrongType)))
+	require.True(t, m.Pass(withHash(wrongLength)))
+}
@@ -0,0 +1,64 @@
+package query
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+type notMatcher struct {
+	m Matcher
+}
+
+// NewNotMatcher returns Matcher which passes the objects
+// that are not passed by m.
+func NewNotMatcher(m Matcher) Matcher {
+	return &notMatcher{
+		m: m,
+	}
+}
+
+func (m *notMatcher) Pass(obj *object.Object) bool {
+	return !m.m.Pass(obj)
+}
+
+func (m *notMatcher) String() string {
+	return "NOT (" + describe(m.m) + ")"
+}
+
+type presenceMatcher struct {
+	key string
+
+	present bool
+}
+
+// NewAttributeExistsMatcher returns Matcher which passes the objects
+// with the attribute of the specified key regardless of its value,
+// including the empty one.
+func NewAttributeExistsMatcher(key string) Matcher {
+	return &presenceMatcher{
+		key:     key,
+		present: true,
+	}
+}
+
+// NewAttributeAbsentMatcher returns Matcher which passes the objects
+// without the attribute of the specified key. Objects with the attribute
+// of the empty value do not match.
+func NewAttributeAbsentMatcher(key string) Matcher {
+	return &presenceMatcher{
+		key: key,
+	}
+}
+
+func (m *presenceMatcher) Pass(obj *object.Object) bool {
+	_, ok := attributeValue(obj, m.key)
+
+	return ok == m.present
+}
+
+func (m *presenceMatcher) String() string {
+	if m.present {
+		return m.key + " exists"
+	}
+
+	return m.key + " absent"
+}
@@ -0,0 +1,78 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotMatcher(t *testing.T) {
+	m := query.NewNotMatcher(query.NewAttributeMatcher("City", "Berlin"))
+
+	require.False(t, m.Pass(objectWithAttributes("City", "Berlin")))
+	require.True(t, m.Pass(objectWithAttributes("City", "Paris")))
+	require.True(t, m.Pass(objectWithAttributes()))
+}
+
+func TestAttributePresenceMatchers(t *testing.T) {
+	const key = "Tag"
+
+	exists := query.NewAttributeExistsMatcher(key)
+	absent := query.NewAttributeAbsentMatcher(key)
+
+	for _, tc := range []struct {
+		name    string
+		attrs   []string
+		present bool
+	}{
+		{name: "with value", attrs: []string{key, "value"}, present: true},
+		{name: "empty value", attrs: []string{key, ""}, present: true},
+		{name: "other attribute", attrs: []string{"Other", "value"}},
+		{name: "no attributes"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := objectWithAttributes(tc.attrs...)
+
+			require.Equal(t, tc.present, exists.Pass(obj))
+			require.Equal(t, !tc.present, absent.Pass(obj))
+		})
+	}
+}
+
+func TestQuery_Negations(t *testing.T) {
+	// objects not from Berlin, not archived and without the Tag attribute
+	q := query.New(
+		query.NewNotMatcher(query.NewAttributeMatcher("City", "Berlin")),
+		query.NewNotMatcher(query.NewAttributeMatcher("Archived", "true")),
+		query.NewAttributeAbsentMatcher("Tag"),
+	)
+
+	for _, tc := range []struct {
+		attrs   []string
+		matched bool
+	}{
+		{matched: true},
+		{attrs: []string{"City", "Paris"}, matched: true},
+		{attrs: []string{"City", "Paris", "Archived", "false"}, matched: true},
+		{attrs: []string{"City", "Berlin"}},
+		{attrs: []string{"City", "Paris", "Archived", "true"}},
+		{attrs: []string{"City", "Paris", "Tag", ""}},
+		{attrs: []string{"Tag", "value"}},
+	} {
+		require.Equal(t, tc.matched, q.Match(objectWithAttributes(tc.attrs...)), tc.attrs)
+	}
+
+	require.Equal(t, []query.MatchResult{
+		{Matcher: "NOT (City == Berlin)", Passed: true},
+		{Matcher: "NOT (Archived == true)", Passed: false},
+		{Matcher: "Tag absent", Passed: true},
+	}, q.Explain(objectWithAttributes("Archived", "true")))
+
+	t.Run("double negation", func(t *testing.T) {
+		m := query.NewNotMatcher(query.NewNotMatcher(query.NewAttributeExistsMatcher("Tag")))
+
+		require.True(t, m.Pass(objectWithAttributes("Tag", "")))
+		require.False(t, m.Pass(objectWithAttributes()))
+	})
+}
@@ -0,0 +1,118 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// Matcher is an interface of the object header checker.
+type Matcher interface {
+	// Pass must return true if the object matches.
+	Pass(*object.Object) bool
+}
+
+// Query is a conjunction of the object matchers.
+//
+// Unlike the search filters, query is evaluated over the
+// object headers, so it can express the conditions which
+// are not supported by the storage indexes.
+//
+// Query can additionally contain optional matchers which do not
+// affect the matching, but are used to score the matched objects.
+type Query struct {
+	matchers []Matcher
+
+	optional []Matcher
+}
+
+// New creates, initializes and returns Query instance.
+//
+// Query without matchers matches any object.
+func New(ms ...Matcher) *Query {
+	return &Query{
+		matchers: ms,
+	}
+}
+
+// Match returns true if the object passes all matchers of the query.
+func (q *Query) Match(obj *object.Object) bool {
+	for i := range q.matchers {
+		if !q.matchers[i].Pass(obj) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WithOptional adds optional matchers to the query and returns it.
+func (q *Query) WithOptional(ms ...Matcher) *Query {
+	q.optional = append(q.optional, ms...)
+
+	return q
+}
+
+// Score returns the number of the optional matchers of the query
+// passed by the object. Score does not check mandatory matchers,
+// so it makes sense for the objects that passed Match only.
+func (q *Query) Score(obj *object.Object) int {
+	var score int
+
+	for i := range q.optional {
+		if q.optional[i].Pass(obj) {
+			score++
+		}
+	}
+
+	return score
+}
+
+// MatchResult describes the outcome of the single matcher evaluation.
+type MatchResult struct {
+	// Description of the matcher.
+	Matcher string
+
+	// Evaluation result.
+	Passed bool
+}
+
+// Explain evaluates all matchers of the query over the object and
+// returns their outcomes in the order of the matchers. Unlike Match,
+// evaluation does not stop on the first failed matcher. Optional
+// matchers are not evaluated.
+//
+// Matchers implementing fmt.Stringer are described with String,
+// the others with the type name.
+func (q *Query) Explain(obj *object.Object) []MatchResult {
+	res := make([]MatchResult, len(q.matchers))
+
+	for i := range q.matchers {
+		res[i] = MatchResult{
+			Matcher: describe(q.matchers[i]),
+			Passed:  q.matchers[i].Pass(obj),
+		}
+	}
+
+	return res
+}
+
+func describe(m Matcher) string {
+	if s, ok := m.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return fmt.Sprintf("%T", m)
+}
+
+// attributeValue returns value of the object attribute and
+// flag of its presence.
+func attributeValue(obj *object.Object, key string) (string, bool) {
+	for _, a := range obj.Attributes() {
+		if a.Key() == key {
+			return a.Value(), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,76 @@
+package query_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
+	"github.com/stretchr/testify/require"
+)
+
+type testMatcher bool
+
+func (m testMatcher) Pass(*object.Object) bool {
+	return bool(m)
+}
+
+func TestQuery_Explain(t *testing.T) {
+	from := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	q := query.New(
+		testMatcher(true),
+		query.NewTimeWindowMatcher(timestampKey, from, time.Time{}),
+		testMatcher(false),
+	)
+
+	t.Run("match", func(t *testing.T) {
+		obj := objectWithAttributes(timestampKey, "2021-06-01T13:00:00Z")
+
+		require.False(t, q.Match(obj))
+		require.Equal(t, []query.MatchResult{
+			{Matcher: "query_test.testMatcher", Passed: true},
+			{Matcher: "Timestamp in [2021-06-01T12:00:00Z, -]", Passed: true},
+			{Matcher: "query_test.testMatcher", Passed: false},
+		}, q.Explain(obj))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		res := q.Explain(objectWithAttributes(timestampKey, "malformed"))
+
+		require.Len(t, res, 3)
+		require.False(t, res[1].Passed)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		require.True(t, query.New().Match(objectWithAttributes()))
+		require.Empty(t, query.New().Explain(objectWithAttributes()))
+	})
+}
+
+func TestQuery_Score(t *testing.T) {
+	q := query.New(testMatcher(true)).WithOptional(
+		query.NewAttributeMatcher("Color", "red"),
+		query.NewAttributeMatcher("Size", "XL"),
+		query.NewAttributeMatcher("Brand", "NeoFS"),
+	)
+
+	for _, tc := range []struct {
+		attrs []string
+		score int
+	}{
+		{score: 0},
+		{attrs: []string{"Color", "blue", "Size", "M"}, score: 0},
+		{attrs: []string{"Color", "red", "Size", "M"}, score: 1},
+		{attrs: []string{"Color", "red", "Brand", "NeoFS"}, score: 2},
+		{attrs: []string{"Color", "red", "Size", "XL", "Brand", "NeoFS"}, score: 3},
+	} {
+		obj := objectWithAttributes(tc.attrs...)
+
+		// optional matchers do not affect the matching
+		require.True(t, q.Match(obj))
+		require.Equal(t, tc.score, q.Score(obj))
+	}
+
+	require.Len(t, q.Explain(objectWithAttributes()), 1)
+}
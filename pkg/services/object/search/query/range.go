@@ -0,0 +1,116 @@
+package query
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// maxNumericLength limits the length of the numeric attribute value,
+// longer values are treated as malformed.
+const maxNumericLength = 64
+
+// RangeBound is a bound of the numeric range.
+//
+// Nil RangeBound means no limit.
+type RangeBound struct {
+	value *big.Int
+
+	exclusive bool
+}
+
+// InclusiveBound returns range bound which includes v.
+func InclusiveBound(v int64) *RangeBound {
+	return &RangeBound{value: big.NewInt(v)}
+}
+
+// ExclusiveBound returns range bound which excludes v.
+func ExclusiveBound(v int64) *RangeBound {
+	return &RangeBound{value: big.NewInt(v), exclusive: true}
+}
+
+type rangeMatcher struct {
+	field string
+
+	value func(*object.Object) (*big.Int, bool)
+
+	from, to *RangeBound
+}
+
+// NewAttributeRangeMatcher returns Matcher which passes objects with the
+// numeric value of the attribute with the specified key within the range
+// between from and to.
+//
+// Attribute value must be a decimal integer. Objects without the attribute
+// or with malformed value do not match.
+func NewAttributeRangeMatcher(key string, from, to *RangeBound) Matcher {
+	return &rangeMatcher{
+		field: key,
+		value: func(obj *object.Object) (*big.Int, bool) {
+			val, ok := attributeValue(obj, key)
+			if !ok || len(val) > maxNumericLength {
+				return nil, false
+			}
+
+			return new(big.Int).SetString(val, 10)
+		},
+		from: from,
+		to:   to,
+	}
+}
+
+// NewPayloadSizeRangeMatcher returns Matcher which passes objects with
+// the payload size within the range between from and to.
+func NewPayloadSizeRangeMatcher(from, to *RangeBound) Matcher {
+	return &rangeMatcher{
+		field: "payload size",
+		value: func(obj *object.Object) (*big.Int, bool) {
+			return new(big.Int).SetUint64(obj.PayloadSize()), true
+		},
+		from: from,
+		to:   to,
+	}
+}
+
+func (m *rangeMatcher) Pass(obj *object.Object) bool {
+	v, ok := m.value(obj)
+	if !ok {
+		return false
+	}
+
+	if m.from != nil {
+		if c := v.Cmp(m.from.value); c < 0 || c == 0 && m.from.exclusive {
+			return false
+		}
+	}
+
+	if m.to != nil {
+		if c := v.Cmp(m.to.value); c > 0 || c == 0 && m.to.exclusive {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *rangeMatcher) String() string {
+	l, r := "[", "]"
+	if m.from != nil && m.from.exclusive {
+		l = "("
+	}
+
+	if m.to != nil && m.to.exclusive {
+		r = ")"
+	}
+
+	return fmt.Sprintf("%s in %s%s, %s%s", m.field, l, formatRangeBound(m.from), formatRangeBound(m.to), r)
+}
+
+func formatRangeBound(b *RangeBound) string {
+	if b == nil {
+		return "-"
+	}
+
+	return b.value.String()
+}
@@ -0,0 +1,83 @@
+package query_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
+	"github.com/stretchr/testify/require"
+)
+
+const priorityKey = "Priority"
+
+func TestAttributeRangeMatcher(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		from, to *query.RangeBound
+		value    string
+		match    bool
+	}{
+		{name: "inside", from: query.InclusiveBound(10), to: query.InclusiveBound(20), value: "15", match: true},
+		{name: "inclusive lower", from: query.InclusiveBound(10), to: query.InclusiveBound(20), value: "10", match: true},
+		{name: "inclusive upper", from: query.InclusiveBound(10), to: query.InclusiveBound(20), value: "20", match: true},
+		{name: "exclusive lower", from: query.ExclusiveBound(10), to: query.InclusiveBound(20), value: "10", match: false},
+		{name: "exclusive upper", from: query.InclusiveBound(10), to: query.ExclusiveBound(20), value: "20", match: false},
+		{name: "below", from: query.InclusiveBound(10), to: query.InclusiveBound(20), value: "9", match: false},
+		{name: "above", from: query.InclusiveBound(10), to: query.InclusiveBound(20), value: "21", match: false},
+		{name: "negative", from: query.InclusiveBound(-5), to: query.ExclusiveBound(0), value: "-3", match: true},
+		{name: "no lower", to: query.InclusiveBound(20), value: "-100", match: true},
+		{name: "no upper", from: query.InclusiveBound(10), value: "18446744073709551616", match: true},
+		{name: "explicit sign", from: query.InclusiveBound(10), value: "+11", match: true},
+		{name: "malformed", from: query.InclusiveBound(10), value: "eleven", match: false},
+		{name: "float", from: query.InclusiveBound(10), value: "11.5", match: false},
+		{name: "hex", from: query.InclusiveBound(10), value: "0x10", match: false},
+		{name: "spaces", from: query.InclusiveBound(10), value: " 11", match: false},
+		{name: "empty", value: "", match: false},
+		{name: "too long", value: strings.Repeat("1", 65), match: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := query.NewAttributeRangeMatcher(priorityKey, tc.from, tc.to)
+
+			require.Equal(t, tc.match, m.Pass(objectWithAttributes(priorityKey, tc.value)))
+		})
+	}
+
+	t.Run("missing attribute", func(t *testing.T) {
+		m := query.NewAttributeRangeMatcher(priorityKey, nil, nil)
+
+		require.False(t, m.Pass(objectWithAttributes("Other", "15")))
+		require.True(t, m.Pass(objectWithAttributes(priorityKey, "15")))
+	})
+}
+
+func TestPayloadSizeRangeMatcher(t *testing.T) {
+	obj := object.NewRaw()
+	obj.SetPayloadSize(100)
+
+	for _, tc := range []struct {
+		from, to *query.RangeBound
+		match    bool
+	}{
+		{from: query.InclusiveBound(100), to: query.InclusiveBound(100), match: true},
+		{from: query.ExclusiveBound(100), match: false},
+		{to: query.ExclusiveBound(100), match: false},
+		{from: query.InclusiveBound(50), to: query.ExclusiveBound(150), match: true},
+		{from: query.InclusiveBound(101), match: false},
+		{match: true},
+	} {
+		require.Equal(t, tc.match, query.NewPayloadSizeRangeMatcher(tc.from, tc.to).Pass(obj.Object()))
+	}
+}
+
+func TestRangeMatcher_Explain(t *testing.T) {
+	res := query.New(
+		query.NewAttributeRangeMatcher(priorityKey, query.ExclusiveBound(1), query.InclusiveBound(5)),
+		query.NewPayloadSizeRangeMatcher(nil, query.ExclusiveBound(10)),
+	).Explain(objectWithAttributes(priorityKey, "3"))
+
+	require.Equal(t, []query.MatchResult{
+		{Matcher: "Priority in (1, 5]", Passed: true},
+		{Matcher: "payload size in [-, 10)", Passed: true},
+	}, res)
+}
@@ -0,0 +1,26 @@
+package query
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+type splitChildMatcher struct{}
+
+// NewSplitChildMatcher returns Matcher which passes the objects that are
+// parts of the split-chains: the objects which carry the parent header or
+// any split-chain reference (split ID, previous part, children list).
+// Monolithic objects do not match.
+func NewSplitChildMatcher() Matcher {
+	return splitChildMatcher{}
+}
+
+func (splitChildMatcher) Pass(obj *object.Object) bool {
+	return obj.GetParent() != nil ||
+		obj.SplitID() != nil ||
+		obj.PreviousID() != nil ||
+		len(obj.Children()) > 0
+}
+
+func (splitChildMatcher) String() string {
+	return "split child"
+}
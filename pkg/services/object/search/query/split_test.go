@@ -0,0 +1,42 @@
+package query_test
+
+import (
+	"testing"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
+	"github.com/stretchr/testify/require"
+)
+
+func testID() *objectSDK.ID {
+	id := objectSDK.NewID()
+	id.SetSHA256([32]byte{1})
+
+	return id
+}
+
+func TestSplitChildMatcher(t *testing.T) {
+	m := query.NewSplitChildMatcher()
+
+	whole := object.NewRaw()
+	whole.SetPayload([]byte{1, 2, 3})
+
+	withParent := object.NewRaw()
+	withParent.SetParent(whole.Object().SDK())
+
+	withSplitID := object.NewRaw()
+	withSplitID.SetSplitID(objectSDK.NewSplitID())
+
+	withPrevious := object.NewRaw()
+	withPrevious.SetPreviousID(testID())
+
+	linking := object.NewRaw()
+	linking.SetChildren(testID())
+
+	require.False(t, m.Pass(whole.Object()))
+
+	for _, obj := range []*object.RawObject{withParent, withSplitID, withPrevious, linking} {
+		require.True(t, m.Pass(obj.Object()))
+	}
+}
@@ -0,0 +1,72 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+type timeWindowMatcher struct {
+	key string
+
+	from, to time.Time
+}
+
+// NewTimeWindowMatcher returns Matcher which passes objects created
+// within [from, to] according to the timestamp in the attribute
+// with the specified key. Zero bound means no limit.
+//
+// Attribute value must be either Unix time in seconds or time in
+// RFC3339 format with the time zone (fractional seconds are allowed).
+// Timestamps in different zones are compared as absolute time instants.
+//
+// Objects without the attribute or with malformed timestamp do not match.
+func NewTimeWindowMatcher(key string, from, to time.Time) Matcher {
+	return &timeWindowMatcher{
+		key:  key,
+		from: from,
+		to:   to,
+	}
+}
+
+func (m *timeWindowMatcher) Pass(obj *object.Object) bool {
+	val, ok := attributeValue(obj, m.key)
+	if !ok {
+		return false
+	}
+
+	t, ok := parseTimestamp(val)
+	if !ok {
+		return false
+	}
+
+	return (m.from.IsZero() || !t.Before(m.from)) &&
+		(m.to.IsZero() || !t.After(m.to))
+}
+
+func (m *timeWindowMatcher) String() string {
+	return fmt.Sprintf("%s in [%s, %s]", m.key, formatBound(m.from), formatBound(m.to))
+}
+
+func formatBound(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
+
+func parseTimestamp(val string) (time.Time, bool) {
+	if unix, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return time.Unix(unix, 0), true
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, val)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
@@ -0,0 +1,73 @@
+package query_test
+
+import (
+	"testing"
+	"time"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
+	"github.com/stretchr/testify/require"
+)
+
+const timestampKey = "Timestamp"
+
+func objectWithAttributes(kv ...string) *object.Object {
+	obj := object.NewRaw()
+
+	attrs := make([]*objectSDK.Attribute, 0, len(kv)/2)
+
+	for i := 0; i < len(kv); i += 2 {
+		a := objectSDK.NewAttribute()
+		a.SetKey(kv[i])
+		a.SetValue(kv[i+1])
+
+		attrs = append(attrs, a)
+	}
+
+	obj.SetAttributes(attrs...)
+
+	return obj.Object()
+}
+
+func TestTimeWindowMatcher(t *testing.T) {
+	from := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	m := query.NewTimeWindowMatcher(timestampKey, from, to)
+
+	for _, tc := range []struct {
+		name  string
+		value string
+		match bool
+	}{
+		{name: "unix inside", value: "1622550600", match: true},      // 12:30 UTC
+		{name: "unix lower bound", value: "1622548800", match: true}, // 12:00 UTC
+		{name: "unix upper bound", value: "1622552400", match: true}, // 13:00 UTC
+		{name: "unix before", value: "1622548799", match: false},     // 11:59:59 UTC
+		{name: "unix after", value: "1622552401", match: false},      // 13:00:01 UTC
+		{name: "RFC3339 UTC", value: "2021-06-01T12:30:00Z", match: true},
+		{name: "RFC3339 offset inside", value: "2021-06-01T15:30:00+03:00", match: true},
+		{name: "RFC3339 offset outside", value: "2021-06-01T12:30:00+03:00", match: false},
+		{name: "RFC3339 fractional upper bound", value: "2021-06-01T13:00:00.000Z", match: true},
+		{name: "RFC3339 fractional after", value: "2021-06-01T13:00:00.001Z", match: false},
+		{name: "without zone", value: "2021-06-01T12:30:00", match: false},
+		{name: "malformed", value: "yesterday", match: false},
+		{name: "empty", value: "", match: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.match, m.Pass(objectWithAttributes(timestampKey, tc.value)))
+		})
+	}
+
+	t.Run("missing attribute", func(t *testing.T) {
+		require.False(t, m.Pass(objectWithAttributes("key", "1622550600")))
+	})
+
+	t.Run("open bounds", func(t *testing.T) {
+		obj := objectWithAttributes(timestampKey, "0")
+
+		require.True(t, query.NewTimeWindowMatcher(timestampKey, time.Time{}, to).Pass(obj))
+		require.False(t, query.NewTimeWindowMatcher(timestampKey, from, time.Time{}).Pass(obj))
+	})
+}
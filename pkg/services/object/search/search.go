@@ -8,6 +8,10 @@ import (
 
 // Search serves a request to select the objects.
 func (s *Service) Search(ctx context.Context, prm Prm) error {
+	if err := prm.validate(); err != nil {
+		return err
+	}
+
 	exec := &execCtx{
 		svc: s,
 		ctx: ctx,
@@ -7,14 +7,20 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/nspcc-dev/neofs-api-go/pkg/container"
 	cid "github.com/nspcc-dev/neofs-api-go/pkg/container/id"
 	cidtest "github.com/nspcc-dev/neofs-api-go/pkg/container/id/test"
 	"github.com/nspcc-dev/neofs-api-go/pkg/netmap"
 	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-api-go/pkg/owner"
+	ownertest "github.com/nspcc-dev/neofs-api-go/pkg/owner/test"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
 	"github.com/nspcc-dev/neofs-node/pkg/network"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/search/query"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object/util"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/placement"
 	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
@@ -28,6 +34,8 @@ type idsErr struct {
 
 type testStorage struct {
 	items map[string]idsErr
+
+	heads map[string]*object.Object
 }
 
 type testTraverserGenerator struct {
@@ -61,6 +69,7 @@ func (s *simpleIDWriter) WriteIDs(ids []*objectSDK.ID) error {
 func newTestStorage() *testStorage {
 	return &testStorage{
 		items: make(map[string]idsErr),
+		heads: make(map[string]*object.Object),
 	}
 }
 
@@ -102,6 +111,15 @@ func (s *testStorage) search(exec *execCtx) ([]*objectSDK.ID, error) {
 	return v.ids, v.err
 }
 
+func (s *testStorage) head(addr *objectSDK.Address) (*object.Object, error) {
+	hdr, ok := s.heads[addr.ObjectID().String()]
+	if !ok {
+		return nil, object.ErrNotFound
+	}
+
+	return hdr, nil
+}
+
 func (c *testStorage) searchObjects(exec *execCtx, _ network.AddressGroup) ([]*objectSDK.ID, error) {
 	v, ok := c.items[exec.containerID().String()]
 	if !ok {
@@ -118,6 +136,26 @@ func (c *testStorage) addResult(addr *cid.ID, ids []*objectSDK.ID, err error) {
 	}
 }
 
+// addHeaders stores headers and returns identifiers of the objects.
+func (s *testStorage) addHeaders(hdrs ...*object.RawObject) []*objectSDK.ID {
+	ids := make([]*objectSDK.ID, len(hdrs))
+
+	for i := range hdrs {
+		ids[i] = hdrs[i].ID()
+		s.heads[ids[i].String()] = hdrs[i].Object()
+	}
+
+	return ids
+}
+
+func generateHeader(ownerID *owner.ID) *object.RawObject {
+	obj := object.NewRaw()
+	obj.SetID(generateIDs(1)[0])
+	obj.SetOwnerID(ownerID)
+
+	return obj
+}
+
 func testSHA256() (cs [sha256.Size]byte) {
 	rand.Read(cs[:])
 	return cs
@@ -186,6 +224,567 @@ func TestGetLocalOnly(t *testing.T) {
 	})
 }
 
+// batchSizeWriter records the sizes of the written batches.
+type batchSizeWriter struct {
+	simpleIDWriter
+
+	batches []int
+
+	err error
+}
+
+func (w *batchSizeWriter) WriteIDs(ids []*objectSDK.ID) error {
+	w.batches = append(w.batches, len(ids))
+
+	if w.err != nil {
+		return w.err
+	}
+
+	return w.simpleIDWriter.WriteIDs(ids)
+}
+
+func TestGetLocalBatches(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name      string
+		num       int
+		batchSize int
+		batches   []int
+	}{
+		{name: "default", num: 600, batches: []int{256, 256, 88}},
+		{name: "custom", num: 250, batchSize: 100, batches: []int{100, 100, 50}},
+		{name: "aligned", num: 200, batchSize: 100, batches: []int{100, 100}},
+		{name: "single", num: 10, batchSize: 100, batches: []int{10}},
+		{name: "empty", num: 0, batchSize: 100, batches: []int{0}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := newTestStorage()
+
+			svc := &Service{cfg: new(cfg)}
+			svc.log = test.NewLogger(false)
+			svc.localStorage = storage
+			svc.localBatchSize = tc.batchSize
+
+			cid := cidtest.Generate()
+			ids := generateIDs(tc.num)
+			storage.addResult(cid, ids, nil)
+
+			w := new(batchSizeWriter)
+
+			p := Prm{}
+			p.WithContainerID(cid)
+			p.SetWriter(w)
+			p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+			require.NoError(t, svc.Search(ctx, p))
+			require.Equal(t, tc.batches, w.batches)
+			require.Equal(t, len(ids), len(w.ids))
+
+			if len(ids) > 0 {
+				require.Equal(t, ids, w.ids)
+			}
+		})
+	}
+
+	t.Run("writer failure", func(t *testing.T) {
+		storage := newTestStorage()
+
+		svc := &Service{cfg: new(cfg)}
+		svc.log = test.NewLogger(false)
+		svc.localStorage = storage
+		svc.localBatchSize = 3
+
+		cid := cidtest.Generate()
+		storage.addResult(cid, generateIDs(10), nil)
+
+		w := &batchSizeWriter{err: errors.New("test error")}
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+		require.True(t, errors.Is(svc.Search(ctx, p), w.err))
+		require.Equal(t, []int{3}, w.batches)
+	})
+}
+
+// slowIDWriter simulates the slow consumer of the results.
+type slowIDWriter struct {
+	delay time.Duration
+
+	batches int
+}
+
+func (w *slowIDWriter) WriteIDs([]*objectSDK.ID) error {
+	w.batches++
+	time.Sleep(w.delay)
+
+	return nil
+}
+
+type slowExplanationWriter struct {
+	delay time.Duration
+
+	explained int
+}
+
+func (w *slowExplanationWriter) WriteQueryExplanation(*objectSDK.ID, []query.MatchResult) {
+	w.explained++
+	time.Sleep(w.delay)
+}
+
+func TestGetLocalDeadline(t *testing.T) {
+	const (
+		num   = 50
+		delay = 10 * time.Millisecond
+	)
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+	svc.localBatchSize = 1
+
+	hdrs := make([]*object.RawObject, num)
+	for i := range hdrs {
+		hdrs[i] = generateHeader(nil)
+	}
+
+	cid := cidtest.Generate()
+	storage.addResult(cid, storage.addHeaders(hdrs...), nil)
+
+	newPrm := func(w IDListWriter) Prm {
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+		return p
+	}
+
+	t.Run("slow writer", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*delay)
+		defer cancel()
+
+		w := &slowIDWriter{delay: delay}
+
+		err := svc.Search(ctx, newPrm(w))
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+		require.Less(t, w.batches, num)
+	})
+
+	t.Run("slow explanation writer", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*delay)
+		defer cancel()
+
+		ew := &slowExplanationWriter{delay: delay}
+		w := new(simpleIDWriter)
+
+		p := newPrm(w)
+		p.SetQuery(query.New(query.NewSplitChildMatcher()))
+		p.SetQueryExplanationWriter(ew, 1)
+
+		err := svc.Search(ctx, p)
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+		require.Less(t, ew.explained, num)
+		require.Empty(t, w.ids)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		w := new(slowIDWriter)
+
+		err := svc.Search(ctx, newPrm(w))
+		require.True(t, errors.Is(err, context.Canceled))
+		require.False(t, errors.Is(err, context.DeadlineExceeded))
+		require.Zero(t, w.batches)
+	})
+}
+
+func TestGetLocalSplitChainOnce(t *testing.T) {
+	ctx := context.Background()
+
+	splitID := objectSDK.NewSplitID()
+	par := generateHeader(nil)
+
+	var chain []*object.RawObject
+
+	for i := 0; i < 3; i++ {
+		child := generateHeader(nil)
+		child.SetSplitID(splitID)
+
+		if i > 0 {
+			child.SetPreviousID(chain[i-1].ID())
+		}
+
+		chain = append(chain, child)
+	}
+
+	chain[2].SetParent(par.SDK().Object())
+
+	link := generateHeader(nil)
+	link.SetSplitID(splitID)
+	link.SetParent(par.SDK().Object())
+	link.SetChildren(chain[0].ID(), chain[1].ID(), chain[2].ID())
+
+	chain = append(chain, link)
+
+	cid := cidtest.Generate()
+
+	var (
+		storages multiStorage
+		ids      []*objectSDK.ID
+	)
+
+	// chain members are stored in several partitions
+	for _, idx := range [][]int{{0, 1, 2}, {2, 3}, {3, 0}} {
+		storage := newTestStorage()
+
+		partHdrs := make([]*object.RawObject, 0, len(idx))
+		for _, i := range idx {
+			partHdrs = append(partHdrs, chain[i])
+		}
+
+		storage.addResult(cid, storage.addHeaders(partHdrs...), nil)
+
+		storages = append(storages, storage)
+	}
+
+	for i := range chain {
+		ids = append(ids, chain[i].ID())
+	}
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storages
+	// batches cover different parts of the chain
+	svc.localBatchSize = 1
+
+	w := new(batchSizeWriter)
+
+	p := Prm{}
+	p.WithContainerID(cid)
+	p.SetWriter(w)
+	p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+	require.NoError(t, svc.Search(ctx, p))
+	require.Equal(t, ids, w.ids)
+	require.Equal(t, []int{1, 1, 1, 1}, w.batches)
+}
+
+type ownerGroupWriter struct {
+	groups map[string][]*objectSDK.ID
+}
+
+func (w *ownerGroupWriter) WriteOwnerIDs(ownerID *owner.ID, ids []*objectSDK.ID) error {
+	w.groups[ownerID.String()] = append(w.groups[ownerID.String()], ids...)
+	return nil
+}
+
+func TestGetLocalGroupByOwner(t *testing.T) {
+	ctx := context.Background()
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	owners := []*owner.ID{ownertest.Generate(), ownertest.Generate(), ownertest.Generate()}
+	expected := make(map[string][]*objectSDK.ID, len(owners))
+
+	var ids []*objectSDK.ID
+
+	for i := range owners {
+		for j := 0; j <= i; j++ {
+			id := storage.addHeaders(generateHeader(owners[i]))[0]
+			ids = append(ids, id)
+			expected[owners[i].String()] = append(expected[owners[i].String()], id)
+		}
+	}
+
+	cid := cidtest.Generate()
+	storage.addResult(cid, ids, nil)
+
+	newPrm := func(localOnly bool) (Prm, *ownerGroupWriter) {
+		w := &ownerGroupWriter{groups: make(map[string][]*objectSDK.ID)}
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetOwnerGroupWriter(w)
+		p.common = new(util.CommonPrm).WithLocalOnly(localOnly)
+
+		return p, w
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		p, w := newPrm(true)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, expected, w.groups)
+
+		for i := range owners {
+			require.Len(t, w.groups[owners[i].String()], i+1)
+		}
+	})
+
+	t.Run("non-local", func(t *testing.T) {
+		p, _ := newPrm(false)
+
+		err := svc.Search(ctx, p)
+		require.True(t, errors.Is(err, errHeaderModeNotLocal))
+	})
+}
+
+func TestGetLocalMultiStorage(t *testing.T) {
+	ctx := context.Background()
+
+	cid := cidtest.Generate()
+	ids := generateIDs(6)
+
+	var storages multiStorage
+
+	// partitions with overlapping contents
+	for _, idx := range [][]int{{0, 1}, {}, {1, 2, 3}, {3, 4, 5, 0}} {
+		storage := newTestStorage()
+
+		partIDs := make([]*objectSDK.ID, 0, len(idx))
+		for _, i := range idx {
+			partIDs = append(partIDs, ids[i])
+		}
+
+		storage.addResult(cid, partIDs, nil)
+
+		storages = append(storages, storage)
+	}
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storages
+
+	newPrm := func() (Prm, *simpleIDWriter) {
+		w := new(simpleIDWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+		return p, w
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		p, w := newPrm()
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, ids, w.ids)
+
+		res, err := storages.search(&execCtx{prm: p})
+		require.NoError(t, err)
+		require.Equal(t, ids, res)
+	})
+
+	t.Run("head", func(t *testing.T) {
+		hdr := generateHeader(ownertest.Generate())
+
+		storages[2].(*testStorage).addHeaders(hdr)
+
+		addr := objectSDK.NewAddress()
+		addr.SetContainerID(cid)
+		addr.SetObjectID(hdr.ID())
+
+		res, err := storages.head(addr)
+		require.NoError(t, err)
+		require.Equal(t, hdr.Object(), res)
+
+		addr.SetObjectID(generateIDs(1)[0])
+
+		_, err = storages.head(addr)
+		require.True(t, errors.Is(err, object.ErrNotFound))
+	})
+
+	t.Run("partition failure", func(t *testing.T) {
+		testErr := errors.New("test error")
+
+		storages[1].(*testStorage).addResult(cid, nil, testErr)
+
+		p, _ := newPrm()
+
+		err := svc.Search(ctx, p)
+		require.True(t, errors.Is(err, testErr))
+	})
+}
+
+func TestGetLocalWithQuery(t *testing.T) {
+	ctx := context.Background()
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	const timestampKey = "Timestamp"
+
+	var ids []*objectSDK.ID
+
+	for _, ts := range []string{"100", "200", "300", "invalid"} {
+		hdr := generateHeader(ownertest.Generate())
+
+		a := objectSDK.NewAttribute()
+		a.SetKey(timestampKey)
+		a.SetValue(ts)
+
+		hdr.SetAttributes(a)
+
+		ids = append(ids, storage.addHeaders(hdr)...)
+	}
+
+	cid := cidtest.Generate()
+	storage.addResult(cid, ids, nil)
+
+	newPrm := func(localOnly bool) (Prm, *simpleIDWriter) {
+		w := new(simpleIDWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.SetQuery(query.New(
+			query.NewTimeWindowMatcher(timestampKey, time.Unix(150, 0), time.Unix(300, 0)),
+		))
+		p.common = new(util.CommonPrm).WithLocalOnly(localOnly)
+
+		return p, w
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		p, w := newPrm(true)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, ids[1:3], w.ids)
+	})
+
+	t.Run("explanation", func(t *testing.T) {
+		p, w := newPrm(true)
+
+		e := &explanationWriter{explanations: make(map[string][]query.MatchResult)}
+		p.SetQueryExplanationWriter(e, 2)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, ids[1:3], w.ids)
+
+		// each second object is explained
+		require.Len(t, e.explanations, 2)
+		require.Equal(t, []bool{false}, passed(e.explanations[ids[0].String()]))
+		require.Equal(t, []bool{true}, passed(e.explanations[ids[2].String()]))
+	})
+
+	t.Run("non-local", func(t *testing.T) {
+		p, _ := newPrm(false)
+
+		err := svc.Search(ctx, p)
+		require.True(t, errors.Is(err, errHeaderModeNotLocal))
+	})
+}
+
+func TestGetLocalRankedQuery(t *testing.T) {
+	ctx := context.Background()
+
+	storage := newTestStorage()
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	var ids []*objectSDK.ID
+
+	// objects match different subsets of the optional filters
+	for _, attrs := range [][]string{
+		{"Type", "photo"},
+		{"Type", "photo", "Color", "red", "Size", "XL"},
+		{"Type", "video", "Color", "red", "Size", "XL"},
+		{"Type", "photo", "Size", "XL"},
+		{"Type", "photo", "Color", "red"},
+		{"Type", "photo", "Color", "blue", "Size", "XL", "Author", "Bob"},
+	} {
+		hdr := generateHeader(ownertest.Generate())
+
+		as := make([]*objectSDK.Attribute, 0, len(attrs)/2)
+
+		for i := 0; i < len(attrs); i += 2 {
+			a := objectSDK.NewAttribute()
+			a.SetKey(attrs[i])
+			a.SetValue(attrs[i+1])
+
+			as = append(as, a)
+		}
+
+		hdr.SetAttributes(as...)
+
+		ids = append(ids, storage.addHeaders(hdr)...)
+	}
+
+	cid := cidtest.Generate()
+	storage.addResult(cid, ids, nil)
+
+	newPrm := func(rank bool) (Prm, *simpleIDWriter) {
+		w := new(simpleIDWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.SetQuery(query.New(
+			query.NewAttributeMatcher("Type", "photo"),
+		).WithOptional(
+			query.NewAttributeMatcher("Color", "red"),
+			query.NewAttributeMatcher("Size", "XL"),
+			query.NewAttributeMatcher("Author", "Bob"),
+		))
+		p.SetQueryRanking(rank)
+		p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+		return p, w
+	}
+
+	t.Run("ranked", func(t *testing.T) {
+		p, w := newPrm(true)
+
+		require.NoError(t, svc.Search(ctx, p))
+
+		// scores: 2, 2, 1, 1, 0, objects with equal scores keep the order
+		require.Equal(t, []*objectSDK.ID{ids[1], ids[5], ids[3], ids[4], ids[0]}, w.ids)
+	})
+
+	t.Run("not ranked", func(t *testing.T) {
+		p, w := newPrm(false)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, []*objectSDK.ID{ids[0], ids[1], ids[3], ids[4], ids[5]}, w.ids)
+	})
+}
+
+type explanationWriter struct {
+	explanations map[string][]query.MatchResult
+}
+
+func (w *explanationWriter) WriteQueryExplanation(id *objectSDK.ID, res []query.MatchResult) {
+	w.explanations[id.String()] = res
+}
+
+func passed(res []query.MatchResult) []bool {
+	ps := make([]bool, len(res))
+	for i := range res {
+		ps[i] = res[i].Passed
+	}
+
+	return ps
+}
+
 func testNodeMatrix(t testing.TB, dim []int) ([]netmap.Nodes, [][]string) {
 	mNodes := make([]netmap.Nodes, len(dim))
 	mAddr := make([][]string, len(dim))
@@ -417,3 +1016,143 @@ func TestGetFromPastEpoch(t *testing.T) {
 	require.NoError(t, err)
 	assertContains(ids11, ids12, ids21, ids22)
 }
+
+// concurrentStorage is a testStorage which tracks
+// the number of the concurrent searches.
+type concurrentStorage struct {
+	*testStorage
+
+	mtx *sync.Mutex
+
+	active, maxActive *int
+
+	// called on search, can be nil
+	onSearch func()
+}
+
+func (s *concurrentStorage) search(exec *execCtx) ([]*objectSDK.ID, error) {
+	s.mtx.Lock()
+	*s.active++
+	if *s.active > *s.maxActive {
+		*s.maxActive = *s.active
+	}
+	s.mtx.Unlock()
+
+	if s.onSearch != nil {
+		s.onSearch()
+	}
+
+	// let the other workers start
+	time.Sleep(10 * time.Millisecond)
+
+	s.mtx.Lock()
+	*s.active--
+	s.mtx.Unlock()
+
+	return s.testStorage.search(exec)
+}
+
+func TestGetLocalConcurrency(t *testing.T) {
+	cid := cidtest.Generate()
+	ids := generateIDs(10)
+
+	var (
+		mtx               sync.Mutex
+		active, maxActive int
+		searched          int
+		storages          multiStorage
+	)
+
+	// partitions with overlapping contents
+	for i := 0; i < len(ids)-1; i++ {
+		storage := newTestStorage()
+		storage.addResult(cid, []*objectSDK.ID{ids[i+1], ids[i]}, nil)
+
+		storages = append(storages, &concurrentStorage{
+			testStorage: storage,
+			mtx:         &mtx,
+			active:      &active,
+			maxActive:   &maxActive,
+			onSearch: func() {
+				mtx.Lock()
+				searched++
+				mtx.Unlock()
+			},
+		})
+	}
+
+	expected := []*objectSDK.ID{ids[1], ids[0]}
+	expected = append(expected, ids[2:]...)
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storages
+
+	search := func(ctx context.Context) ([]*objectSDK.ID, error) {
+		w := new(simpleIDWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.common = new(util.CommonPrm).WithLocalOnly(true)
+
+		err := svc.Search(ctx, p)
+
+		return w.ids, err
+	}
+
+	for _, n := range []int{0, 1, 3, 20} {
+		t.Run("concurrency="+strconv.Itoa(n), func(t *testing.T) {
+			svc.concurrency = n
+			maxActive, searched = 0, 0
+
+			res, err := search(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, expected, res)
+			require.Equal(t, len(storages), searched)
+
+			limit := n
+			if limit < 1 {
+				limit = 1
+			}
+
+			require.LessOrEqual(t, maxActive, limit)
+
+			if n > 1 {
+				require.Greater(t, maxActive, 1)
+			}
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		svc.concurrency = 2
+		searched = 0
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		storages[0].(*concurrentStorage).onSearch = func() {
+			mtx.Lock()
+			searched++
+			mtx.Unlock()
+
+			cancel()
+		}
+
+		_, err := search(ctx)
+		require.True(t, errors.Is(err, context.Canceled))
+
+		// the rest of the partitions are not searched
+		require.LessOrEqual(t, searched, 2)
+	})
+
+	t.Run("partition failure", func(t *testing.T) {
+		svc.concurrency = 3
+
+		testErr := errors.New("test error")
+
+		storages[4].(*concurrentStorage).addResult(cid, nil, testErr)
+
+		_, err := search(context.Background())
+		require.True(t, errors.Is(err, testErr))
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/pkg/object"
 	"github.com/nspcc-dev/neofs-node/pkg/core/client"
 	"github.com/nspcc-dev/neofs-node/pkg/core/netmap"
+	objectcore "github.com/nspcc-dev/neofs-node/pkg/core/object"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
 	"github.com/nspcc-dev/neofs-node/pkg/network"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object/util"
@@ -30,12 +31,15 @@ type ClientConstructor interface {
 	Get(network.AddressGroup) (client.Client, error)
 }
 
+type localStorage interface {
+	search(*execCtx) ([]*object.ID, error)
+	head(*object.Address) (*objectcore.Object, error)
+}
+
 type cfg struct {
 	log *logger.Logger
 
-	localStorage interface {
-		search(*execCtx) ([]*object.ID, error)
-	}
+	localStorage localStorage
 
 	clientConstructor interface {
 		get(network.AddressGroup) (searchClient, error)
@@ -48,12 +52,19 @@ type cfg struct {
 	currentEpochReceiver interface {
 		currentEpoch() (uint64, error)
 	}
+
+	localBatchSize int
+
+	concurrency int
 }
 
+const defaultLocalBatchSize = 256
+
 func defaultCfg() *cfg {
 	return &cfg{
 		log:               zap.L(),
 		clientConstructor: new(clientConstructorWrapper),
+		localBatchSize:    defaultLocalBatchSize,
 	}
 }
 
@@ -86,6 +97,21 @@ func WithLocalStorageEngine(e *engine.StorageEngine) Option {
 	}
 }
 
+// WithLocalStorageEngines returns option to set the ordered list of local
+// storage partitions (e.g. sharded by time). Search spans all partitions,
+// objects stored in several partitions are returned once.
+func WithLocalStorageEngines(es ...*engine.StorageEngine) Option {
+	return func(c *cfg) {
+		ss := make(multiStorage, len(es))
+
+		for i := range es {
+			ss[i] = (*storageEngineWrapper)(es[i])
+		}
+
+		c.localStorage = ss
+	}
+}
+
 // WithClientConstructor returns option to set constructor of remote node clients.
 func WithClientConstructor(v ClientConstructor) Option {
 	return func(c *cfg) {
@@ -110,3 +136,29 @@ func WithNetMapSource(nmSrc netmap.Source) Option {
 		}
 	}
 }
+
+// WithLocalBatchSize returns option to set the number of object identifiers
+// written at once from the local storage. Identifiers are written in batches
+// instead of a single list of all the selected objects.
+//
+// Non-positive value means default batch size (256).
+func WithLocalBatchSize(n int) Option {
+	return func(c *cfg) {
+		c.localBatchSize = n
+	}
+}
+
+// WithConcurrency returns option to limit the number of the local storage
+// partitions (see WithLocalStorageEngines) searched in parallel. Partitions
+// are not searched once the context of the operation is done.
+//
+// Result does not depend on the concurrency: objects are still written in
+// the order of the partitions and objects stored in several partitions are
+// written once.
+//
+// Non-positive value or 1 means sequential search.
+func WithConcurrency(n int) Option {
+	return func(c *cfg) {
+		c.concurrency = n
+	}
+}
@@ -0,0 +1,106 @@
+package searchsvc
+
+import (
+	"bytes"
+	"sort"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+)
+
+type sortKind uint8
+
+const (
+	sortNone sortKind = iota
+	sortID
+	sortAttribute
+)
+
+// Sort is a sorting of the local search result.
+//
+// Zero Sort means no sorting.
+type Sort struct {
+	kind sortKind
+
+	attr string
+
+	desc bool
+}
+
+// SortByID returns Sort by the bytes of the object identifiers.
+func SortByID(desc bool) Sort {
+	return Sort{
+		kind: sortID,
+		desc: desc,
+	}
+}
+
+// SortByAttribute returns Sort by the value of the object attribute.
+// Values are compared as integers if both of them are decimal numbers,
+// and as strings otherwise.
+//
+// Objects without the attribute are placed last in any direction.
+// Objects with equal values (and the ones without the attribute) are
+// sorted by the bytes of their identifiers in ascending order, so the
+// result is always the same for the same set of the objects.
+func SortByAttribute(key string, desc bool) Sort {
+	return Sort{
+		kind: sortAttribute,
+		attr: key,
+		desc: desc,
+	}
+}
+
+func (s Sort) isDefault() bool {
+	return s.kind == sortNone
+}
+
+// sortResult sorts identifiers of the selected objects.
+func (exec *execCtx) sortResult(ids []*objectSDK.ID) []*objectSDK.ID {
+	s := exec.prm.sort
+
+	var items []attributeValue
+
+	if s.kind == sortAttribute {
+		items = exec.attributeValues(ids, s.attr)
+	} else {
+		items = make([]attributeValue, len(ids))
+		for i := range ids {
+			items[i].id = ids[i]
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		var c int
+
+		switch {
+		case s.kind == sortID:
+			c = compareIDs(items[i].id, items[j].id)
+
+			if s.desc {
+				c = -c
+			}
+
+			return c < 0
+		case items[i].has != items[j].has:
+			return items[i].has
+		case items[i].has:
+			c = compareAttributeValues(items[i].val, items[j].val)
+
+			if s.desc {
+				c = -c
+			}
+		}
+
+		if c == 0 {
+			c = compareIDs(items[i].id, items[j].id)
+		}
+
+		return c < 0
+	})
+
+	return sortedIDs(items)
+}
+
+func compareIDs(a, b *objectSDK.ID) int {
+	return bytes.Compare(idBytes(a), idBytes(b))
+}
@@ -0,0 +1,118 @@
+package searchsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cidtest "github.com/nspcc-dev/neofs-api-go/pkg/container/id/test"
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object/util"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLocalSorted(t *testing.T) {
+	ctx := context.Background()
+
+	const priorityKey = "Priority"
+
+	storage := newTestStorage()
+
+	// identifiers are ordered by their first byte
+	ids := make(map[byte]*objectSDK.ID)
+
+	for b, val := range map[byte]string{
+		1: "10",
+		2: "9",
+		3: "",
+		4: "10",
+		5: "abc",
+		6: "",
+		7: "2",
+	} {
+		var cs [32]byte
+		cs[0] = b
+
+		id := objectSDK.NewID()
+		id.SetSHA256(cs)
+
+		hdr := object.NewRaw()
+		hdr.SetID(id)
+
+		if val != "" {
+			a := objectSDK.NewAttribute()
+			a.SetKey(priorityKey)
+			a.SetValue(val)
+
+			hdr.SetAttributes(a)
+		}
+
+		ids[b] = storage.addHeaders(hdr)[0]
+	}
+
+	idList := func(bs ...byte) []*objectSDK.ID {
+		res := make([]*objectSDK.ID, len(bs))
+		for i := range bs {
+			res[i] = ids[bs[i]]
+		}
+
+		return res
+	}
+
+	cid := cidtest.Generate()
+	storage.addResult(cid, idList(5, 3, 1, 7, 6, 2, 4), nil)
+
+	svc := &Service{cfg: new(cfg)}
+	svc.log = test.NewLogger(false)
+	svc.localStorage = storage
+
+	newPrm := func(s Sort, localOnly bool) (Prm, *simpleIDWriter) {
+		w := new(simpleIDWriter)
+
+		p := Prm{}
+		p.WithContainerID(cid)
+		p.SetWriter(w)
+		p.SetSort(s)
+		p.common = new(util.CommonPrm).WithLocalOnly(localOnly)
+
+		return p, w
+	}
+
+	for _, tc := range []struct {
+		name     string
+		sort     Sort
+		expected []*objectSDK.ID
+	}{
+		{name: "unsorted", expected: idList(5, 3, 1, 7, 6, 2, 4)},
+		{name: "by ID", sort: SortByID(false), expected: idList(1, 2, 3, 4, 5, 6, 7)},
+		{name: "by ID descending", sort: SortByID(true), expected: idList(7, 6, 5, 4, 3, 2, 1)},
+		// numbers are compared as integers, missing attributes go last
+		{name: "by attribute", sort: SortByAttribute(priorityKey, false), expected: idList(7, 2, 1, 4, 5, 3, 6)},
+		// equal values and missing attributes are still ordered by ID
+		{name: "by attribute descending", sort: SortByAttribute(priorityKey, true), expected: idList(5, 1, 4, 2, 7, 3, 6)},
+		{name: "by missing attribute", sort: SortByAttribute("Other", true), expected: idList(1, 2, 3, 4, 5, 6, 7)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, w := newPrm(tc.sort, true)
+
+			require.NoError(t, svc.Search(ctx, p))
+			require.Equal(t, tc.expected, w.ids)
+		})
+	}
+
+	t.Run("with limit", func(t *testing.T) {
+		p, w := newPrm(SortByAttribute(priorityKey, false), true)
+		p.SetLimit(3, nil)
+
+		require.NoError(t, svc.Search(ctx, p))
+		require.Equal(t, idList(7, 2, 1), w.ids)
+	})
+
+	t.Run("non-local", func(t *testing.T) {
+		p, _ := newPrm(SortByID(false), false)
+
+		require.True(t, errors.Is(svc.Search(ctx, p), errHeaderModeNotLocal))
+	})
+}
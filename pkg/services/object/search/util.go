@@ -1,12 +1,16 @@
 package searchsvc
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
 
 	cid "github.com/nspcc-dev/neofs-api-go/pkg/container/id"
 	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
 	"github.com/nspcc-dev/neofs-node/pkg/core/client"
 	"github.com/nspcc-dev/neofs-node/pkg/core/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
 	"github.com/nspcc-dev/neofs-node/pkg/network"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object/util"
@@ -31,6 +35,9 @@ type clientWrapper struct {
 
 type storageEngineWrapper engine.StorageEngine
 
+// multiStorage is an ordered list of local storage partitions.
+type multiStorage []localStorage
+
 type traverseGeneratorWrapper util.TraverserGenerator
 
 type nmSrcWrapper struct {
@@ -101,6 +108,139 @@ func (e *storageEngineWrapper) search(exec *execCtx) ([]*objectSDK.ID, error) {
 	return idsFromAddresses(r.AddressList()), nil
 }
 
+func (e *storageEngineWrapper) head(addr *objectSDK.Address) (*object.Object, error) {
+	return engine.Head((*engine.StorageEngine)(e), addr)
+}
+
+func (s multiStorage) search(exec *execCtx) ([]*objectSDK.ID, error) {
+	results, err := s.searchPartitions(exec)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		res  []*objectSDK.ID
+		mIDs = make(map[string]struct{})
+	)
+
+	for i := range results {
+		for _, id := range results[i] {
+			key := id.String()
+
+			if _, ok := mIDs[key]; !ok {
+				mIDs[key] = struct{}{}
+				res = append(res, id)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// searchPartitions selects objects from each partition. Partitions are
+// searched by the configured number of workers (see WithConcurrency),
+// results are returned in the order of the partitions regardless of it.
+//
+// Workers stop taking the partitions on the first failure or when the
+// context of the operation is done.
+func (s multiStorage) searchPartitions(exec *execCtx) ([][]*objectSDK.ID, error) {
+	var (
+		workers int
+		results = make([][]*objectSDK.ID, len(s))
+		errs    = make([]error, len(s))
+	)
+
+	if exec.svc != nil {
+		workers = exec.svc.concurrency
+	}
+
+	if workers > len(s) {
+		workers = len(s)
+	}
+
+	if workers <= 1 {
+		for i := range s {
+			ids, err := s[i].search(exec)
+			if err != nil {
+				return nil, fmt.Errorf("could not search in partition #%d: %w", i, err)
+			}
+
+			results[i] = ids
+		}
+
+		return results, nil
+	}
+
+	ctx, cancel := context.WithCancel(exec.context())
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		next = make(chan int)
+	)
+
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			for i := range next {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				ids, err := s[i].search(exec)
+				if err != nil {
+					errs[i] = err
+					cancel()
+
+					continue
+				}
+
+				results[i] = ids
+			}
+		}()
+	}
+
+loop:
+	for i := range s {
+		select {
+		case <-ctx.Done():
+			break loop
+		case next <- i:
+		}
+	}
+
+	close(next)
+	wg.Wait()
+
+	for i := range errs {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("could not search in partition #%d: %w", i, errs[i])
+		}
+	}
+
+	if err := exec.context().Err(); err != nil {
+		return nil, fmt.Errorf("local search interrupted: %w", err)
+	}
+
+	return results, nil
+}
+
+// head returns header of the object from the first partition
+// that stores it.
+func (s multiStorage) head(addr *objectSDK.Address) (*object.Object, error) {
+	for i := range s {
+		hdr, err := s[i].head(addr)
+		if err == nil || !errors.Is(err, object.ErrNotFound) {
+			return hdr, err
+		}
+	}
+
+	return nil, object.ErrNotFound
+}
+
 func idsFromAddresses(addrs []*objectSDK.Address) []*objectSDK.ID {
 	ids := make([]*objectSDK.ID, len(addrs))
 
@@ -0,0 +1,31 @@
+package transformer
+
+import (
+	"encoding/hex"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// AttributeACLRef is a key of the object attribute which value is
+// a hex-encoded reference to the object-level access control rules
+// enforced by the storage and gateways (see WithACLRef).
+const AttributeACLRef = "__NEOFS__ACL_REF"
+
+// WithACLRef returns option to set AttributeACLRef attribute of each
+// generated object: payload parts, parent, linking and index objects.
+// Value of the attribute in the source header is overwritten.
+//
+// Attribute is not set if ref is empty.
+func WithACLRef(ref []byte) Option {
+	ref = append([]byte(nil), ref...)
+
+	return func(c *cfg) {
+		c.aclRef = ref
+	}
+}
+
+func (s *payloadSizeLimiter) setACLRef(obj *object.RawObject) {
+	if len(s.aclRef) > 0 {
+		replaceAttribute(obj, AttributeACLRef, hex.EncodeToString(s.aclRef))
+	}
+}
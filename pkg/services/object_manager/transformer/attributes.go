@@ -0,0 +1,119 @@
+package transformer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// AttributeChildrenChecksum is a key of the linking object attribute
+// which value is a hex-encoded SHA256 checksum of the ordered list of
+// the child object identifiers (see ChildrenChecksum).
+const AttributeChildrenChecksum = "__NEOFS__CHILDREN_SHA256"
+
+// AttributePartCount is a key of the parent object attribute which
+// value is a decimal number of the split-chain parts carrying the
+// payload (see WithPartCount).
+const AttributePartCount = "__NEOFS__PART_COUNT"
+
+// WithPartCount returns option to set AttributePartCount attribute of
+// the parent object. Linking object is not counted. Attribute is not
+// set if the payload fits into a single object, since there is no
+// parent object then.
+func WithPartCount() Option {
+	return func(c *cfg) {
+		c.withPartCount = true
+	}
+}
+
+// sysAttributePrefix is a key prefix of the NeoFS system attributes.
+const sysAttributePrefix = "__NEOFS__"
+
+// WithChildAttributes returns option to propagate the attributes of the
+// written header to the children of the split-chain. User attributes are
+// propagated if filter returns true for them, system attributes (with
+// "__NEOFS__" key prefix) are always propagated. Parent object carries
+// the full set of the attributes anyway.
+//
+// By default, attributes of the written header are set in the parent
+// object only.
+func WithChildAttributes(filter func(*objectSDK.Attribute) bool) Option {
+	return func(c *cfg) {
+		c.childAttrFilter = filter
+	}
+}
+
+// childAttributes returns the attributes of the parent object
+// which are propagated to the children.
+func (s *payloadSizeLimiter) childAttributes(attrs []*objectSDK.Attribute) []*objectSDK.Attribute {
+	if s.childAttrFilter == nil {
+		return nil
+	}
+
+	res := make([]*objectSDK.Attribute, 0, len(attrs))
+
+	for i := range attrs {
+		if strings.HasPrefix(attrs[i].Key(), sysAttributePrefix) || s.childAttrFilter(attrs[i]) {
+			res = append(res, attrs[i])
+		}
+	}
+
+	return res
+}
+
+// ChildrenChecksum returns SHA256 checksum of the concatenated
+// identifiers of the child objects in the order of the split-chain.
+//
+// Readers can compare it with the value of AttributeChildrenChecksum
+// to detect tampered or reordered list of the children.
+func ChildrenChecksum(ids []*objectSDK.ID) [sha256.Size]byte {
+	h := sha256.New()
+
+	for i := range ids {
+		h.Write(ids[i].ToV2().GetValue())
+	}
+
+	var cs [sha256.Size]byte
+
+	copy(cs[:], h.Sum(nil))
+
+	return cs
+}
+
+func childrenChecksumValue(ids []*objectSDK.ID) string {
+	cs := ChildrenChecksum(ids)
+
+	return hex.EncodeToString(cs[:])
+}
+
+// addAttribute appends attribute to the object header
+// keeping the attributes sorted.
+func addAttribute(obj *object.RawObject, key, val string) {
+	a := objectSDK.NewAttribute()
+	a.SetKey(key)
+	a.SetValue(val)
+
+	obj.SetAttributes(sortAttributes(append(obj.Attributes(), a))...)
+}
+
+// replaceAttribute sets attribute of the object header replacing the existing
+// one with the same key. Attribute is removed if value is empty.
+func replaceAttribute(obj *object.RawObject, key, val string) {
+	attrs := obj.Attributes()
+	res := make([]*objectSDK.Attribute, 0, len(attrs)+1)
+
+	for i := range attrs {
+		if attrs[i].Key() != key {
+			res = append(res, attrs[i])
+		}
+	}
+
+	obj.SetAttributes(res...)
+
+	if val != "" {
+		addAttribute(obj, key, val)
+	}
+}
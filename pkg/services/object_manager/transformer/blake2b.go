@@ -0,0 +1,66 @@
+package transformer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"golang.org/x/crypto/blake2b"
+)
+
+// AttributeBLAKE2bChecksum is a key of the object attribute which value
+// is a hex-encoded BLAKE2b-256 checksum of the object payload
+// (see BLAKE2bChecksumHasher).
+const AttributeBLAKE2bChecksum = "__NEOFS__PAYLOAD_BLAKE2B"
+
+// BLAKE2bChecksum represents BLAKE2b-256 checksum of the object payload.
+//
+// NeoFS API has no BLAKE2b checksum type, so the checksum is carried
+// in AttributeBLAKE2bChecksum attribute of the object header.
+type BLAKE2bChecksum [blake2b.Size256]byte
+
+// BLAKE2bChecksumHasher is a ChecksumHasherFactory of
+// the BLAKE2b-256 checksum of the object payload.
+//
+// Hasher can be used alongside SHA256ChecksumHasher or instead of it,
+// in the latter case the objects have no PayloadChecksum set.
+func BLAKE2bChecksumHasher(obj *object.RawObject) (hash.Hash, func([]byte)) {
+	h, err := blake2b.New256(nil)
+	if err != nil {
+		// never happens without the key
+		panic(fmt.Sprintf("could not create BLAKE2b hasher: %v", err))
+	}
+
+	return h, func(cs []byte) {
+		if ln := len(cs); ln != blake2b.Size256 {
+			panic(fmt.Sprintf("wrong checksum length: expected %d, has %d", blake2b.Size256, ln))
+		}
+
+		replaceAttribute(obj, AttributeBLAKE2bChecksum, hex.EncodeToString(cs))
+	}
+}
+
+// PayloadBLAKE2bChecksum returns BLAKE2b-256 checksum of the object payload
+// set by BLAKE2bChecksumHasher.
+//
+// Returns false if the checksum is missing or invalid.
+func PayloadBLAKE2bChecksum(obj *object.Object) (BLAKE2bChecksum, bool) {
+	var cs BLAKE2bChecksum
+
+	for _, a := range obj.Attributes() {
+		if a.Key() != AttributeBLAKE2bChecksum {
+			continue
+		}
+
+		if hex.DecodedLen(len(a.Value())) != len(cs) {
+			return cs, false
+		}
+
+		_, err := hex.Decode(cs[:], []byte(a.Value()))
+
+		return cs, err == nil
+	}
+
+	return cs, false
+}
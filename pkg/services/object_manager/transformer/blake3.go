@@ -0,0 +1,75 @@
+package transformer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/util/blake3"
+)
+
+// AttributeBLAKE3Checksum is a key of the object attribute which value
+// is a hex-encoded BLAKE3 checksum of the object payload
+// (see WithBlake3Checksum).
+const AttributeBLAKE3Checksum = "__NEOFS__PAYLOAD_BLAKE3"
+
+// BLAKE3Checksum represents BLAKE3 checksum of the object payload.
+//
+// NeoFS API has no BLAKE3 checksum type, so the checksum is carried
+// in AttributeBLAKE3Checksum attribute of the object header.
+type BLAKE3Checksum [blake3.Size]byte
+
+// WithBlake3Checksum returns option to calculate BLAKE3 checksum of the
+// payload in addition to the other checksums. Checksum is set both to the
+// generated objects and to the parent objects of the split-chains.
+//
+// Checkpoints (see WithCheckpoints, WithResume) are not supported
+// with BLAKE3 checksum enabled.
+func WithBlake3Checksum(enabled bool) Option {
+	return func(c *cfg) {
+		c.blake3Checksum = enabled
+	}
+}
+
+// BLAKE3ChecksumHasher is a ChecksumHasherFactory of
+// the BLAKE3 checksum of the object payload.
+func BLAKE3ChecksumHasher(obj *object.RawObject) (hash.Hash, func([]byte)) {
+	return blake3.New(), func(cs []byte) {
+		if ln := len(cs); ln != blake3.Size {
+			panic(fmt.Sprintf("wrong checksum length: expected %d, has %d", blake3.Size, ln))
+		}
+
+		replaceAttribute(obj, AttributeBLAKE3Checksum, hex.EncodeToString(cs))
+	}
+}
+
+// PayloadBLAKE3Checksum returns BLAKE3 checksum of the object payload
+// set by BLAKE3ChecksumHasher.
+//
+// Returns false if the checksum is missing or invalid.
+func PayloadBLAKE3Checksum(obj *object.Object) (BLAKE3Checksum, bool) {
+	var cs BLAKE3Checksum
+
+	for _, a := range obj.Attributes() {
+		if a.Key() != AttributeBLAKE3Checksum {
+			continue
+		}
+
+		if hex.DecodedLen(len(a.Value())) != len(cs) {
+			return cs, false
+		}
+
+		_, err := hex.Decode(cs[:], []byte(a.Value()))
+
+		return cs, err == nil
+	}
+
+	return cs, false
+}
+
+func withBLAKE3Hasher(hashers func(*object.RawObject) []*payloadChecksumHasher) func(*object.RawObject) []*payloadChecksumHasher {
+	return func(obj *object.RawObject) []*payloadChecksumHasher {
+		return append(hashers(obj), newPayloadHashers(obj, []ChecksumHasherFactory{BLAKE3ChecksumHasher})...)
+	}
+}
@@ -0,0 +1,188 @@
+package transformer
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"errors"
+	"fmt"
+	"hash"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/tzhash/tz"
+)
+
+// Checkpoint represents the progress of the split-chain writing
+// that is sufficient to resume it after the crash.
+//
+// Checkpoints are made on the boundaries of the released objects,
+// so the written bytes are always covered by the committed parts.
+type Checkpoint struct {
+	// Split ID of the split-chain.
+	SplitID *objectSDK.SplitID
+
+	// Number of the payload bytes stored in the committed parts.
+	Written uint64
+
+	// Identifiers of the committed parts in the order of writing.
+	Parts []*objectSDK.ID
+
+	// Payload sizes of the committed parts.
+	PartSizes []uint64
+
+	// Binary state of the SHA256 hasher of the parent payload.
+	PayloadHashState []byte
+
+	// Homomorphic hash of the written parent payload. Homomorphic hasher
+	// is not resumed from its state, the hash is concatenated with the hash
+	// of the rest of the payload instead.
+	//
+	// Empty if homomorphic hashing is disabled (see WithHomomorphicHashDisabled).
+	PayloadHomomorphicHash []byte
+}
+
+// CheckpointStore is an interface of the durable storage of checkpoints.
+type CheckpointStore interface {
+	// SaveCheckpoint persists the checkpoint. Only the last saved
+	// checkpoint is required to resume writing.
+	//
+	// Checkpoint must not be modified.
+	SaveCheckpoint(*Checkpoint) error
+}
+
+// concatHasher is a homomorphic hasher that prepends
+// the hash of the already written data to the result.
+type concatHasher struct {
+	hash.Hash
+
+	prefix []byte
+}
+
+var errInvalidCheckpoint = errors.New("invalid checkpoint")
+
+// WithCheckpoints returns option to save the progress of the split-chain
+// writing to the store. Checkpoint is saved after the object of the chain
+// is released if at least everyParts objects or everyBytes payload bytes
+// were released since the previous checkpoint. Since checkpoints are made
+// on the object boundaries only, byte cadence is rounded up to the object size.
+//
+// Checkpoint is saved after each released object if both values are not positive.
+func WithCheckpoints(store CheckpointStore, everyParts int, everyBytes uint64) Option {
+	return func(c *cfg) {
+		c.checkpoints = store
+		c.checkpointParts = everyParts
+		c.checkpointBytes = everyBytes
+	}
+}
+
+// WithResume returns option to resume writing from the checkpoint.
+//
+// Header passed to WriteHeader must be the same as in the interrupted
+// writing, and only the payload that follows Checkpoint.Written bytes
+// must be written.
+func WithResume(cp *Checkpoint) Option {
+	return func(c *cfg) {
+		c.resume = cp
+	}
+}
+
+func (s *payloadSizeLimiter) needCheckpoint() bool {
+	if s.checkpoints == nil {
+		return false
+	}
+
+	if s.checkpointParts <= 0 && s.checkpointBytes <= 0 {
+		return true
+	}
+
+	return s.checkpointParts > 0 && len(s.previous)-s.checkpointedParts >= s.checkpointParts ||
+		s.checkpointBytes > 0 && s.written-s.checkpointedBytes >= s.checkpointBytes
+}
+
+func (s *payloadSizeLimiter) checkpoint() error {
+	state, err := s.parentHashers[0].hasher.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("could not marshal hasher state: %w", err)
+	}
+
+	cp := &Checkpoint{
+		SplitID:          s.splitID,
+		Written:          s.written,
+		Parts:            append([]*objectSDK.ID(nil), s.previous...),
+		PartSizes:        append([]uint64(nil), s.partSizes...),
+		PayloadHashState: state,
+	}
+
+	if !s.noHomomorphicHash {
+		cp.PayloadHomomorphicHash = s.parentHashers[1].hasher.Sum(nil)
+	}
+
+	if err := s.checkpoints.SaveCheckpoint(cp); err != nil {
+		return err
+	}
+
+	s.checkpointedParts = len(s.previous)
+	s.checkpointedBytes = s.written
+
+	return nil
+}
+
+// restore initializes the state of the interrupted writing that is
+// equivalent to the state right after the last committed object has
+// been released.
+func (s *payloadSizeLimiter) restore(hdr *object.RawObject) error {
+	cp := s.resume
+
+	switch {
+	case len(cp.Parts) == 0 || len(cp.PartSizes) != len(cp.Parts):
+		return fmt.Errorf("%w: inconsistent parts", errInvalidCheckpoint)
+	case !s.noHomomorphicHash && len(cp.PayloadHomomorphicHash) != tzChecksumSize:
+		return fmt.Errorf("%w: wrong homomorphic hash length %d", errInvalidCheckpoint, len(cp.PayloadHomomorphicHash))
+	}
+
+	sha := sha256.New()
+
+	if err := sha.(encoding.BinaryUnmarshaler).UnmarshalBinary(cp.PayloadHashState); err != nil {
+		return fmt.Errorf("%w: could not unmarshal hasher state: %v", errInvalidCheckpoint, err)
+	}
+
+	s.splitID = cp.SplitID
+	s.previous = append([]*objectSDK.ID(nil), cp.Parts...)
+	s.partSizes = append([]uint64(nil), cp.PartSizes...)
+	s.written = cp.Written
+	s.released = cp.Written
+
+	s.parent = fromObject(hdr)
+	s.parent.ResetRelations()
+
+	s.parentHashers = s.payloadHashers(s.parent)
+	s.parentHashers[0].hasher = sha
+
+	if !s.noHomomorphicHash {
+		s.parentHashers[1].hasher = &concatHasher{
+			Hash:   tz.New(),
+			prefix: cp.PayloadHomomorphicHash,
+		}
+	}
+
+	s.current = fromObject(s.parent)
+	s.current.SetAttributes(s.childAttributes(s.parent.Attributes())...)
+	s.current.SetSplitID(s.splitID)
+	s.current.SetPreviousID(s.previous[len(s.previous)-1])
+
+	s.initializeCurrent()
+
+	s.checkpointedParts = len(s.previous)
+	s.checkpointedBytes = s.written
+
+	return nil
+}
+
+func (h *concatHasher) Sum(b []byte) []byte {
+	sum, err := tz.Concat([][]byte{h.prefix, h.Hash.Sum(nil)})
+	if err != nil {
+		panic(fmt.Sprintf("could not concatenate homomorphic hashes: %v", err))
+	}
+
+	return append(b, sum...)
+}
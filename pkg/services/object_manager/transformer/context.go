@@ -0,0 +1,42 @@
+package transformer
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithContext returns option to interrupt writing when the context is done.
+// After that WriteHeader, Write and Close fail with the error wrapping the
+// context error (context.Canceled or context.DeadlineExceeded), so it can be
+// distinguished from the target failures by errors.Is.
+//
+// Context is checked before each call and before each object of the
+// split-chain. On interruption, hashers and the target of the unfinished
+// object are dropped without closing, already released objects are kept.
+func WithContext(ctx context.Context) Option {
+	return func(c *cfg) {
+		c.ctx = ctx
+	}
+}
+
+// ctxErr returns an error if the context of the writing is done.
+func (s *payloadSizeLimiter) ctxErr() error {
+	if s.ctx == nil {
+		return nil
+	}
+
+	err := s.ctx.Err()
+	if err == nil {
+		return nil
+	}
+
+	// free the resources of the unfinished object
+	s.stopHashing()
+	s.target = nil
+	s.chunkWriter = nil
+	s.currentHashers = nil
+	s.parentHashers = nil
+	s.partPayload = nil
+
+	return fmt.Errorf("writing interrupted: %w", err)
+}
@@ -0,0 +1,61 @@
+package transformer
+
+import (
+	"errors"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+)
+
+// FixedIDTarget is an ObjectTarget that is able to store the object
+// under the identifier provided by the caller.
+type FixedIDTarget interface {
+	ObjectTarget
+
+	// SetFixedID sets identifier of the written object which
+	// is used instead of the derived one. Must be called
+	// before Close.
+	SetFixedID(*objectSDK.ID)
+}
+
+// ErrFixedIDSplit is returned when the payload of the object with
+// the fixed identifier exceeds the max object size.
+var ErrFixedIDSplit = errors.New("object with fixed ID can not be split")
+
+var errFixedIDNotSupported = errors.New("target does not support fixed object ID")
+
+// WithFixedID returns option to store the object under the specified
+// identifier (e.g. when importing objects from another system) instead
+// of the one derived from the object header. Targets must implement
+// FixedIDTarget, otherwise WriteHeader fails.
+//
+// Fixed identifier is supported for the objects which are not split:
+// the write which exceeds max object size fails with ErrFixedIDSplit.
+//
+// Note that fixed identifier is not bound to the object content, so
+// integrity of the object can not be verified by its identifier.
+func WithFixedID(id *objectSDK.ID) Option {
+	return func(c *cfg) {
+		c.fixedID = id
+	}
+}
+
+// setFixedID passes fixed identifier to the current target.
+func (s *payloadSizeLimiter) setFixedID() error {
+	if s.fixedID == nil {
+		return nil
+	}
+
+	t, ok := s.target.(FixedIDTarget)
+	if !ok {
+		return errFixedIDNotSupported
+	}
+
+	t.SetFixedID(s.fixedID)
+
+	return nil
+}
+
+// SetFixedID implements FixedIDTarget.
+func (f *formatter) SetFixedID(id *objectSDK.ID) {
+	f.fixedID = id
+}
@@ -17,6 +17,8 @@ type formatter struct {
 	obj *object.RawObject
 
 	sz uint64
+
+	fixedID *objectSDK.ID
 }
 
 // FormatterParams groups NewFormatTarget parameters.
@@ -28,6 +30,23 @@ type FormatterParams struct {
 	SessionToken *session.Token
 
 	NetworkState netmap.State
+
+	// Optional calculator of the object identifiers.
+	// If nil, identifier is calculated by NeoFS SDK rules.
+	IDDeriver IDDeriver
+}
+
+// IDDeriver is an interface of the object identifier calculator.
+//
+// It allows to substitute the identifiers calculated by NeoFS SDK
+// rules, e.g. with deterministic values in tests. Note that objects
+// with the identifiers not derived by SDK rules do not pass the default
+// verification of the header.
+type IDDeriver interface {
+	// DeriveID must return identifier of the finalized object header.
+	//
+	// Object signature is calculated over the returned identifier.
+	DeriveID(*objectSDK.RawObject) (*objectSDK.ID, error)
 }
 
 // NewFormatTarget returns ObjectTarget instance that finalizes object structure
@@ -41,6 +60,8 @@ type FormatterParams struct {
 // - sets session token;
 // - sets number of creation epoch;
 // - calculates and sets verification fields (ID, Signature).
+//
+// Returned target implements FixedIDTarget.
 func NewFormatTarget(p *FormatterParams) ObjectTarget {
 	return &formatter{
 		prm: p,
@@ -80,7 +101,7 @@ func (f *formatter) Close() (*AccessIdentifiers, error) {
 		rawPar.SetSessionToken(f.prm.SessionToken)
 		rawPar.SetCreationEpoch(curEpoch)
 
-		if err := objectSDK.SetIDWithSignature(f.prm.Key, rawPar); err != nil {
+		if err := f.setIDWithSignature(rawPar); err != nil {
 			return nil, fmt.Errorf("could not finalize parent object: %w", err)
 		}
 
@@ -90,7 +111,13 @@ func (f *formatter) Close() (*AccessIdentifiers, error) {
 		f.obj.SetParent(parHdr)
 	}
 
-	if err := objectSDK.SetIDWithSignature(f.prm.Key, f.obj.SDK()); err != nil {
+	if f.fixedID != nil {
+		f.obj.SetID(f.fixedID)
+
+		if err := objectSDK.CalculateAndSetSignature(f.prm.Key, f.obj.SDK()); err != nil {
+			return nil, fmt.Errorf("could not sign object with fixed ID: %w", err)
+		}
+	} else if err := f.setIDWithSignature(f.obj.SDK()); err != nil {
 		return nil, fmt.Errorf("could not finalize object: %w", err)
 	}
 
@@ -107,3 +134,18 @@ func (f *formatter) Close() (*AccessIdentifiers, error) {
 		WithParentID(parID).
 		WithParent(parHdr), nil
 }
+
+func (f *formatter) setIDWithSignature(obj *objectSDK.RawObject) error {
+	if f.prm.IDDeriver == nil {
+		return objectSDK.SetIDWithSignature(f.prm.Key, obj)
+	}
+
+	id, err := f.prm.IDDeriver.DeriveID(obj)
+	if err != nil {
+		return fmt.Errorf("could not derive object ID: %w", err)
+	}
+
+	obj.SetID(id)
+
+	return objectSDK.CalculateAndSetSignature(f.prm.Key, obj)
+}
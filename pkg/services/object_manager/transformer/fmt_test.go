@@ -0,0 +1,133 @@
+package transformer
+
+import (
+	"errors"
+	"testing"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+type testNetState uint64
+
+func (s testNetState) CurrentEpoch() uint64 {
+	return uint64(s)
+}
+
+// headerTarget saves the header written by the formatter.
+type headerTarget struct {
+	hdr *object.RawObject
+}
+
+func (t *headerTarget) WriteHeader(hdr *object.RawObject) error {
+	t.hdr = hdr
+	return nil
+}
+
+func (t *headerTarget) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (t *headerTarget) Close() (*AccessIdentifiers, error) {
+	return new(AccessIdentifiers).WithSelfID(t.hdr.ID()), nil
+}
+
+type fixedIDDeriver struct {
+	id  *objectSDK.ID
+	err error
+}
+
+func (d fixedIDDeriver) DeriveID(*objectSDK.RawObject) (*objectSDK.ID, error) {
+	return d.id, d.err
+}
+
+func newTestFormatter(next ObjectTarget, deriver IDDeriver) ObjectTarget {
+	return NewFormatTarget(&FormatterParams{
+		Key:          test.DecodeKey(-1),
+		NextTarget:   next,
+		NetworkState: testNetState(10),
+		IDDeriver:    deriver,
+	})
+}
+
+func TestFormatter_IDDeriver(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		next := new(headerTarget)
+
+		ids := writeObject(t, newTestFormatter(next, nil), testHeader(), testPayload(t, 10))
+
+		require.NoError(t, objectSDK.CheckHeaderVerificationFields(next.hdr.SDK().Object()))
+		require.Equal(t, next.hdr.ID(), ids.SelfID())
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		id := objectSDK.NewID()
+		id.SetSHA256(testSHA256(t))
+
+		next := new(headerTarget)
+
+		ids := writeObject(t, newTestFormatter(next, fixedIDDeriver{id: id}), testHeader(), testPayload(t, 10))
+
+		require.Equal(t, id, ids.SelfID())
+		require.Equal(t, id, next.hdr.ID())
+		require.NotNil(t, next.hdr.Signature())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		testErr := errors.New("test error")
+
+		target := newTestFormatter(new(headerTarget), fixedIDDeriver{err: testErr})
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Close()
+		require.True(t, errors.Is(err, testErr))
+	})
+}
+
+func TestPayloadSizeLimiter_FixedID(t *testing.T) {
+	const maxSize = 64
+
+	id := objectSDK.NewID()
+	id.SetSHA256(testSHA256(t))
+
+	t.Run("single object", func(t *testing.T) {
+		next := new(headerTarget)
+
+		target := NewPayloadSizeLimiter(maxSize, func() ObjectTarget {
+			return newTestFormatter(next, nil)
+		}, WithFixedID(id))
+
+		ids := writeObject(t, target, testHeader(), testPayload(t, maxSize))
+
+		require.Equal(t, id, ids.SelfID())
+		require.Equal(t, id, next.hdr.ID())
+		require.NotNil(t, next.hdr.Signature())
+
+		// identifier is not bound to the content
+		require.Error(t, objectSDK.CheckHeaderVerificationFields(next.hdr.SDK().Object()))
+	})
+
+	t.Run("split", func(t *testing.T) {
+		next := new(headerTarget)
+
+		target := NewPayloadSizeLimiter(maxSize, func() ObjectTarget {
+			return newTestFormatter(next, nil)
+		}, WithFixedID(id))
+
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(testPayload(t, maxSize+1))
+		require.True(t, errors.Is(err, ErrFixedIDSplit))
+		require.Nil(t, next.hdr)
+	})
+
+	t.Run("unsupported target", func(t *testing.T) {
+		s := new(memStorage)
+
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithFixedID(id))
+
+		require.True(t, errors.Is(target.WriteHeader(testHeader()), errFixedIDNotSupported))
+	})
+}
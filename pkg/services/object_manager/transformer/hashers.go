@@ -0,0 +1,109 @@
+package transformer
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/tzhash/tz"
+)
+
+// ChecksumHasherFactory returns the hasher of the object payload and
+// the function that sets the resulting checksum in the object header.
+type ChecksumHasherFactory func(obj *object.RawObject) (h hash.Hash, checksumWriter func(checksum []byte))
+
+var defaultChecksumHashers = []ChecksumHasherFactory{
+	SHA256ChecksumHasher,
+	TZChecksumHasher,
+}
+
+var errCheckpointsWithCustomHashers = errors.New("checkpoints are not supported with custom checksum hashers")
+
+// NewPayloadSizeLimiterWithHashers returns ObjectTarget instance that works
+// like the one returned by NewPayloadSizeLimiter, but calculates the payload
+// checksums of the generated objects with the specified hashers. The same
+// hashers are used for the parent objects of the split-chains.
+//
+// Default hashers are SHA256ChecksumHasher and TZChecksumHasher.
+// Checkpoints (see WithCheckpoints, WithResume) are not supported
+// with the custom hashers.
+func NewPayloadSizeLimiterWithHashers(maxSize uint64, targetInit TargetInitializer, hashers []ChecksumHasherFactory, opts ...Option) ObjectTarget {
+	return NewPayloadSizeLimiter(maxSize, targetInit, append([]Option{withChecksumHashers(hashers)}, opts...)...)
+}
+
+// WithHomomorphicHashDisabled returns option to skip the calculation of the
+// homomorphic payload hash: neither generated objects nor parent objects
+// of the split-chains have PayloadHomomorphicHash set. Only SHA256 payload
+// checksum is calculated.
+//
+// Option is ignored if the hashers are set by NewPayloadSizeLimiterWithHashers.
+func WithHomomorphicHashDisabled() Option {
+	return func(c *cfg) {
+		c.noHomomorphicHash = true
+	}
+}
+
+func withChecksumHashers(hashers []ChecksumHasherFactory) Option {
+	hashers = append([]ChecksumHasherFactory(nil), hashers...)
+
+	return func(c *cfg) {
+		c.payloadHashers = func(obj *object.RawObject) []*payloadChecksumHasher {
+			return newPayloadHashers(obj, hashers)
+		}
+		c.customHashers = true
+	}
+}
+
+func newPayloadHashers(obj *object.RawObject, hashers []ChecksumHasherFactory) []*payloadChecksumHasher {
+	res := make([]*payloadChecksumHasher, len(hashers))
+
+	for i := range hashers {
+		h, w := hashers[i](obj)
+
+		res[i] = &payloadChecksumHasher{
+			hasher:         h,
+			checksumWriter: w,
+		}
+	}
+
+	return res
+}
+
+// SHA256ChecksumHasher is a ChecksumHasherFactory of
+// the SHA256 checksum of the object payload.
+func SHA256ChecksumHasher(obj *object.RawObject) (hash.Hash, func([]byte)) {
+	return sha256.New(), func(cs []byte) {
+		if ln := len(cs); ln != sha256.Size {
+			panic(fmt.Sprintf("wrong checksum length: expected %d, has %d", ln, sha256.Size))
+		}
+
+		csSHA := [sha256.Size]byte{}
+		copy(csSHA[:], cs)
+
+		checksum := pkg.NewChecksum()
+		checksum.SetSHA256(csSHA)
+
+		obj.SetPayloadChecksum(checksum)
+	}
+}
+
+// TZChecksumHasher is a ChecksumHasherFactory of
+// the Tillich-Zémor homomorphic hash of the object payload.
+func TZChecksumHasher(obj *object.RawObject) (hash.Hash, func([]byte)) {
+	return tz.New(), func(cs []byte) {
+		if ln := len(cs); ln != tzChecksumSize {
+			panic(fmt.Sprintf("wrong checksum length: expected %d, has %d", ln, tzChecksumSize))
+		}
+
+		csTZ := [tzChecksumSize]byte{}
+		copy(csTZ[:], cs)
+
+		checksum := pkg.NewChecksum()
+		checksum.SetTillichZemor(csTZ)
+
+		obj.SetPayloadHomomorphicHash(checksum)
+	}
+}
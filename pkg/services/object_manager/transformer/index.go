@@ -0,0 +1,143 @@
+package transformer
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// AttributeIndex is a key of the index object attribute
+// (see WithIndex). Value is the identifier of the parent object.
+const AttributeIndex = "__NEOFS__INDEX"
+
+// IndexEntry describes single part of the split-chain
+// in the payload of the index object.
+type IndexEntry struct {
+	// Identifier of the part.
+	ID *objectSDK.ID
+
+	// Offset of the part payload in the parent payload.
+	Offset uint64
+
+	// Size of the part payload.
+	Size uint64
+}
+
+// size of the binary IndexEntry: ID, offset and size
+const indexEntrySize = sha256.Size + 8 + 8
+
+var errInvalidIndex = errors.New("invalid index payload")
+
+// WithIndex returns option to write an index object on Close.
+//
+// Index object is written after the linking object. It is not a part of
+// the split-chain: it has neither parent header nor split ID, so the
+// storage does not take it for the part of the parent object. Index
+// object refers to the parent by AttributeIndex, and contains the list
+// of the parts with their offsets and payload sizes (see DecodeIndex).
+// Its identifier is returned from Close as AccessIdentifiers.IndexID.
+//
+// Index object is not written if the payload fits into a single object.
+func WithIndex() Option {
+	return func(c *cfg) {
+		c.withIndex = true
+	}
+}
+
+// EncodeIndex returns binary representation of the index entries.
+func EncodeIndex(entries []IndexEntry) []byte {
+	data := make([]byte, 0, len(entries)*indexEntrySize)
+
+	for i := range entries {
+		data = append(data, entries[i].ID.ToV2().GetValue()...)
+		data = appendUint64(data, entries[i].Offset)
+		data = appendUint64(data, entries[i].Size)
+	}
+
+	return data
+}
+
+// DecodeIndex parses the payload of the index object.
+func DecodeIndex(data []byte) ([]IndexEntry, error) {
+	if len(data)%indexEntrySize != 0 {
+		return nil, fmt.Errorf("%w: wrong length %d", errInvalidIndex, len(data))
+	}
+
+	entries := make([]IndexEntry, 0, len(data)/indexEntrySize)
+
+	for ; len(data) > 0; data = data[indexEntrySize:] {
+		var cs [sha256.Size]byte
+
+		copy(cs[:], data)
+
+		id := objectSDK.NewID()
+		id.SetSHA256(cs)
+
+		entries = append(entries, IndexEntry{
+			ID:     id,
+			Offset: binary.BigEndian.Uint64(data[sha256.Size:]),
+			Size:   binary.BigEndian.Uint64(data[sha256.Size+8:]),
+		})
+	}
+
+	return entries, nil
+}
+
+func appendUint64(data []byte, v uint64) []byte {
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], v)
+
+	return append(data, buf[:]...)
+}
+
+// indexEntries returns index entries of the released parts.
+func (s *payloadSizeLimiter) indexEntries() []IndexEntry {
+	entries := make([]IndexEntry, len(s.partSizes))
+
+	var off uint64
+
+	for i := range s.partSizes {
+		entries[i] = IndexEntry{
+			ID:     s.previous[i],
+			Offset: off,
+			Size:   s.partSizes[i],
+		}
+
+		off += s.partSizes[i]
+	}
+
+	return entries
+}
+
+func (s *payloadSizeLimiter) releaseIndex(parID *objectSDK.ID, entries []IndexEntry) (*objectSDK.ID, error) {
+	s.current = object.NewRaw()
+	s.current.SetContainerID(s.parent.ContainerID())
+	s.current.SetOwnerID(s.parent.OwnerID())
+	s.current.SetType(objectSDK.TypeRegular)
+
+	addAttribute(s.current, AttributeIndex, parID.String())
+
+	if s.tiered() {
+		setStorageTier(s.current, s.tier)
+	}
+
+	s.parentHashers = nil
+
+	s.initializeCurrent()
+
+	if err := s.writeToTarget(EncodeIndex(entries)); err != nil {
+		return nil, fmt.Errorf("could not write index payload: %w", err)
+	}
+
+	ids, err := s.release(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids.SelfID(), nil
+}
@@ -0,0 +1,83 @@
+package transformer
+
+import (
+	"errors"
+	"fmt"
+
+	cid "github.com/nspcc-dev/neofs-api-go/pkg/container/id"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// MaxSizeSource is an interface of the source of the max object size limits.
+type MaxSizeSource interface {
+	// MaxObjectSize must return max payload size of the objects
+	// of the container. Limit must be positive.
+	MaxObjectSize(*cid.ID) (uint64, error)
+}
+
+// ErrMaxSizeChanged is returned when the max object size reported
+// by the MaxSizeSource changes during the writing.
+var ErrMaxSizeChanged = errors.New("max object size changed during writing")
+
+// WithMaxSizeSource returns option to resolve the max object size of the
+// container of the written object in WriteHeader. Resolved value overrides
+// the size passed to the constructor and is used until the Close.
+//
+// The source is consulted again before each next object of the split-chain
+// and on Close. If the limit changes mid-stream, the call fails with
+// ErrMaxSizeChanged, so objects of the same split-chain are never cut
+// at the inconsistent boundaries. Already released objects are kept.
+func WithMaxSizeSource(src MaxSizeSource) Option {
+	return func(c *cfg) {
+		c.maxSizeSource = src
+	}
+}
+
+// resolveMaxSize sets the max object size of the container of the object.
+func (s *payloadSizeLimiter) resolveMaxSize(hdr *object.RawObject) error {
+	if s.maxSizeSource == nil {
+		return nil
+	}
+
+	maxSize, err := s.maxObjectSize(hdr.ContainerID())
+	if err != nil {
+		return err
+	}
+
+	s.maxSize = maxSize
+	s.maxSizeContainer = hdr.ContainerID()
+
+	return nil
+}
+
+// checkMaxSize returns ErrMaxSizeChanged if the max object size
+// differs from the resolved one.
+func (s *payloadSizeLimiter) checkMaxSize() error {
+	if s.maxSizeSource == nil {
+		return nil
+	}
+
+	maxSize, err := s.maxObjectSize(s.maxSizeContainer)
+	if err != nil {
+		return err
+	}
+
+	if maxSize != s.maxSize {
+		return fmt.Errorf("%w: %d -> %d", ErrMaxSizeChanged, s.maxSize, maxSize)
+	}
+
+	return nil
+}
+
+func (s *payloadSizeLimiter) maxObjectSize(id *cid.ID) (uint64, error) {
+	maxSize, err := s.maxSizeSource.MaxObjectSize(id)
+	if err != nil {
+		return 0, fmt.Errorf("could not get max object size: %w", err)
+	}
+
+	if maxSize == 0 {
+		return 0, errors.New("zero max object size")
+	}
+
+	return maxSize, nil
+}
@@ -0,0 +1,137 @@
+package transformer
+
+import (
+	"io"
+	"sync"
+)
+
+// parallelHashWriter writes chunks to the target in the calling goroutine
+// and concurrently passes the same chunks to the payload hashers. Each
+// worker goroutine lives until the object is released and feeds its own
+// subset of the hashers.
+type parallelHashWriter struct {
+	target io.Writer
+
+	// chunks to hash by the workers, one channel per worker
+	chunks []chan []byte
+
+	// chunks being hashed
+	hashing sync.WaitGroup
+
+	// running workers
+	workers sync.WaitGroup
+}
+
+// WithParallelHashing returns option to calculate payload checksums
+// concurrently with the target write using no more than workers
+// goroutines. Workers are started for each object of the split-chain
+// and stopped before its checksums are written. Chunk is written to the
+// target in the calling goroutine and Write returns after all hashers
+// have processed the chunk, so the backpressure of the target is
+// preserved.
+//
+// Non-positive value means hashing in the calling goroutine.
+func WithParallelHashing(workers int) Option {
+	return func(c *cfg) {
+		c.hashWorkers = workers
+	}
+}
+
+// parallelHashing wraps target writer and payload hashers into the writer
+// which hashes the chunks concurrently. Workers are started right away and
+// must be stopped by stopHashing.
+func (s *payloadSizeLimiter) parallelHashing(target io.Writer, hashers []io.Writer) *parallelHashWriter {
+	workers := s.hashWorkers
+	if workers > len(hashers) {
+		workers = len(hashers)
+	}
+
+	w := &parallelHashWriter{
+		target: target,
+		chunks: make([]chan []byte, workers),
+	}
+
+	w.workers.Add(workers)
+
+	for i := range w.chunks {
+		var own []io.Writer
+
+		for j := i; j < len(hashers); j += workers {
+			own = append(own, hashers[j])
+		}
+
+		w.chunks[i] = make(chan []byte, 1)
+
+		go w.hash(w.chunks[i], own)
+	}
+
+	return w
+}
+
+func (w *parallelHashWriter) hash(chunks <-chan []byte, hashers []io.Writer) {
+	defer w.workers.Done()
+
+	for p := range chunks {
+		for i := range hashers {
+			// hashers never return an error
+			_, _ = hashers[i].Write(p)
+		}
+
+		w.hashing.Done()
+	}
+}
+
+func (w *parallelHashWriter) Write(p []byte) (int, error) {
+	w.hashing.Add(len(w.chunks))
+
+	for i := range w.chunks {
+		w.chunks[i] <- p
+	}
+
+	n, err := w.target.Write(p)
+	if err == nil && n != len(p) {
+		err = io.ErrShortWrite
+	}
+
+	// caller may reuse p after the return
+	w.hashing.Wait()
+
+	return n, err
+}
+
+// stop waits for the workers to finish. Writer must not be used after.
+func (w *parallelHashWriter) stop() {
+	for i := range w.chunks {
+		close(w.chunks[i])
+	}
+
+	w.workers.Wait()
+}
+
+// stopHashing waits for the hashing workers of the current object to
+// process the written chunks and stops them, so the checksums can be
+// written.
+func (s *payloadSizeLimiter) stopHashing() {
+	if s.hashWriter == nil {
+		return
+	}
+
+	s.hashWriter.stop()
+	s.hashWriter = nil
+}
+
+// abandonHashing stops the hashing workers of the abandoned target
+// after the stalled write is finished.
+func (s *payloadSizeLimiter) abandonHashing(written <-chan error) {
+	if s.hashWriter == nil {
+		return
+	}
+
+	w := s.hashWriter
+	s.hashWriter = nil
+
+	go func() {
+		<-written
+		w.stop()
+	}()
+}
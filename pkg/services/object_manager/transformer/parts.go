@@ -0,0 +1,78 @@
+package transformer
+
+import (
+	"bytes"
+	"fmt"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// Part groups information about the finalized object of the split-chain.
+type Part struct {
+	// Identifier of the object.
+	ID *objectSDK.ID
+
+	// Header of the object w/o payload.
+	Header *object.RawObject
+
+	// Payload of the object.
+	Payload []byte
+}
+
+// WithPartsChannel returns option to send each finalized object to ch
+// right after its target is closed. Objects are sent in the order of the
+// split-chain: payload parts, then linking object (and index object, see
+// WithIndex). Object that fits into a single object is sent as is.
+//
+// Sending blocks until the object is received, so the slow consumer
+// slows down the writing. If the context is set (see WithContext),
+// sending is interrupted when the context is done. Channel is not
+// closed by the transformer: all objects are sent once Close returns.
+//
+// Note that each object payload is buffered in memory until it is sent.
+func WithPartsChannel(ch chan<- Part) Option {
+	return func(c *cfg) {
+		c.parts = ch
+	}
+}
+
+func (s *payloadSizeLimiter) sendPart(id *objectSDK.ID) error {
+	data, err := s.current.CutPayload().Marshal()
+	if err != nil {
+		return fmt.Errorf("could not marshal header: %w", err)
+	}
+
+	hdr := object.NewRaw()
+	if err := hdr.Unmarshal(data); err != nil {
+		return fmt.Errorf("could not unmarshal header: %w", err)
+	}
+
+	part := Part{
+		ID:      id,
+		Header:  hdr,
+		Payload: append([]byte{}, s.partPayload.Bytes()...),
+	}
+
+	if s.ctx == nil {
+		s.parts <- part
+		return nil
+	}
+
+	select {
+	case s.parts <- part:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctxErr()
+	}
+}
+
+func (s *payloadSizeLimiter) partPayloadWriter() *bytes.Buffer {
+	if s.partPayload == nil {
+		s.partPayload = new(bytes.Buffer)
+	}
+
+	s.partPayload.Reset()
+
+	return s.partPayload
+}
@@ -0,0 +1,26 @@
+package transformer
+
+// PredictSplit returns the number of the payload-bearing objects the payload
+// of the specified size is split into by the target from NewPayloadSizeLimiter
+// with the specified max size, and whether the linking object is released.
+//
+// Objects are released lazily, so payload which size is divisible by max size
+// is not followed by the empty object. Payload which size does not exceed max
+// size (including empty payload) is released as a single object without
+// the linking one.
+//
+// Prediction does not account for the options changing the split boundaries
+// (e.g. WithRecordSplit, WithMaxSizeSource) and for the index object (see
+// WithIndex). Max size must be positive.
+func PredictSplit(payloadSize, maxSize uint64) (childCount int, hasLinking bool) {
+	if payloadSize <= maxSize {
+		return 1, false
+	}
+
+	childCount = int(payloadSize / maxSize)
+	if payloadSize%maxSize != 0 {
+		childCount++
+	}
+
+	return childCount, true
+}
@@ -0,0 +1,23 @@
+package transformer
+
+// ProgressCallback is a callback of the writing progress. It receives
+// the number of the payload bytes written so far and the number of
+// the objects released to the targets.
+type ProgressCallback func(writtenBytes uint64, objectsReleased int)
+
+// WithProgressCallback returns option to report the writing progress.
+//
+// Callback is called once after each released object (including the
+// linking and index objects) and after each Write. Callback is called
+// synchronously, so it should not block.
+func WithProgressCallback(f ProgressCallback) Option {
+	return func(c *cfg) {
+		c.progress = f
+	}
+}
+
+func (s *payloadSizeLimiter) reportProgress() {
+	if s.progress != nil {
+		s.progress(s.written, len(s.previous))
+	}
+}
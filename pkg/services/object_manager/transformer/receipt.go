@@ -0,0 +1,120 @@
+package transformer
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg"
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+)
+
+// Receipt attests the completed upload of the object.
+type Receipt struct {
+	// Identifier of the uploaded object: parent object
+	// of the split-chain or the object itself.
+	ObjectID *objectSDK.ID
+
+	// Size of the object payload.
+	PayloadSize uint64
+
+	// SHA256 checksum of the object payload.
+	PayloadChecksum [sha256.Size]byte
+
+	// Signature of the receipt data (see Data).
+	Signature []byte
+}
+
+// size of the receipt data: object ID, payload size and payload checksum
+const receiptDataSize = sha256.Size + 8 + sha256.Size
+
+var errInvalidReceipt = errors.New("invalid receipt")
+
+// WithReceiptSigner returns option to sign the receipt of the upload
+// on Close. Receipt (see Receipt, DecodeReceipt) is returned from Close
+// as AccessIdentifiers.Receipt. Close fails if the receipt can not be
+// signed, or if SHA256 payload checksum is not calculated (e.g. custom
+// checksum hashers are used).
+func WithReceiptSigner(sign func(data []byte) (signature []byte, err error)) Option {
+	return func(c *cfg) {
+		c.receiptSigner = sign
+	}
+}
+
+// Data returns the canonical form of the signed receipt fields:
+// 32-byte object ID, payload size as 8-byte big-endian integer
+// and 32-byte payload SHA256 checksum.
+func (r *Receipt) Data() []byte {
+	data := make([]byte, 0, receiptDataSize)
+
+	data = append(data, r.ObjectID.ToV2().GetValue()...)
+	data = appendUint64(data, r.PayloadSize)
+	data = append(data, r.PayloadChecksum[:]...)
+
+	return data
+}
+
+// Encode returns binary representation of the receipt:
+// the canonical data followed by the signature.
+func (r *Receipt) Encode() []byte {
+	return append(r.Data(), r.Signature...)
+}
+
+// DecodeReceipt parses the binary receipt returned from Close.
+//
+// Signature must be verified by the caller against Receipt.Data.
+func DecodeReceipt(data []byte) (*Receipt, error) {
+	if len(data) < receiptDataSize {
+		return nil, fmt.Errorf("%w: wrong length %d", errInvalidReceipt, len(data))
+	}
+
+	var (
+		id [sha256.Size]byte
+		r  = new(Receipt)
+	)
+
+	copy(id[:], data)
+
+	r.ObjectID = objectSDK.NewID()
+	r.ObjectID.SetSHA256(id)
+
+	data = data[sha256.Size:]
+	r.PayloadSize = binary.BigEndian.Uint64(data)
+
+	data = data[8:]
+	copy(r.PayloadChecksum[:], data)
+
+	r.Signature = append([]byte(nil), data[sha256.Size:]...)
+
+	return r, nil
+}
+
+func (s *payloadSizeLimiter) signReceipt(ids *AccessIdentifiers) (*AccessIdentifiers, error) {
+	var (
+		r  = &Receipt{PayloadSize: s.written}
+		cs *pkg.Checksum
+	)
+
+	if r.ObjectID = ids.ParentID(); r.ObjectID != nil {
+		cs = s.parent.PayloadChecksum()
+	} else {
+		r.ObjectID = ids.SelfID()
+		cs = s.current.PayloadChecksum()
+	}
+
+	if cs == nil || cs.Type() != pkg.ChecksumSHA256 {
+		return nil, errors.New("could not sign receipt: missing SHA256 payload checksum")
+	}
+
+	copy(r.PayloadChecksum[:], cs.Sum())
+
+	sig, err := s.receiptSigner(r.Data())
+	if err != nil {
+		return nil, fmt.Errorf("could not sign receipt: %w", err)
+	}
+
+	r.Signature = sig
+
+	return ids.WithReceipt(r.Encode()), nil
+}
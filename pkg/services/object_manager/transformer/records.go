@@ -0,0 +1,40 @@
+package transformer
+
+// RecordTarget is an ObjectTarget which payload consists
+// of the logical records.
+type RecordTarget interface {
+	ObjectTarget
+
+	// RecordBoundary marks the end of the record
+	// written by the preceding Write calls.
+	RecordBoundary()
+}
+
+// WithRecordSplit returns option to split the payload every k records
+// instead of every max object size bytes. Record boundaries are reported
+// through the RecordTarget interface which is implemented by the target
+// returned from NewPayloadSizeLimiter. Object is released once k boundaries
+// have been reported or the max size is reached, whichever comes first,
+// so record may be split between objects if it does not fit the max size.
+// In the latter case, counting starts over from the next object.
+//
+// Non-positive value means splitting by size only.
+func WithRecordSplit(k int) Option {
+	return func(c *cfg) {
+		c.recordsPerPart = k
+	}
+}
+
+// RecordBoundary implements RecordTarget. Object is released lazily on the
+// next Write, so the last record does not produce an empty object on Close.
+func (s *payloadSizeLimiter) RecordBoundary() {
+	if s.recordsPerPart > 0 {
+		s.partRecords++
+	}
+}
+
+// recordsCut returns true if the current object contains
+// the max number of records.
+func (s *payloadSizeLimiter) recordsCut() bool {
+	return s.recordsPerPart > 0 && s.partRecords >= s.recordsPerPart
+}
@@ -0,0 +1,56 @@
+package transformer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// SourceFetcher is a function that reads the header of the stored
+// object and opens the stream of its payload.
+type SourceFetcher func() (*object.Object, io.Reader, error)
+
+var errSplitSource = errors.New("source object is a part of the split-chain")
+
+// Repack reads the monolithic object through the fetcher and writes it
+// through the target, e.g. payloadSizeLimiter with the smaller max object
+// size to get the split-chain. Payload is streamed without buffering the
+// whole object in memory.
+//
+// Container, owner, type and attributes of the source object are
+// preserved, source identifier, signature and checksums are not.
+//
+// Returns the result of the target's Close.
+func Repack(fetch SourceFetcher, target ObjectTarget) (*AccessIdentifiers, error) {
+	src, payload, err := fetch()
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch source object: %w", err)
+	}
+
+	if src.GetParent() != nil || src.SplitID() != nil {
+		return nil, errSplitSource
+	}
+
+	hdr := object.NewRaw()
+	hdr.SetContainerID(src.ContainerID())
+	hdr.SetOwnerID(src.OwnerID())
+	hdr.SetType(src.Type())
+	hdr.SetAttributes(src.Attributes()...)
+
+	if err := target.WriteHeader(hdr); err != nil {
+		return nil, fmt.Errorf("could not write header: %w", err)
+	}
+
+	if _, err := io.Copy(target, payload); err != nil {
+		return nil, fmt.Errorf("could not copy payload: %w", err)
+	}
+
+	ids, err := target.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not close target: %w", err)
+	}
+
+	return ids, nil
+}
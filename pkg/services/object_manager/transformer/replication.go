@@ -0,0 +1,35 @@
+package transformer
+
+import (
+	"strconv"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// AttributeReplicationHint is a key of the object attribute which value
+// is a decimal number of the object replicas requested by the object
+// owner in addition to the container placement policy (see WithReplicationHint).
+const AttributeReplicationHint = "__NEOFS__REPLICATION_HINT"
+
+// WithReplicationHint returns option to set AttributeReplicationHint attribute
+// of each generated object: payload parts, parent, linking and index objects.
+// Value of the attribute in the source header is overwritten.
+//
+// Hint is per-object and does not depend on the container placement policy.
+//
+// Panics if n is zero.
+func WithReplicationHint(n uint32) Option {
+	if n == 0 {
+		panic("zero replication hint")
+	}
+
+	return func(c *cfg) {
+		c.replicationHint = n
+	}
+}
+
+func (s *payloadSizeLimiter) setReplicationHint(obj *object.RawObject) {
+	if s.replicationHint > 0 {
+		replaceAttribute(obj, AttributeReplicationHint, strconv.FormatUint(uint64(s.replicationHint), 10))
+	}
+}
@@ -0,0 +1,101 @@
+package transformer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// ContentRouter returns the target of the object
+// by the SHA256 checksum of its payload.
+type ContentRouter func(partChecksum []byte) ObjectTarget
+
+var errNoRoutedTarget = errors.New("no target for the payload checksum")
+
+// WithContentRouting returns option to write each generated object (payload
+// parts, linking and index objects) to the target returned by the router
+// for the SHA256 checksum of the object payload, e.g. to the bucket of the
+// sharded storage. Initializer passed to NewPayloadSizeLimiter is not used.
+//
+// Since the checksum is known only when the object is released, the payload
+// of the current object is buffered in memory until then, so up to the max
+// object size is kept per write. Router is called right before the object
+// header is written, all buffered payload is flushed to the returned target
+// after the header.
+//
+// Routing is incompatible with WithFixedID.
+func WithContentRouting(r ContentRouter) Option {
+	return func(c *cfg) {
+		c.contentRouter = r
+	}
+}
+
+// routedTarget is an ObjectTarget which buffers the payload and
+// chooses the actual target when the header is written.
+type routedTarget struct {
+	router ContentRouter
+
+	hasher hash.Hash
+
+	payload bytes.Buffer
+
+	target ObjectTarget
+}
+
+func (s *payloadSizeLimiter) initTarget() ObjectTarget {
+	if s.contentRouter == nil {
+		return s.targetInit()
+	}
+
+	return &routedTarget{
+		router: s.contentRouter,
+		hasher: sha256.New(),
+	}
+}
+
+func (t *routedTarget) WriteHeader(hdr *object.RawObject) error {
+	cs := t.hasher.Sum(nil)
+
+	target := t.router(cs)
+	if target == nil {
+		return fmt.Errorf("%w %x", errNoRoutedTarget, cs)
+	}
+
+	if err := target.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	if t.payload.Len() > 0 {
+		if _, err := target.Write(t.payload.Bytes()); err != nil {
+			return fmt.Errorf("could not flush payload to routed target: %w", err)
+		}
+	}
+
+	t.payload.Reset()
+	t.target = target
+
+	return nil
+}
+
+func (t *routedTarget) Write(p []byte) (int, error) {
+	if t.target != nil {
+		// header is already written
+		return t.target.Write(p)
+	}
+
+	t.hasher.Write(p)
+
+	return t.payload.Write(p)
+}
+
+func (t *routedTarget) Close() (*AccessIdentifiers, error) {
+	if t.target == nil {
+		return nil, errHeaderNotWritten
+	}
+
+	return t.target.Close()
+}
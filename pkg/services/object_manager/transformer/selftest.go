@@ -0,0 +1,186 @@
+package transformer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/tzhash/tz"
+)
+
+const (
+	selfTestMaxSize     = 256
+	selfTestPayloadSize = 3*selfTestMaxSize + selfTestMaxSize/2
+)
+
+// selfTestStorage collects objects written during the self-test.
+type selfTestStorage struct {
+	objects map[string]*object.RawObject
+
+	link *object.RawObject
+}
+
+type selfTestTarget struct {
+	storage *selfTestStorage
+
+	hdr *object.RawObject
+
+	payload []byte
+}
+
+// withPayloadHashers returns option to override constructor
+// of the payload hashers of the objects.
+func withPayloadHashers(f func(*object.RawObject) []*payloadChecksumHasher) Option {
+	return func(c *cfg) {
+		c.payloadHashers = f
+	}
+}
+
+// SelfTest writes the small payload through the transformer into the memory,
+// reassembles it back and verifies the payload and checksums of the generated
+// objects. Returns an error if anything is off, e.g. random number generator
+// or homomorphic hashing library is broken.
+//
+// SelfTest is intended to be called at the application startup.
+func SelfTest() error {
+	return selfTest()
+}
+
+func selfTest(opts ...Option) error {
+	payload := make([]byte, selfTestPayloadSize)
+
+	if _, err := rand.Read(payload); err != nil {
+		return fmt.Errorf("could not generate random payload: %w", err)
+	}
+
+	if bytes.Equal(payload, make([]byte, len(payload))) {
+		return errors.New("random number generator returned zero payload")
+	}
+
+	s := &selfTestStorage{
+		objects: make(map[string]*object.RawObject),
+	}
+
+	target := NewPayloadSizeLimiter(selfTestMaxSize, func() ObjectTarget {
+		return &selfTestTarget{storage: s}
+	}, opts...)
+
+	hdr := object.NewRaw()
+	hdr.SetType(objectSDK.TypeRegular)
+
+	if err := target.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("could not write header: %w", err)
+	}
+
+	if _, err := target.Write(payload); err != nil {
+		return fmt.Errorf("could not write payload: %w", err)
+	}
+
+	if _, err := target.Close(); err != nil {
+		return fmt.Errorf("could not close transformer: %w", err)
+	}
+
+	return s.verify(payload)
+}
+
+func (s *selfTestStorage) verify(payload []byte) error {
+	if s.link == nil {
+		return errors.New("linking object is missing")
+	}
+
+	var (
+		children = s.link.Children()
+		restored = make([]byte, 0, len(payload))
+		tzHashes = make([][]byte, 0, len(children))
+	)
+
+	for i := range children {
+		child, ok := s.objects[children[i].String()]
+		if !ok {
+			return fmt.Errorf("child object #%d is missing", i)
+		}
+
+		if err := verifyChecksums(child.Payload(), child.PayloadChecksum().Sum(), child.PayloadHomomorphicHash().Sum()); err != nil {
+			return fmt.Errorf("invalid child object #%d: %w", i, err)
+		}
+
+		restored = append(restored, child.Payload()...)
+		tzHashes = append(tzHashes, child.PayloadHomomorphicHash().Sum())
+	}
+
+	if !bytes.Equal(payload, restored) {
+		return errors.New("reassembled payload differs from the original one")
+	}
+
+	par := s.link.Parent()
+
+	if err := verifyChecksums(payload, par.PayloadChecksum().Sum(), par.PayloadHomomorphicHash().Sum()); err != nil {
+		return fmt.Errorf("invalid parent object: %w", err)
+	}
+
+	// homomorphic hash of the parent must be the composition of the children's ones
+	tzSum, err := tz.Concat(tzHashes)
+	if err != nil {
+		return fmt.Errorf("could not concatenate homomorphic hashes: %w", err)
+	}
+
+	if !bytes.Equal(tzSum, par.PayloadHomomorphicHash().Sum()) {
+		return errors.New("concatenated homomorphic hash of the children differs from the parent one")
+	}
+
+	return nil
+}
+
+func verifyChecksums(payload, cs, tzCs []byte) error {
+	if sum := sha256.Sum256(payload); !bytes.Equal(sum[:], cs) {
+		return errors.New("payload checksum mismatch")
+	}
+
+	if sum := tz.Sum(payload); !bytes.Equal(sum[:], tzCs) {
+		return errors.New("payload homomorphic hash mismatch")
+	}
+
+	return nil
+}
+
+func (t *selfTestTarget) WriteHeader(hdr *object.RawObject) error {
+	t.hdr = hdr
+	return nil
+}
+
+func (t *selfTestTarget) Write(p []byte) (int, error) {
+	t.payload = append(t.payload, p...)
+	return len(p), nil
+}
+
+func (t *selfTestTarget) Close() (*AccessIdentifiers, error) {
+	t.hdr.SetPayload(t.payload)
+
+	// limiter reuses released header structures, so save a copy
+	data, err := t.hdr.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal object: %w", err)
+	}
+
+	obj := object.NewRaw()
+	if err := obj.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("could not unmarshal object: %w", err)
+	}
+
+	id := objectSDK.NewID()
+	id.SetSHA256(sha256.Sum256(data))
+
+	obj.SetID(id)
+
+	t.storage.objects[id.String()] = obj
+
+	if len(obj.Children()) > 0 {
+		t.storage.link = obj
+	}
+
+	return new(AccessIdentifiers).WithSelfID(id), nil
+}
@@ -0,0 +1,41 @@
+package transformer
+
+import (
+	"hash"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/stretchr/testify/require"
+)
+
+// faultyHasher corrupts the first byte of each written chunk.
+type faultyHasher struct {
+	hash.Hash
+}
+
+func (h faultyHasher) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	corrupted := append([]byte{p[0] ^ 0xff}, p[1:]...)
+
+	return h.Hash.Write(corrupted)
+}
+
+func TestSelfTest(t *testing.T) {
+	require.NoError(t, SelfTest())
+
+	for i, name := range []string{"SHA256", "homomorphic"} {
+		t.Run("faulty "+name+" hasher", func(t *testing.T) {
+			err := selfTest(withPayloadHashers(func(obj *object.RawObject) []*payloadChecksumHasher {
+				hs := payloadHashersForObject(obj)
+				hs[i].hasher = faultyHasher{Hash: hs[i].hasher}
+
+				return hs
+			}))
+
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package transformer
+
+import (
+	"github.com/nspcc-dev/neofs-api-go/pkg/session"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// WithSessionToken returns option to set the session token of all the
+// objects produced by the transformer: the children, the parent and the
+// linking object of the split-chain. If not set, session token of the
+// header passed to WriteHeader is used.
+//
+// Token is set before the header is written to the target, so the target
+// signs the header with the token (targets may override it, e.g.
+// NewFormatTarget sets the token from FormatterParams).
+func WithSessionToken(tok *session.Token) Option {
+	return func(c *cfg) {
+		c.sessionToken = tok
+	}
+}
+
+// captureSessionToken takes session token from the written header
+// if it is not set explicitly.
+func (s *payloadSizeLimiter) captureSessionToken(hdr *object.RawObject) {
+	if s.sessionToken == nil {
+		s.sessionToken = hdr.SessionToken()
+	}
+}
+
+// setSessionToken sets session token of the object if it is known.
+func (s *payloadSizeLimiter) setSessionToken(obj *object.RawObject) {
+	if s.sessionToken != nil {
+		obj.SetSessionToken(s.sessionToken)
+	}
+}
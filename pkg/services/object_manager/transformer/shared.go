@@ -0,0 +1,146 @@
+package transformer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+)
+
+// AttributeSharedChildren is a key of the linking object attribute which
+// value is a comma-separated list of the decimal indices of the children
+// shared with other split-chains (see WithSharedParts).
+const AttributeSharedChildren = "__NEOFS__SHARED_CHILDREN"
+
+// WithSharedParts returns option to mark the children of the linking
+// object that are shared with other split-chains (e.g. deduplicated
+// in the content-addressed storage). Predicate is called for each child
+// when the linking object is formed, and indices of the shared children
+// are recorded in AttributeSharedChildren. Attribute is not set if there
+// are no shared children.
+//
+// Marks allow to determine the children that are safe to delete along
+// with the chain (see SafeToDelete).
+func WithSharedParts(shared func(*objectSDK.ID) bool) Option {
+	return func(c *cfg) {
+		c.sharedPart = shared
+	}
+}
+
+func (s *payloadSizeLimiter) sharedChildrenValue() string {
+	var idx []string
+
+	for i := range s.previous {
+		if s.sharedPart(s.previous[i]) {
+			idx = append(idx, strconv.Itoa(i))
+		}
+	}
+
+	return strings.Join(idx, ",")
+}
+
+// SharedChildren returns the children of the linking object
+// marked as shared with other split-chains.
+func SharedChildren(link *objectSDK.Object) ([]*objectSDK.ID, error) {
+	var val string
+
+	for _, a := range link.Attributes() {
+		if a.Key() == AttributeSharedChildren {
+			val = a.Value()
+			break
+		}
+	}
+
+	if val == "" {
+		return nil, nil
+	}
+
+	var (
+		children = link.Children()
+		idx      = strings.Split(val, ",")
+		res      = make([]*objectSDK.ID, 0, len(idx))
+	)
+
+	for i := range idx {
+		n, err := strconv.Atoi(idx[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid shared child index %q: %w", idx[i], err)
+		}
+
+		if n < 0 || n >= len(children) {
+			return nil, fmt.Errorf("shared child index %d out of range [0; %d)", n, len(children))
+		}
+
+		res = append(res, children[n])
+	}
+
+	return res, nil
+}
+
+// SafeToDelete returns the members of the tombstone which can be removed
+// without orphaning the split-chains that remain alive.
+//
+// Links must contain the linking objects of the split-chains which can
+// share the children with the removed ones. Chain is considered removed
+// if its linking object is a member of the tombstone. Child of the removed
+// chain is kept if it is referenced by the alive chain and is marked as
+// shared by any of these chains. Children that are not marked as shared
+// are removed anyway.
+func SafeToDelete(tomb *objectSDK.Tombstone, links []*objectSDK.Object) ([]*objectSDK.ID, error) {
+	members := tomb.Members()
+
+	mMembers := make(map[string]struct{}, len(members))
+	for i := range members {
+		mMembers[members[i].String()] = struct{}{}
+	}
+
+	var (
+		// shared children of the removed and alive chains
+		sharedRemoved = make(map[string]struct{})
+		sharedAlive   = make(map[string]struct{})
+		// all children of the alive chains
+		aliveChildren = make(map[string]struct{})
+	)
+
+	for i := range links {
+		shared, err := SharedChildren(links[i])
+		if err != nil {
+			return nil, fmt.Errorf("could not read shared children of %s: %w", links[i].ID(), err)
+		}
+
+		if _, removed := mMembers[links[i].ID().String()]; removed {
+			addIDs(sharedRemoved, shared)
+			continue
+		}
+
+		addIDs(sharedAlive, shared)
+		addIDs(aliveChildren, links[i].Children())
+	}
+
+	res := make([]*objectSDK.ID, 0, len(members))
+
+	for i := range members {
+		key := members[i].String()
+
+		if _, ok := sharedAlive[key]; ok {
+			continue
+		}
+
+		if _, ok := sharedRemoved[key]; ok {
+			if _, ok := aliveChildren[key]; ok {
+				continue
+			}
+		}
+
+		res = append(res, members[i])
+	}
+
+	return res, nil
+}
+
+func addIDs(m map[string]struct{}, ids []*objectSDK.ID) {
+	for i := range ids {
+		m[ids[i].String()] = struct{}{}
+	}
+}
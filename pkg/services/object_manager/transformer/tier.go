@@ -0,0 +1,60 @@
+package transformer
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// AttributeStorageTier is a key of the object attribute which value
+// is a storage tier (e.g. hot, cold, archive) the object is targeted at
+// (see WithStorageTier).
+const AttributeStorageTier = "__NEOFS__STORAGE_TIER"
+
+// WithStorageTier returns option to set AttributeStorageTier attribute
+// of each generated object: payload parts, parent, linking and index
+// objects. Value of the attribute in the source header is overwritten.
+//
+// If any of the tier options is set, objects with empty tier have no attribute.
+func WithStorageTier(tier string) Option {
+	return func(c *cfg) {
+		c.tier = tier
+	}
+}
+
+// WithPartStorageTier returns option to set AttributeStorageTier attribute
+// of the objects carrying the payload according to their 0-based number
+// in the split-chain. Object that fits into a single object is the part
+// number 0. Empty tier returned by f means the tier set by WithStorageTier.
+//
+// Tier of the parent, linking and index objects is set by WithStorageTier only.
+func WithPartStorageTier(f func(part int) string) Option {
+	return func(c *cfg) {
+		c.partTier = f
+	}
+}
+
+func (s *payloadSizeLimiter) tiered() bool {
+	return s.tier != "" || s.partTier != nil
+}
+
+// setPartTier sets storage tier of the current payload part.
+func (s *payloadSizeLimiter) setPartTier() {
+	if !s.tiered() {
+		return
+	}
+
+	tier := s.tier
+
+	if s.partTier != nil {
+		if t := s.partTier(len(s.previous)); t != "" {
+			tier = t
+		}
+	}
+
+	setStorageTier(s.current, tier)
+}
+
+// setStorageTier sets AttributeStorageTier attribute of the object
+// replacing the existing one. Attribute is removed if tier is empty.
+func setStorageTier(obj *object.RawObject, tier string) {
+	replaceAttribute(obj, AttributeStorageTier, tier)
+}
@@ -0,0 +1,59 @@
+package transformer
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrChunkWriteTimeout is returned when the write of the payload
+// chunk to the target exceeds the timeout (see WithChunkWriteTimeout).
+var ErrChunkWriteTimeout = errors.New("chunk write timeout")
+
+// WithChunkWriteTimeout returns option to limit the duration of each write
+// of the payload chunk to the target. Write that exceeds the timeout
+// fails with ErrChunkWriteTimeout.
+//
+// Stalled write is not interrupted: target is abandoned in the partially
+// written state and is never closed, all subsequent calls fail with
+// ErrChunkWriteTimeout. Since the stalled write can finish after the
+// failure, written chunks are copied, so the caller is free to reuse them.
+//
+// Non-positive value means no timeout.
+func WithChunkWriteTimeout(d time.Duration) Option {
+	return func(c *cfg) {
+		c.chunkWriteTimeout = d
+	}
+}
+
+// writeToTarget writes the chunk to the current target
+// respecting the chunk write timeout.
+func (s *payloadSizeLimiter) writeToTarget(chunk []byte) error {
+	if s.chunkWriteTimeout <= 0 {
+		_, err := s.chunkWriter.Write(chunk)
+		return err
+	}
+
+	var (
+		w    = s.chunkWriter
+		data = append([]byte(nil), chunk...)
+		done = make(chan error, 1)
+	)
+
+	go func() {
+		_, err := w.Write(data)
+		done <- err
+	}()
+
+	timer := time.NewTimer(s.chunkWriteTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		s.abandoned = true
+		s.abandonHashing(done)
+
+		return ErrChunkWriteTimeout
+	}
+}
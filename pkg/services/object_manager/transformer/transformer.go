@@ -1,20 +1,31 @@
 package transformer
 
 import (
+	"bytes"
+	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"hash"
 	"io"
+	"sort"
+	"strconv"
+	"time"
 
-	"github.com/nspcc-dev/neofs-api-go/pkg"
+	cid "github.com/nspcc-dev/neofs-api-go/pkg/container/id"
 	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	"github.com/nspcc-dev/neofs-api-go/pkg/session"
 	"github.com/nspcc-dev/neofs-node/pkg/core/object"
-	"github.com/nspcc-dev/tzhash/tz"
 )
 
 type payloadSizeLimiter struct {
+	*cfg
+
 	maxSize, written uint64
 
+	// container of the resolved max size (see WithMaxSizeSource)
+	maxSizeContainer *cid.ID
+
 	targetInit func() ObjectTarget
 
 	target ObjectTarget
@@ -25,11 +36,34 @@ type payloadSizeLimiter struct {
 
 	previous []*objectSDK.ID
 
+	// payload sizes of the released parts
+	partSizes []uint64
+
+	released uint64
+
+	// number of the record boundaries in the current object
+	partRecords int
+
 	chunkWriter io.Writer
 
+	// writer of the current object if the payload is hashed
+	// concurrently, nil otherwise
+	hashWriter *parallelHashWriter
+
 	splitID *objectSDK.SplitID
 
 	parAttrs []*objectSDK.Attribute
+
+	// payload of the current object sent to the parts channel
+	partPayload *bytes.Buffer
+
+	// progress at the moment of the last checkpoint
+	checkpointedParts int
+
+	checkpointedBytes uint64
+
+	// target is abandoned after the chunk write timeout
+	abandoned bool
 }
 
 type payloadChecksumHasher struct {
@@ -38,40 +72,258 @@ type payloadChecksumHasher struct {
 	checksumWriter func([]byte)
 }
 
+// Option is a payloadSizeLimiter's constructor option.
+type Option func(*cfg)
+
+type cfg struct {
+	maxParts int
+
+	withIndex bool
+
+	readBack func(*objectSDK.ID) (io.Reader, error)
+
+	chunkTransform func([]byte) ([]byte, error)
+
+	withPartCount bool
+
+	parts chan<- Part
+
+	checkpoints CheckpointStore
+
+	checkpointParts int
+
+	checkpointBytes uint64
+
+	resume *Checkpoint
+
+	sharedPart func(*objectSDK.ID) bool
+
+	tier string
+
+	partTier func(int) string
+
+	payloadHashers func(*object.RawObject) []*payloadChecksumHasher
+
+	chunkWriteTimeout time.Duration
+
+	replicationHint uint32
+
+	wal WAL
+
+	customHashers bool
+
+	noHomomorphicHash bool
+
+	ctx context.Context
+
+	receiptSigner func([]byte) ([]byte, error)
+
+	maxSizeSource MaxSizeSource
+
+	progress ProgressCallback
+
+	recordsPerPart int
+
+	hashWorkers int
+
+	fixedID *objectSDK.ID
+
+	childAttrFilter func(*objectSDK.Attribute) bool
+
+	sessionToken *session.Token
+
+	blake3Checksum bool
+
+	aclRef []byte
+
+	contentRouter ContentRouter
+}
+
 const tzChecksumSize = 64
 
+// ErrMaxPartsExceeded is returned when the payload does not fit
+// into the limited number of the split-chain parts.
+var ErrMaxPartsExceeded = errors.New("max number of object parts exceeded")
+
+// ErrReadBackMismatch is returned when the payload read back from
+// the storage differs from the written one.
+var ErrReadBackMismatch = errors.New("read back payload checksum mismatch")
+
+var errHeaderNotWritten = errors.New("header is not written")
+
+func defaultCfg() *cfg {
+	return &cfg{
+		payloadHashers: payloadHashersForObject,
+	}
+}
+
 // NewPayloadSizeLimiter returns ObjectTarget instance that restricts payload length
 // of the writing object and writes generated objects to targets from initializer.
 //
 // Objects w/ payload size less or equal than max size remain untouched.
+// Close right after WriteHeader releases single object with empty payload
+// and the checksums of the empty payload.
+//
+// Objects of the split-chain are released strictly one by one: target of
+// the next object is initialized only after the previous target is closed,
+// since its identifier is required for the header of the next one. So no
+// more than one target Close is in progress at a time.
 //
 // TODO: describe behavior in details.
-func NewPayloadSizeLimiter(maxSize uint64, targetInit TargetInitializer) ObjectTarget {
+func NewPayloadSizeLimiter(maxSize uint64, targetInit TargetInitializer, opts ...Option) ObjectTarget {
+	c := defaultCfg()
+
+	for i := range opts {
+		opts[i](c)
+	}
+
+	if c.noHomomorphicHash && !c.customHashers {
+		c.payloadHashers = func(obj *object.RawObject) []*payloadChecksumHasher {
+			return newPayloadHashers(obj, defaultChecksumHashers[:1])
+		}
+	}
+
+	if c.blake3Checksum {
+		c.payloadHashers = withBLAKE3Hasher(c.payloadHashers)
+	}
+
 	return &payloadSizeLimiter{
+		cfg:        c,
 		maxSize:    maxSize,
 		targetInit: targetInit,
 		splitID:    objectSDK.NewSplitID(),
 	}
 }
 
+// WithReadBackVerification returns option to verify each written object
+// of the split-chain by reading it back. After the target of the object
+// is closed, fetch is called with the object ID, and the payload read
+// from the returned io.Reader is compared with the written one by the
+// SHA256 checksum. Write fails with ErrReadBackMismatch if checksums differ.
+func WithReadBackVerification(fetch func(id *objectSDK.ID) (io.Reader, error)) Option {
+	return func(c *cfg) {
+		c.readBack = fetch
+	}
+}
+
+// WithChunkTransform returns option to transform payload chunks before
+// writing. Transformed bytes are cut into the objects, hashed and stored
+// instead of the original ones, so the length of the result may differ
+// from the length of the original chunk.
+//
+// Transform is called on each Write with the written chunk. Chunk boundaries
+// are arbitrary and not aligned to any logical records of the payload, so
+// transformations depending on the context must buffer the data themselves.
+// Transform must not retain the passed slice.
+func WithChunkTransform(f func([]byte) ([]byte, error)) Option {
+	return func(c *cfg) {
+		c.chunkTransform = f
+	}
+}
+
+// WithMaxParts returns option to limit the number of objects with payload
+// in the split-chain (linking object is not counted). The write which
+// requires more parts fails with ErrMaxPartsExceeded before writing
+// the exceeding part.
+//
+// Non-positive value means no limit.
+func WithMaxParts(n int) Option {
+	return func(c *cfg) {
+		c.maxParts = n
+	}
+}
+
 func (s *payloadSizeLimiter) WriteHeader(hdr *object.RawObject) error {
+	if err := s.ctxErr(); err != nil {
+		return err
+	}
+
+	if (s.customHashers || s.blake3Checksum) && (s.checkpoints != nil || s.resume != nil) {
+		return errCheckpointsWithCustomHashers
+	}
+
+	if err := s.resolveMaxSize(hdr); err != nil {
+		return err
+	}
+
+	s.captureSessionToken(hdr)
+
+	if s.resume != nil {
+		if err := s.restore(hdr); err != nil {
+			return fmt.Errorf("could not resume from checkpoint: %w", err)
+		}
+
+		return nil
+	}
+
 	s.current = fromObject(hdr)
 
 	s.initialize()
 
-	return nil
+	return s.setFixedID()
 }
 
 func (s *payloadSizeLimiter) Write(p []byte) (int, error) {
-	if err := s.writeChunk(p); err != nil {
+	if s.abandoned {
+		return 0, ErrChunkWriteTimeout
+	}
+
+	if s.current == nil {
+		return 0, errHeaderNotWritten
+	}
+
+	if err := s.ctxErr(); err != nil {
 		return 0, err
 	}
 
+	chunk := p
+
+	if s.chunkTransform != nil {
+		var err error
+
+		if chunk, err = s.chunkTransform(p); err != nil {
+			return 0, fmt.Errorf("could not transform chunk: %w", err)
+		}
+	}
+
+	if err := s.writeChunk(chunk); err != nil {
+		return 0, err
+	}
+
+	s.reportProgress()
+
 	return len(p), nil
 }
 
 func (s *payloadSizeLimiter) Close() (*AccessIdentifiers, error) {
-	return s.release(true)
+	if s.abandoned {
+		return nil, ErrChunkWriteTimeout
+	}
+
+	if s.current == nil {
+		return nil, errHeaderNotWritten
+	}
+
+	if err := s.ctxErr(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkMaxSize(); err != nil {
+		return nil, err
+	}
+
+	s.setPartTier()
+
+	if s.tiered() && len(s.previous) > 0 {
+		setStorageTier(s.parent, s.tier)
+	}
+
+	ids, err := s.release(true)
+	if err != nil || s.receiptSigner == nil {
+		return ids, err
+	}
+
+	return s.signReceipt(ids)
 }
 
 func (s *payloadSizeLimiter) initialize() {
@@ -93,7 +345,7 @@ func fromObject(obj *object.RawObject) *object.RawObject {
 	res := object.NewRaw()
 	res.SetContainerID(obj.ContainerID())
 	res.SetOwnerID(obj.OwnerID())
-	res.SetAttributes(obj.Attributes()...)
+	res.SetAttributes(sortAttributes(obj.Attributes())...)
 	res.SetType(obj.Type())
 
 	// obj.SetSplitID creates splitHeader but we don't need to do it in case
@@ -105,18 +357,40 @@ func fromObject(obj *object.RawObject) *object.RawObject {
 	return res
 }
 
+// sortAttributes sorts attributes by key and value, so the same set
+// of attributes is always serialized in the same way and the identical
+// inputs result in the identical object IDs.
+func sortAttributes(attrs []*objectSDK.Attribute) []*objectSDK.Attribute {
+	sort.Slice(attrs, func(i, j int) bool {
+		if attrs[i].Key() != attrs[j].Key() {
+			return attrs[i].Key() < attrs[j].Key()
+		}
+
+		return attrs[i].Value() < attrs[j].Value()
+	})
+
+	return attrs
+}
+
 func (s *payloadSizeLimiter) initializeCurrent() {
 	// initialize current object target
-	s.target = s.targetInit()
+	s.target = s.initTarget()
 
 	// create payload hashers
-	s.currentHashers = payloadHashersForObject(s.current)
+	s.currentHashers = s.payloadHashers(s.current)
 
 	// compose multi-writer from target and all payload hashers
 	ws := make([]io.Writer, 0, 1+len(s.currentHashers)+len(s.parentHashers))
 
 	ws = append(ws, s.target)
 
+	if s.parts != nil {
+		ws = append(ws, s.partPayloadWriter())
+	}
+
+	// hashers follow the payload receivers
+	hs := len(ws)
+
 	for i := range s.currentHashers {
 		ws = append(ws, s.currentHashers[i].hasher)
 	}
@@ -125,44 +399,17 @@ func (s *payloadSizeLimiter) initializeCurrent() {
 		ws = append(ws, s.parentHashers[i].hasher)
 	}
 
+	if s.hashWorkers > 0 {
+		s.hashWriter = s.parallelHashing(io.MultiWriter(ws[:hs]...), ws[hs:])
+		s.chunkWriter = s.hashWriter
+		return
+	}
+
 	s.chunkWriter = io.MultiWriter(ws...)
 }
 
 func payloadHashersForObject(obj *object.RawObject) []*payloadChecksumHasher {
-	return []*payloadChecksumHasher{
-		{
-			hasher: sha256.New(),
-			checksumWriter: func(cs []byte) {
-				if ln := len(cs); ln != sha256.Size {
-					panic(fmt.Sprintf("wrong checksum length: expected %d, has %d", ln, sha256.Size))
-				}
-
-				csSHA := [sha256.Size]byte{}
-				copy(csSHA[:], cs)
-
-				checksum := pkg.NewChecksum()
-				checksum.SetSHA256(csSHA)
-
-				obj.SetPayloadChecksum(checksum)
-			},
-		},
-		{
-			hasher: tz.New(),
-			checksumWriter: func(cs []byte) {
-				if ln := len(cs); ln != tzChecksumSize {
-					panic(fmt.Sprintf("wrong checksum length: expected %d, has %d", ln, tzChecksumSize))
-				}
-
-				csTZ := [tzChecksumSize]byte{}
-				copy(csTZ[:], cs)
-
-				checksum := pkg.NewChecksum()
-				checksum.SetTillichZemor(csTZ)
-
-				obj.SetPayloadHomomorphicHash(checksum)
-			},
-		},
-	}
+	return newPayloadHashers(obj, defaultChecksumHashers)
 }
 
 func (s *payloadSizeLimiter) release(close bool) (*AccessIdentifiers, error) {
@@ -171,12 +418,28 @@ func (s *payloadSizeLimiter) release(close bool) (*AccessIdentifiers, error) {
 	// than 1 object in split-chain.
 	withParent := close && len(s.previous) > 0
 
+	// all written chunks must be hashed
+	s.stopHashing()
+
 	if withParent {
+		if s.withPartCount {
+			// current object is the last part
+			addAttribute(s.parent, AttributePartCount, strconv.Itoa(len(s.previous)+1))
+		}
+
+		s.setReplicationHint(s.parent)
+		s.setACLRef(s.parent)
+		s.setSessionToken(s.parent)
+
 		writeHashes(s.parentHashers)
 		s.parent.SetPayloadSize(s.written)
 		s.current.SetParent(s.parent.SDK().Object())
 	}
 
+	s.setReplicationHint(s.current)
+	s.setACLRef(s.current)
+	s.setSessionToken(s.current)
+
 	// release current object
 	writeHashes(s.currentHashers)
 
@@ -185,27 +448,88 @@ func (s *payloadSizeLimiter) release(close bool) (*AccessIdentifiers, error) {
 		return nil, fmt.Errorf("could not write header: %w", err)
 	}
 
+	if s.wal != nil {
+		if err := s.wal.Append(s.current); err != nil {
+			return nil, fmt.Errorf("could not append header to WAL: %w", err)
+		}
+	}
+
 	ids, err := s.target.Close()
 	if err != nil {
 		return nil, fmt.Errorf("could not close target: %w", err)
 	}
 
+	if s.readBack != nil {
+		if err := s.verifyReadBack(ids.SelfID()); err != nil {
+			return nil, fmt.Errorf("could not verify written object %s: %w", ids.SelfID(), err)
+		}
+	}
+
+	if s.parts != nil {
+		if err := s.sendPart(ids.SelfID()); err != nil {
+			return nil, fmt.Errorf("could not send object %s to the parts channel: %w", ids.SelfID(), err)
+		}
+	}
+
 	// save identifier of the released object
 	s.previous = append(s.previous, ids.SelfID())
 
+	s.partSizes = append(s.partSizes, s.written-s.released)
+	s.released = s.written
+
+	s.reportProgress()
+
+	if close {
+		// all payload-bearing objects are released
+		ids = ids.WithChildIDs(append([]*objectSDK.ID(nil), s.previous...))
+	}
+
 	if withParent {
+		entries := s.indexEntries()
+
 		// generate and release linking object
 		s.initializeLinking(ids.Parent())
 		s.initializeCurrent()
 
-		if _, err := s.release(false); err != nil {
+		linkIDs, err := s.release(false)
+		if err != nil {
 			return nil, fmt.Errorf("could not release linking object: %w", err)
 		}
+
+		ids = ids.WithLinkID(linkIDs.SelfID())
+
+		if s.withIndex {
+			indexID, err := s.releaseIndex(ids.ParentID(), entries)
+			if err != nil {
+				return nil, fmt.Errorf("could not release index object: %w", err)
+			}
+
+			ids = ids.WithIndexID(indexID)
+		}
 	}
 
 	return ids, nil
 }
 
+func (s *payloadSizeLimiter) verifyReadBack(id *objectSDK.ID) error {
+	r, err := s.readBack(id)
+	if err != nil {
+		return fmt.Errorf("could not read object back: %w", err)
+	}
+
+	h := sha256.New()
+
+	if _, err := io.Copy(h, r); err != nil {
+		return fmt.Errorf("could not read object payload: %w", err)
+	}
+
+	if !bytes.Equal(h.Sum(nil), s.current.PayloadChecksum().Sum()) {
+		return ErrReadBackMismatch
+	}
+
+	return nil
+}
+
 func writeHashes(hashers []*payloadChecksumHasher) {
 	for i := range hashers {
 		hashers[i].checksumWriter(hashers[i].hasher.Sum(nil))
@@ -217,15 +541,47 @@ func (s *payloadSizeLimiter) initializeLinking(parHdr *objectSDK.Object) {
 	s.current.SetParent(parHdr)
 	s.current.SetChildren(s.previous...)
 	s.current.SetSplitID(s.splitID)
+
+	addAttribute(s.current, AttributeChildrenChecksum, childrenChecksumValue(s.previous))
+
+	if s.tiered() {
+		setStorageTier(s.current, s.tier)
+	}
+
+	if s.sharedPart != nil {
+		if val := s.sharedChildrenValue(); val != "" {
+			addAttribute(s.current, AttributeSharedChildren, val)
+		}
+	}
 }
 
 func (s *payloadSizeLimiter) writeChunk(chunk []byte) error {
-	// statement is true if the previous write of bytes reached exactly the boundary.
-	if s.written > 0 && s.written%s.maxSize == 0 {
-		if s.written == s.maxSize {
+	// statement is true if the previous write of bytes reached exactly the boundary
+	// of the object that has not been released yet.
+	if s.written > s.released && (s.written-s.released == s.maxSize || s.recordsCut()) {
+		if err := s.ctxErr(); err != nil {
+			return err
+		}
+
+		if err := s.checkMaxSize(); err != nil {
+			return err
+		}
+
+		if s.fixedID != nil {
+			return ErrFixedIDSplit
+		}
+
+		// current object is the last one that can be written
+		if s.maxParts > 0 && len(s.previous)+1 >= s.maxParts {
+			return ErrMaxPartsExceeded
+		}
+
+		if len(s.previous) == 0 {
 			s.prepareFirstChild()
 		}
 
+		s.setPartTier()
+
 		// we need to release current object
 		if _, err := s.release(false); err != nil {
 			return fmt.Errorf("could not release object: %w", err)
@@ -233,12 +589,20 @@ func (s *payloadSizeLimiter) writeChunk(chunk []byte) error {
 
 		// initialize another object
 		s.initialize()
+
+		s.partRecords = 0
+
+		if s.needCheckpoint() {
+			if err := s.checkpoint(); err != nil {
+				return fmt.Errorf("could not save checkpoint: %w", err)
+			}
+		}
 	}
 
 	var (
 		ln         = uint64(len(chunk))
 		cut        = ln
-		leftToEdge = s.maxSize - s.written%s.maxSize
+		leftToEdge = s.maxSize - (s.written - s.released)
 	)
 
 	// write bytes no further than the boundary of the current object
@@ -246,7 +610,7 @@ func (s *payloadSizeLimiter) writeChunk(chunk []byte) error {
 		cut = leftToEdge
 	}
 
-	if _, err := s.chunkWriter.Write(chunk[:cut]); err != nil {
+	if err := s.writeToTarget(chunk[:cut]); err != nil {
 		return fmt.Errorf("could not write chunk to target: %w", err)
 	}
 
@@ -268,7 +632,7 @@ func (s *payloadSizeLimiter) prepareFirstChild() {
 
 	// cut source attributes
 	s.parAttrs = s.current.Attributes()
-	s.current.SetAttributes()
+	s.current.SetAttributes(s.childAttributes(s.parAttrs)...)
 
 	// attributes will be added to parent in detachParent
 }
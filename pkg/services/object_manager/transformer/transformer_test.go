@@ -0,0 +1,2395 @@
+package transformer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-api-go/pkg"
+	cid "github.com/nspcc-dev/neofs-api-go/pkg/container/id"
+	cidtest "github.com/nspcc-dev/neofs-api-go/pkg/container/id/test"
+	objectSDK "github.com/nspcc-dev/neofs-api-go/pkg/object"
+	ownertest "github.com/nspcc-dev/neofs-api-go/pkg/owner/test"
+	"github.com/nspcc-dev/neofs-api-go/pkg/session"
+	sessiontest "github.com/nspcc-dev/neofs-api-go/pkg/session/test"
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+	meta "github.com/nspcc-dev/neofs-node/pkg/local_object_storage/metabase"
+	"github.com/nspcc-dev/neofs-node/pkg/util/blake3"
+	"github.com/nspcc-dev/tzhash/tz"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+// memStorage collects objects released by the payloadSizeLimiter.
+type memStorage struct {
+	objects []*object.RawObject
+}
+
+// memTarget finalizes object like formatter does, but w/o signatures,
+// so identifiers depend only on the object content.
+type memTarget struct {
+	storage *memStorage
+
+	hdr *object.RawObject
+
+	payload []byte
+}
+
+func (s *memStorage) initializer() TargetInitializer {
+	return func() ObjectTarget {
+		return &memTarget{storage: s}
+	}
+}
+
+func (t *memTarget) WriteHeader(hdr *object.RawObject) error {
+	t.hdr = hdr
+	return nil
+}
+
+func (t *memTarget) Write(p []byte) (int, error) {
+	t.payload = append(t.payload, p...)
+	return len(p), nil
+}
+
+func (t *memTarget) Close() (*AccessIdentifiers, error) {
+	var (
+		parID  *objectSDK.ID
+		parHdr *objectSDK.Object
+	)
+
+	if par := t.hdr.Parent(); par != nil && par.ID() == nil {
+		rawPar := objectSDK.NewRawFromV2(par.ToV2())
+
+		id, err := contentID(object.NewRawFrom(rawPar))
+		if err != nil {
+			return nil, err
+		}
+
+		rawPar.SetID(id)
+
+		parID = id
+		parHdr = rawPar.Object()
+
+		t.hdr.SetParent(parHdr)
+	}
+
+	t.hdr.SetPayload(t.payload)
+
+	id, err := contentID(t.hdr)
+	if err != nil {
+		return nil, err
+	}
+
+	t.hdr.SetID(id)
+
+	// limiter reuses released header structures, so save a copy
+	data, err := t.hdr.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	obj := object.NewRaw()
+	if err := obj.Unmarshal(data); err != nil {
+		return nil, err
+	}
+
+	t.storage.objects = append(t.storage.objects, obj)
+
+	return new(AccessIdentifiers).
+		WithSelfID(id).
+		WithParentID(parID).
+		WithParent(parHdr), nil
+}
+
+func contentID(obj *object.RawObject) (*objectSDK.ID, error) {
+	data, err := obj.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	id := objectSDK.NewID()
+	id.SetSHA256(sha256.Sum256(data))
+
+	return id, nil
+}
+
+func testPayload(t testing.TB, size int) []byte {
+	payload := make([]byte, size)
+
+	_, err := rand.Read(payload)
+	require.NoError(t, err)
+
+	return payload
+}
+
+func testHeader(attrs ...*objectSDK.Attribute) *object.RawObject {
+	hdr := object.NewRaw()
+	hdr.SetContainerID(cidtest.Generate())
+	hdr.SetOwnerID(ownertest.Generate())
+	hdr.SetAttributes(attrs...)
+
+	return hdr
+}
+
+func testAttribute(key, val string) *objectSDK.Attribute {
+	a := objectSDK.NewAttribute()
+	a.SetKey(key)
+	a.SetValue(val)
+
+	return a
+}
+
+// writeObject writes object through the target and returns the result of Close.
+func writeObject(t testing.TB, target ObjectTarget, hdr *object.RawObject, payload []byte) *AccessIdentifiers {
+	require.NoError(t, target.WriteHeader(hdr))
+
+	_, err := target.Write(payload)
+	require.NoError(t, err)
+
+	ids, err := target.Close()
+	require.NoError(t, err)
+
+	return ids
+}
+
+func objectIDs(objs []*object.RawObject) []*objectSDK.ID {
+	ids := make([]*objectSDK.ID, len(objs))
+
+	for i := range objs {
+		ids[i] = objs[i].ID()
+	}
+
+	return ids
+}
+
+func TestPayloadSizeLimiter_DeterministicAttributes(t *testing.T) {
+	const maxSize = 64
+
+	payload := testPayload(t, 4*maxSize+maxSize/2)
+
+	a1 := testAttribute("key1", "val1")
+	a2 := testAttribute("key2", "val2")
+
+	hdr1 := testHeader(a1, a2)
+
+	// same header with reversed order of attributes
+	hdr2 := object.NewRaw()
+	hdr2.SetContainerID(hdr1.ContainerID())
+	hdr2.SetOwnerID(hdr1.OwnerID())
+	hdr2.SetAttributes(a2, a1)
+
+	s1, s2 := new(memStorage), new(memStorage)
+
+	l1 := NewPayloadSizeLimiter(maxSize, s1.initializer())
+	l2 := NewPayloadSizeLimiter(maxSize, s2.initializer())
+
+	// split ID is random, make it the same for both chains
+	l2.(*payloadSizeLimiter).splitID = l1.(*payloadSizeLimiter).splitID
+
+	ids1 := writeObject(t, l1, hdr1, payload)
+	ids2 := writeObject(t, l2, hdr2, payload)
+
+	require.Equal(t, ids1.ParentID(), ids2.ParentID())
+	require.Equal(t, objectIDs(s1.objects), objectIDs(s2.objects))
+}
+
+func attributeValue(obj *object.RawObject, key string) (string, bool) {
+	for _, a := range obj.Attributes() {
+		if a.Key() == key {
+			return a.Value(), true
+		}
+	}
+
+	return "", false
+}
+
+func TestPayloadSizeLimiter_ChildrenChecksum(t *testing.T) {
+	const maxSize = 64
+
+	s := new(memStorage)
+
+	writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer()), testHeader(), testPayload(t, 3*maxSize+1))
+
+	require.Len(t, s.objects, 5)
+
+	children := objectIDs(s.objects[:4])
+	link := s.objects[4]
+
+	require.Equal(t, children, link.Children())
+
+	// independent computation
+	var data []byte
+	for i := range children {
+		data = append(data, children[i].ToV2().GetValue()...)
+	}
+
+	expected := sha256.Sum256(data)
+
+	val, ok := attributeValue(link, AttributeChildrenChecksum)
+	require.True(t, ok)
+	require.Equal(t, hex.EncodeToString(expected[:]), val)
+	require.Equal(t, expected, ChildrenChecksum(children))
+
+	// reordered children list must not match
+	children[0], children[1] = children[1], children[0]
+	require.NotEqual(t, expected, ChildrenChecksum(children))
+
+	// payload-bearing children do not carry the checksum
+	for i := range s.objects[:4] {
+		_, ok := attributeValue(s.objects[i], AttributeChildrenChecksum)
+		require.False(t, ok)
+	}
+}
+
+func TestPayloadSizeLimiter_MaxParts(t *testing.T) {
+	const (
+		maxSize  = 16
+		maxParts = 3
+	)
+
+	t.Run("within limit", func(t *testing.T) {
+		s := new(memStorage)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithMaxParts(maxParts)),
+			testHeader(), testPayload(t, maxParts*maxSize))
+
+		// parts and linking object
+		require.Len(t, s.objects, maxParts+1)
+	})
+
+	t.Run("limit exceeded", func(t *testing.T) {
+		s := new(memStorage)
+
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithMaxParts(maxParts))
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(testPayload(t, maxParts*maxSize+1))
+		require.True(t, errors.Is(err, ErrMaxPartsExceeded))
+
+		// exceeding part is not written
+		require.Len(t, s.objects, maxParts-1)
+	})
+}
+
+// payloadReader returns function that reads payload of the stored object.
+// If corrupt is set, first byte of the payload bearing objects is changed.
+func (s *memStorage) payloadReader(corrupt bool) func(*objectSDK.ID) (io.Reader, error) {
+	return func(id *objectSDK.ID) (io.Reader, error) {
+		for i := range s.objects {
+			if !s.objects[i].ID().Equal(id) {
+				continue
+			}
+
+			payload := append([]byte{}, s.objects[i].Payload()...)
+			if corrupt && len(payload) > 0 {
+				payload[0]++
+			}
+
+			return bytes.NewReader(payload), nil
+		}
+
+		return nil, errors.New("object not found")
+	}
+}
+
+func TestPayloadSizeLimiter_ReadBackVerification(t *testing.T) {
+	const maxSize = 64
+
+	payload := testPayload(t, 2*maxSize+1)
+
+	t.Run("correct", func(t *testing.T) {
+		s := new(memStorage)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithReadBackVerification(s.payloadReader(false))),
+			testHeader(), payload)
+
+		require.Len(t, s.objects, 4)
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		s := new(memStorage)
+
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithReadBackVerification(s.payloadReader(true)))
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		// corruption of the first part is detected on the release
+		_, err := target.Write(payload)
+		require.True(t, errors.Is(err, ErrReadBackMismatch))
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		s := new(memStorage)
+		testErr := errors.New("test error")
+
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithReadBackVerification(func(*objectSDK.ID) (io.Reader, error) {
+			return nil, testErr
+		}))
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(payload[:maxSize])
+		require.NoError(t, err)
+
+		_, err = target.Close()
+		require.True(t, errors.Is(err, testErr))
+	})
+}
+
+func TestPayloadSizeLimiter_ChunkTransform(t *testing.T) {
+	const maxSize = 64
+
+	payload := testPayload(t, 2*maxSize+maxSize/2)
+
+	// write payload in chunks not aligned to the object boundaries
+	writeChunks := func(t *testing.T, target ObjectTarget, hdr *object.RawObject) *AccessIdentifiers {
+		require.NoError(t, target.WriteHeader(hdr))
+
+		for off := 0; off < len(payload); off += 10 {
+			end := off + 10
+			if end > len(payload) {
+				end = len(payload)
+			}
+
+			n, err := target.Write(payload[off:end])
+			require.NoError(t, err)
+			require.Equal(t, end-off, n)
+		}
+
+		ids, err := target.Close()
+		require.NoError(t, err)
+
+		return ids
+	}
+
+	joinPayloads := func(objs []*object.RawObject) []byte {
+		var res []byte
+		for i := range objs {
+			res = append(res, objs[i].Payload()...)
+		}
+
+		return res
+	}
+
+	t.Run("identity", func(t *testing.T) {
+		s1, s2 := new(memStorage), new(memStorage)
+
+		l1 := NewPayloadSizeLimiter(maxSize, s1.initializer())
+		l2 := NewPayloadSizeLimiter(maxSize, s2.initializer(), WithChunkTransform(func(p []byte) ([]byte, error) {
+			return p, nil
+		}))
+
+		l2.(*payloadSizeLimiter).splitID = l1.(*payloadSizeLimiter).splitID
+
+		hdr := testHeader()
+
+		ids1 := writeChunks(t, l1, hdr)
+		ids2 := writeChunks(t, l2, hdr)
+
+		require.Equal(t, ids1.ParentID(), ids2.ParentID())
+		require.Equal(t, objectIDs(s1.objects), objectIDs(s2.objects))
+	})
+
+	t.Run("altering", func(t *testing.T) {
+		s := new(memStorage)
+
+		// duplicate each byte, so the payload size doubles
+		ids := writeChunks(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithChunkTransform(func(p []byte) ([]byte, error) {
+			res := make([]byte, 0, 2*len(p))
+			for i := range p {
+				res = append(res, p[i], p[i])
+			}
+
+			return res, nil
+		})), testHeader())
+
+		expected := make([]byte, 0, 2*len(payload))
+		for i := range payload {
+			expected = append(expected, payload[i], payload[i])
+		}
+
+		// 5 parts and linking object
+		require.Len(t, s.objects, 6)
+		require.Equal(t, expected, joinPayloads(s.objects[:5]))
+
+		for i := range s.objects[:5] {
+			cs := sha256.Sum256(s.objects[i].Payload())
+			require.Equal(t, cs[:], s.objects[i].PayloadChecksum().Sum())
+		}
+
+		cs := sha256.Sum256(expected)
+		require.Equal(t, cs[:], ids.Parent().PayloadChecksum().Sum())
+		require.EqualValues(t, len(expected), ids.Parent().PayloadSize())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		testErr := errors.New("test error")
+
+		target := NewPayloadSizeLimiter(maxSize, new(memStorage).initializer(), WithChunkTransform(func([]byte) ([]byte, error) {
+			return nil, testErr
+		}))
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(payload)
+		require.True(t, errors.Is(err, testErr))
+	})
+}
+
+func TestRepack(t *testing.T) {
+	const maxSize = 64
+
+	payload := testPayload(t, 5*maxSize+maxSize/3)
+
+	// store monolithic object
+	srcStorage := new(memStorage)
+	writeObject(t, NewPayloadSizeLimiter(uint64(len(payload)), srcStorage.initializer()),
+		testHeader(testAttribute("key1", "val1"), testAttribute("key2", "val2")), payload)
+	require.Len(t, srcStorage.objects, 1)
+
+	src := srcStorage.objects[0]
+
+	fetch := func() (*object.Object, io.Reader, error) {
+		return src.Object(), bytes.NewReader(src.Payload()), nil
+	}
+
+	t.Run("split", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids, err := Repack(fetch, NewPayloadSizeLimiter(maxSize, s.initializer()))
+		require.NoError(t, err)
+
+		// parts and linking object
+		require.Len(t, s.objects, 7)
+
+		var res []byte
+		for i := range s.objects[:6] {
+			res = append(res, s.objects[i].Payload()...)
+		}
+
+		require.Equal(t, payload, res)
+
+		par := ids.Parent()
+		require.Equal(t, src.ContainerID(), par.ContainerID())
+		require.Equal(t, src.OwnerID(), par.OwnerID())
+		require.Equal(t, src.Attributes(), par.Attributes())
+		require.Equal(t, src.PayloadChecksum(), par.PayloadChecksum())
+		require.EqualValues(t, len(payload), par.PayloadSize())
+	})
+
+	t.Run("fetch failure", func(t *testing.T) {
+		testErr := errors.New("test error")
+
+		_, err := Repack(func() (*object.Object, io.Reader, error) {
+			return nil, nil, testErr
+		}, NewPayloadSizeLimiter(maxSize, new(memStorage).initializer()))
+		require.True(t, errors.Is(err, testErr))
+	})
+
+	t.Run("split source", func(t *testing.T) {
+		s := new(memStorage)
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer()), testHeader(), payload)
+
+		_, err := Repack(func() (*object.Object, io.Reader, error) {
+			return s.objects[0].Object(), bytes.NewReader(s.objects[0].Payload()), nil
+		}, NewPayloadSizeLimiter(maxSize, new(memStorage).initializer()))
+		require.True(t, errors.Is(err, errSplitSource))
+	})
+}
+
+func TestPayloadSizeLimiter_PartCount(t *testing.T) {
+	const maxSize = 64
+
+	for _, tc := range []struct {
+		size, parts int
+	}{
+		{size: maxSize + 1, parts: 2},
+		{size: 2 * maxSize, parts: 2},
+		{size: 2*maxSize + 1, parts: 3},
+		{size: 10*maxSize + maxSize/2, parts: 11},
+	} {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithPartCount()),
+			testHeader(), testPayload(t, tc.size))
+
+		// parts and linking object
+		require.Len(t, s.objects, tc.parts+1)
+
+		// parent header is stored in the last part and linking object
+		for _, par := range []*objectSDK.Object{ids.Parent(), s.objects[tc.parts-1].Parent(), s.objects[tc.parts].Parent()} {
+			val, ok := attributeValue(object.NewRawFrom(objectSDK.NewRawFromV2(par.ToV2())), AttributePartCount)
+			require.True(t, ok)
+			require.Equal(t, strconv.Itoa(tc.parts), val)
+		}
+	}
+
+	t.Run("single object", func(t *testing.T) {
+		s := new(memStorage)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithPartCount()),
+			testHeader(), testPayload(t, maxSize))
+
+		require.Len(t, s.objects, 1)
+
+		_, ok := attributeValue(s.objects[0], AttributePartCount)
+		require.False(t, ok)
+	})
+}
+
+func testSHA256(t testing.TB) (cs [sha256.Size]byte) {
+	copy(cs[:], testPayload(t, sha256.Size))
+	return cs
+}
+
+func TestPayloadSizeLimiter_Index(t *testing.T) {
+	const maxSize = 64
+
+	t.Run("split object", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithIndex()),
+			testHeader(), testPayload(t, 3*maxSize+maxSize/2))
+
+		// parts, linking and index objects
+		require.Len(t, s.objects, 6)
+
+		index := s.objects[5]
+		require.Equal(t, index.ID(), ids.IndexID())
+
+		// index object is not a part of the split-chain
+		require.Nil(t, index.Parent())
+		require.Nil(t, index.SplitID())
+
+		val, ok := attributeValue(index, AttributeIndex)
+		require.True(t, ok)
+		require.Equal(t, ids.ParentID().String(), val)
+
+		entries, err := DecodeIndex(index.Payload())
+		require.NoError(t, err)
+		require.Len(t, entries, 4)
+
+		var off uint64
+
+		for i := range entries {
+			require.Equal(t, s.objects[i].ID(), entries[i].ID)
+			require.Equal(t, off, entries[i].Offset)
+			require.EqualValues(t, len(s.objects[i].Payload()), entries[i].Size)
+
+			off += entries[i].Size
+		}
+
+		require.EqualValues(t, 3*maxSize+maxSize/2, off)
+	})
+
+	t.Run("stored chain", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithIndex()),
+			testHeader(), testPayload(t, 3*maxSize))
+
+		db := meta.New(meta.WithPath(filepath.Join(t.TempDir(), "meta")), meta.WithPermissions(0600))
+		require.NoError(t, db.Open())
+		require.NoError(t, db.Init())
+
+		defer db.Close()
+
+		for _, obj := range s.objects {
+			require.NoError(t, meta.Put(db, obj.Object(), nil))
+		}
+
+		addr := objectSDK.NewAddress()
+		addr.SetContainerID(s.objects[0].ContainerID())
+		addr.SetObjectID(ids.ParentID())
+
+		_, err := meta.Exists(db, addr)
+
+		var si *objectSDK.SplitInfoError
+
+		require.True(t, errors.As(err, &si), err)
+
+		// index object written the last does not replace the last part
+		require.Equal(t, ids.ChildIDs()[len(ids.ChildIDs())-1], si.SplitInfo().LastPart())
+		require.Equal(t, ids.LinkID(), si.SplitInfo().Link())
+
+		indexAddr := objectSDK.NewAddress()
+		indexAddr.SetContainerID(s.objects[0].ContainerID())
+		indexAddr.SetObjectID(ids.IndexID())
+
+		index, err := meta.Get(db, indexAddr)
+		require.NoError(t, err)
+		require.Nil(t, index.GetParent())
+	})
+
+	t.Run("small object", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithIndex()),
+			testHeader(), testPayload(t, maxSize))
+
+		require.Len(t, s.objects, 1)
+		require.Nil(t, ids.IndexID())
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		_, err := DecodeIndex(make([]byte, indexEntrySize+1))
+		require.Error(t, err)
+	})
+}
+
+func TestPayloadSizeLimiter_PartsChannel(t *testing.T) {
+	const maxSize = 64
+
+	// collect sends parts to the unbuffered channel
+	// during the writing and returns received parts
+	collect := func(t *testing.T, payload []byte) (*memStorage, []Part, *AccessIdentifiers) {
+		var (
+			s     = new(memStorage)
+			ch    = make(chan Part)
+			parts []Part
+			done  = make(chan struct{})
+		)
+
+		go func() {
+			for p := range ch {
+				parts = append(parts, p)
+			}
+
+			close(done)
+		}()
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithPartsChannel(ch)),
+			testHeader(testAttribute("key", "val")), payload)
+
+		close(ch)
+		<-done
+
+		return s, parts, ids
+	}
+
+	t.Run("split object", func(t *testing.T) {
+		payload := testPayload(t, 3*maxSize+maxSize/2)
+
+		s, parts, ids := collect(t, payload)
+
+		// parts and linking object
+		require.Len(t, parts, 5)
+		require.Equal(t, objectIDs(s.objects), partIDs(parts))
+
+		// reconstruct the chain
+		var res []byte
+
+		for i, p := range parts[:4] {
+			require.Equal(t, p.ID, p.Header.ID())
+			require.Empty(t, p.Header.Payload())
+
+			if i > 0 {
+				require.Equal(t, parts[i-1].ID, p.Header.PreviousID())
+			}
+
+			res = append(res, p.Payload...)
+		}
+
+		require.Equal(t, payload, res)
+
+		link := parts[4]
+		require.Empty(t, link.Payload)
+		require.Equal(t, partIDs(parts[:4]), link.Header.Children())
+		require.Equal(t, ids.ParentID(), link.Header.Parent().ID())
+	})
+
+	t.Run("small object", func(t *testing.T) {
+		payload := testPayload(t, maxSize)
+
+		s, parts, _ := collect(t, payload)
+
+		require.Len(t, parts, 1)
+		require.Equal(t, s.objects[0].ID(), parts[0].ID)
+		require.Equal(t, payload, parts[0].Payload)
+	})
+
+	t.Run("stopped reader", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan Part)
+
+		go func() {
+			// reader stops after the first part,
+			// so the writer blocks on the next one
+			<-ch
+
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+
+		target := NewPayloadSizeLimiter(maxSize, new(memStorage).initializer(),
+			WithPartsChannel(ch), WithContext(ctx))
+
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(testPayload(t, 3*maxSize))
+		if err == nil {
+			_, err = target.Close()
+		}
+
+		require.True(t, errors.Is(err, context.Canceled), err)
+	})
+}
+
+func partIDs(parts []Part) []*objectSDK.ID {
+	ids := make([]*objectSDK.ID, len(parts))
+
+	for i := range parts {
+		ids[i] = parts[i].ID
+	}
+
+	return ids
+}
+
+type memCheckpointStore struct {
+	checkpoints []*Checkpoint
+}
+
+func (s *memCheckpointStore) SaveCheckpoint(cp *Checkpoint) error {
+	s.checkpoints = append(s.checkpoints, cp)
+	return nil
+}
+
+func TestPayloadSizeLimiter_Checkpoints(t *testing.T) {
+	const maxSize = 64
+
+	hdr := testHeader(testAttribute("key", "val"))
+	payload := testPayload(t, 5*maxSize+maxSize/2)
+
+	// uninterrupted writing
+	expected := writeObject(t, NewPayloadSizeLimiter(maxSize, new(memStorage).initializer()), hdr, payload)
+
+	t.Run("cadence", func(t *testing.T) {
+		for _, tc := range []struct {
+			parts   int
+			bytes   uint64
+			written []uint64
+		}{
+			{written: []uint64{maxSize, 2 * maxSize, 3 * maxSize, 4 * maxSize, 5 * maxSize}},
+			{parts: 2, written: []uint64{2 * maxSize, 4 * maxSize}},
+			{bytes: maxSize * 3 / 2, written: []uint64{2 * maxSize, 4 * maxSize}},
+			{parts: 3, bytes: maxSize * 4, written: []uint64{3 * maxSize}},
+		} {
+			store := new(memCheckpointStore)
+
+			writeObject(t, NewPayloadSizeLimiter(maxSize, new(memStorage).initializer(),
+				WithCheckpoints(store, tc.parts, tc.bytes)), hdr, payload)
+
+			written := make([]uint64, len(store.checkpoints))
+			for i := range store.checkpoints {
+				written[i] = store.checkpoints[i].Written
+			}
+
+			require.Equal(t, tc.written, written)
+		}
+	})
+
+	t.Run("resume after crash", func(t *testing.T) {
+		var (
+			s     = new(memStorage)
+			store = new(memCheckpointStore)
+		)
+
+		// crash in the middle of the 4th object, 3rd object is not checkpointed
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithCheckpoints(store, 2, 0))
+		require.NoError(t, target.WriteHeader(hdr))
+
+		_, err := target.Write(payload[:3*maxSize+maxSize/2])
+		require.NoError(t, err)
+
+		require.Len(t, store.checkpoints, 1)
+
+		cp := store.checkpoints[0]
+		require.EqualValues(t, 2*maxSize, cp.Written)
+		require.Equal(t, objectIDs(s.objects[:2]), cp.Parts)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithResume(cp)),
+			hdr, payload[cp.Written:])
+
+		require.Equal(t, expected.ParentID(), ids.ParentID())
+
+		par := ids.Parent()
+		require.Equal(t, expected.Parent().PayloadChecksum(), par.PayloadChecksum())
+		require.Equal(t, expected.Parent().PayloadHomomorphicHash(), par.PayloadHomomorphicHash())
+		require.EqualValues(t, len(payload), par.PayloadSize())
+
+		link := s.objects[len(s.objects)-1]
+		children := link.Children()
+		require.Len(t, children, 6)
+		require.Equal(t, cp.Parts, children[:2])
+
+		mObjects := make(map[string]*object.RawObject, len(s.objects))
+		for i := range s.objects {
+			mObjects[s.objects[i].ID().String()] = s.objects[i]
+		}
+
+		var restored []byte
+
+		for i := range children {
+			child := mObjects[children[i].String()]
+			require.Equal(t, cp.SplitID, child.SplitID())
+
+			if i > 0 {
+				require.Equal(t, children[i-1], child.PreviousID())
+			}
+
+			restored = append(restored, child.Payload()...)
+		}
+
+		require.Equal(t, payload, restored)
+	})
+
+	t.Run("invalid checkpoint", func(t *testing.T) {
+		target := NewPayloadSizeLimiter(maxSize, new(memStorage).initializer(), WithResume(&Checkpoint{}))
+
+		err := target.WriteHeader(hdr)
+		require.True(t, errors.Is(err, errInvalidCheckpoint))
+	})
+}
+
+// dedupStorage is a content-addressed storage which identifies
+// objects with payload by the payload checksum, so the parts with
+// the same payload are stored once and shared between the chains.
+type dedupStorage struct {
+	refs map[string]int
+
+	links []*objectSDK.Object
+}
+
+type dedupTarget struct {
+	storage *dedupStorage
+
+	hdr *object.RawObject
+
+	payload []byte
+}
+
+func (s *dedupStorage) initializer() TargetInitializer {
+	return func() ObjectTarget {
+		return &dedupTarget{storage: s}
+	}
+}
+
+func (s *dedupStorage) shared(id *objectSDK.ID) bool {
+	return s.refs[id.String()] > 1
+}
+
+func (t *dedupTarget) WriteHeader(hdr *object.RawObject) error {
+	t.hdr = hdr
+	return nil
+}
+
+func (t *dedupTarget) Write(p []byte) (int, error) {
+	t.payload = append(t.payload, p...)
+	return len(p), nil
+}
+
+func (t *dedupTarget) Close() (*AccessIdentifiers, error) {
+	id := objectSDK.NewID()
+
+	if len(t.payload) > 0 {
+		id.SetSHA256(sha256.Sum256(t.payload))
+	} else {
+		var err error
+
+		if id, err = contentID(t.hdr); err != nil {
+			return nil, err
+		}
+	}
+
+	t.storage.refs[id.String()]++
+
+	if len(t.hdr.Children()) > 0 {
+		link := objectSDK.NewRawFromV2(t.hdr.ToV2())
+		link.SetID(id)
+
+		t.storage.links = append(t.storage.links, link.Object())
+	}
+
+	return new(AccessIdentifiers).WithSelfID(id), nil
+}
+
+func TestPayloadSizeLimiter_SharedParts(t *testing.T) {
+	const maxSize = 64
+
+	var (
+		s = &dedupStorage{refs: make(map[string]int)}
+
+		shared   = testPayload(t, 2*maxSize)
+		payloadA = append(append([]byte{}, shared...), testPayload(t, maxSize)...)
+		payloadB = append(append([]byte{}, shared...), testPayload(t, maxSize)...)
+	)
+
+	for _, payload := range [][]byte{payloadA, payloadB} {
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithSharedParts(s.shared)),
+			testHeader(), payload)
+	}
+
+	require.Len(t, s.links, 2)
+
+	linkA, linkB := s.links[0], s.links[1]
+	childrenA, childrenB := linkA.Children(), linkB.Children()
+
+	require.Equal(t, childrenA[:2], childrenB[:2])
+
+	// parts are shared after the 2nd chain is written
+	sharedA, err := SharedChildren(linkA)
+	require.NoError(t, err)
+	require.Empty(t, sharedA)
+
+	sharedB, err := SharedChildren(linkB)
+	require.NoError(t, err)
+	require.Equal(t, childrenB[:2], sharedB)
+
+	tombstone := func(links ...*objectSDK.Object) *objectSDK.Tombstone {
+		var members []*objectSDK.ID
+
+		for i := range links {
+			members = append(members, links[i].Children()...)
+			members = append(members, links[i].ID())
+		}
+
+		tomb := objectSDK.NewTombstone()
+		tomb.SetMembers(members)
+
+		return tomb
+	}
+
+	t.Run("remove chain w/o marks", func(t *testing.T) {
+		res, err := SafeToDelete(tombstone(linkA), s.links)
+		require.NoError(t, err)
+		require.Equal(t, []*objectSDK.ID{childrenA[2], linkA.ID()}, res)
+	})
+
+	t.Run("remove chain with marks", func(t *testing.T) {
+		res, err := SafeToDelete(tombstone(linkB), s.links)
+		require.NoError(t, err)
+		require.Equal(t, []*objectSDK.ID{childrenB[2], linkB.ID()}, res)
+	})
+
+	t.Run("remove last chain", func(t *testing.T) {
+		tomb := tombstone(linkB)
+
+		res, err := SafeToDelete(tomb, []*objectSDK.Object{linkB})
+		require.NoError(t, err)
+		require.Equal(t, tomb.Members(), res)
+	})
+
+	t.Run("remove both chains", func(t *testing.T) {
+		tomb := tombstone(linkA, linkB)
+
+		res, err := SafeToDelete(tomb, s.links)
+		require.NoError(t, err)
+		require.Equal(t, tomb.Members(), res)
+	})
+}
+
+func TestPayloadSizeLimiter_StorageTier(t *testing.T) {
+	const maxSize = 64
+
+	tiers := func(objs []*object.RawObject) []string {
+		res := make([]string, len(objs))
+
+		for i := range objs {
+			res[i], _ = attributeValue(objs[i], AttributeStorageTier)
+		}
+
+		return res
+	}
+
+	parentTier := func(t *testing.T, ids *AccessIdentifiers) string {
+		val, ok := attributeValue(object.NewRawFrom(objectSDK.NewRawFrom(ids.Parent())), AttributeStorageTier)
+		require.True(t, ok)
+
+		return val
+	}
+
+	// source value is overwritten
+	hdr := testHeader(testAttribute(AttributeStorageTier, "archive"), testAttribute("key", "val"))
+
+	t.Run("all objects", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithStorageTier("cold"), WithIndex()),
+			hdr, testPayload(t, 3*maxSize+maxSize/2))
+
+		// parts, linking and index objects
+		require.Equal(t, []string{"cold", "cold", "cold", "cold", "cold", "cold"}, tiers(s.objects))
+		require.Equal(t, "cold", parentTier(t, ids))
+
+		// source attributes are kept in the parent only
+		val, ok := attributeValue(object.NewRawFrom(objectSDK.NewRawFrom(ids.Parent())), "key")
+		require.True(t, ok)
+		require.Equal(t, "val", val)
+	})
+
+	t.Run("per part", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(),
+			WithStorageTier("cold"),
+			WithPartStorageTier(func(part int) string {
+				if part == 0 {
+					return "hot"
+				}
+
+				return ""
+			}),
+		), hdr, testPayload(t, 3*maxSize+maxSize/2))
+
+		require.Equal(t, []string{"hot", "cold", "cold", "cold", "cold"}, tiers(s.objects))
+		require.Equal(t, "cold", parentTier(t, ids))
+	})
+
+	t.Run("per part w/o default", func(t *testing.T) {
+		s := new(memStorage)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(),
+			WithPartStorageTier(func(part int) string {
+				return []string{"hot", "warm"}[part%2]
+			}),
+		), hdr, testPayload(t, 3*maxSize+maxSize/2))
+
+		require.Equal(t, []string{"hot", "warm", "hot", "warm", ""}, tiers(s.objects))
+	})
+
+	t.Run("single object", func(t *testing.T) {
+		s := new(memStorage)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(),
+			WithStorageTier("cold"),
+			WithPartStorageTier(func(int) string { return "hot" }),
+		), hdr, testPayload(t, maxSize))
+
+		require.Equal(t, []string{"hot"}, tiers(s.objects))
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		s := new(memStorage)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer()), hdr, testPayload(t, maxSize))
+
+		require.Equal(t, []string{"archive"}, tiers(s.objects))
+	})
+}
+
+// stallingTarget is a memTarget which stalls
+// on the write of the specified chunk.
+type stallingTarget struct {
+	*memTarget
+
+	writes, stallOn int
+
+	release chan struct{}
+}
+
+func (t *stallingTarget) Write(p []byte) (int, error) {
+	t.writes++
+
+	if t.writes == t.stallOn {
+		<-t.release
+	}
+
+	return t.memTarget.Write(p)
+}
+
+func TestPayloadSizeLimiter_ChunkWriteTimeout(t *testing.T) {
+	const (
+		maxSize = 64
+		timeout = 10 * time.Millisecond
+	)
+
+	newTarget := func(stallOn int) (ObjectTarget, chan struct{}) {
+		var (
+			s       = new(memStorage)
+			release = make(chan struct{})
+		)
+
+		// each object of the split-chain is written to the new target
+		return NewPayloadSizeLimiter(maxSize, func() ObjectTarget {
+			return &stallingTarget{
+				memTarget: &memTarget{storage: s},
+				stallOn:   stallOn,
+				release:   release,
+			}
+		}, WithChunkWriteTimeout(timeout)), release
+	}
+
+	t.Run("fast target", func(t *testing.T) {
+		target, _ := newTarget(-1)
+
+		writeObject(t, target, testHeader(), testPayload(t, 3*maxSize))
+	})
+
+	t.Run("stalled target", func(t *testing.T) {
+		target, release := newTarget(2)
+		defer close(release)
+
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(testPayload(t, maxSize/2))
+		require.NoError(t, err)
+
+		start := time.Now()
+
+		_, err = target.Write(testPayload(t, maxSize/2))
+		require.True(t, errors.Is(err, ErrChunkWriteTimeout))
+		require.Less(t, int64(time.Since(start)), int64(time.Second))
+
+		// target is abandoned
+		_, err = target.Write(testPayload(t, maxSize/2))
+		require.True(t, errors.Is(err, ErrChunkWriteTimeout))
+
+		_, err = target.Close()
+		require.True(t, errors.Is(err, ErrChunkWriteTimeout))
+	})
+}
+
+func TestPayloadSizeLimiter_ReplicationHint(t *testing.T) {
+	const maxSize = 64
+
+	require.Panics(t, func() { WithReplicationHint(0) })
+
+	hints := func(objs []*object.RawObject) []string {
+		res := make([]string, len(objs))
+
+		for i := range objs {
+			res[i], _ = attributeValue(objs[i], AttributeReplicationHint)
+		}
+
+		return res
+	}
+
+	// source value is overwritten
+	hdr := testHeader(testAttribute(AttributeReplicationHint, "1"), testAttribute("key", "val"))
+
+	t.Run("split-chain", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithReplicationHint(5), WithIndex()),
+			hdr, testPayload(t, 3*maxSize+maxSize/2))
+
+		// parts, linking and index objects
+		require.Equal(t, []string{"5", "5", "5", "5", "5", "5"}, hints(s.objects))
+
+		par := object.NewRawFrom(objectSDK.NewRawFrom(ids.Parent()))
+
+		val, ok := attributeValue(par, AttributeReplicationHint)
+		require.True(t, ok)
+		require.Equal(t, "5", val)
+	})
+
+	t.Run("single object", func(t *testing.T) {
+		s := new(memStorage)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithReplicationHint(3)),
+			hdr, testPayload(t, maxSize))
+
+		require.Equal(t, []string{"3"}, hints(s.objects))
+
+		val, ok := attributeValue(s.objects[0], "key")
+		require.True(t, ok)
+		require.Equal(t, "val", val)
+	})
+
+	t.Run("no hint", func(t *testing.T) {
+		s := new(memStorage)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer()), testHeader(), testPayload(t, 2*maxSize))
+
+		require.Equal(t, []string{"", "", ""}, hints(s.objects))
+	})
+}
+
+func TestPayloadSizeLimiter_ACLRef(t *testing.T) {
+	const maxSize = 64
+
+	ref := []byte{0xAC, 0x1, 0x2, 0x3}
+
+	refs := func(objs []*object.RawObject) []string {
+		res := make([]string, len(objs))
+
+		for i := range objs {
+			res[i], _ = attributeValue(objs[i], AttributeACLRef)
+		}
+
+		return res
+	}
+
+	// source value is overwritten
+	hdr := testHeader(testAttribute(AttributeACLRef, "ff"))
+
+	t.Run("split-chain", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithACLRef(ref), WithIndex()),
+			hdr, testPayload(t, 3*maxSize+maxSize/2))
+
+		// parts, linking and index objects
+		require.Equal(t, []string{"ac010203", "ac010203", "ac010203", "ac010203", "ac010203", "ac010203"}, refs(s.objects))
+
+		// logical object access is checked by the parent header
+		par := object.NewRawFrom(objectSDK.NewRawFrom(ids.Parent()))
+
+		val, ok := attributeValue(par, AttributeACLRef)
+		require.True(t, ok)
+		require.Equal(t, "ac010203", val)
+	})
+
+	t.Run("single object", func(t *testing.T) {
+		s := new(memStorage)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithACLRef(ref)), hdr, testPayload(t, maxSize))
+
+		require.Equal(t, []string{"ac010203"}, refs(s.objects))
+	})
+
+	t.Run("no reference", func(t *testing.T) {
+		for _, opts := range [][]Option{nil, {WithACLRef(nil)}} {
+			s := new(memStorage)
+
+			writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), opts...), testHeader(), testPayload(t, 2*maxSize))
+
+			require.Equal(t, []string{"", "", ""}, refs(s.objects))
+		}
+	})
+}
+
+// memWAL records appended headers along with the number
+// of the objects committed to the storage at the moment.
+type memWAL struct {
+	storage *memStorage
+
+	hdrs []*object.RawObject
+
+	committed []int
+}
+
+func (w *memWAL) Append(hdr *object.RawObject) error {
+	data, err := hdr.Marshal()
+	if err != nil {
+		return err
+	}
+
+	cp := object.NewRaw()
+	if err := cp.Unmarshal(data); err != nil {
+		return err
+	}
+
+	w.hdrs = append(w.hdrs, cp)
+	w.committed = append(w.committed, len(w.storage.objects))
+
+	return nil
+}
+
+func payloadChecksums(objs []*object.RawObject) [][]byte {
+	res := make([][]byte, len(objs))
+
+	for i := range objs {
+		res[i] = objs[i].PayloadChecksum().Sum()
+	}
+
+	return res
+}
+
+// crashingTarget is a memTarget which fails on Close
+// like the storage crashed before commit.
+type crashingTarget struct {
+	*memTarget
+
+	crash bool
+}
+
+func (t *crashingTarget) Close() (*AccessIdentifiers, error) {
+	if t.crash {
+		return nil, errors.New("crash")
+	}
+
+	return t.memTarget.Close()
+}
+
+func TestPayloadSizeLimiter_WAL(t *testing.T) {
+	const maxSize = 64
+
+	t.Run("appends precede commits", func(t *testing.T) {
+		s := new(memStorage)
+		wal := &memWAL{storage: s}
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithWAL(wal), WithIndex()),
+			testHeader(), testPayload(t, 3*maxSize+maxSize/2))
+
+		// parts, linking and index objects
+		require.Len(t, s.objects, 6)
+		require.Equal(t, []int{0, 1, 2, 3, 4, 5}, wal.committed)
+		require.Equal(t, payloadChecksums(s.objects), payloadChecksums(wal.hdrs))
+	})
+
+	t.Run("crash", func(t *testing.T) {
+		const crashOn = 3
+
+		var (
+			s      = new(memStorage)
+			wal    = &memWAL{storage: s}
+			closed int
+		)
+
+		target := NewPayloadSizeLimiter(maxSize, func() ObjectTarget {
+			closed++
+
+			return &crashingTarget{
+				memTarget: &memTarget{storage: s},
+				crash:     closed == crashOn,
+			}
+		}, WithWAL(wal))
+
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(testPayload(t, 4*maxSize))
+		require.Error(t, err)
+
+		// WAL contains all committed objects and the uncommitted one
+		require.Len(t, s.objects, crashOn-1)
+		require.Len(t, wal.hdrs, crashOn)
+		require.Equal(t, payloadChecksums(s.objects), payloadChecksums(wal.hdrs[:crashOn-1]))
+
+		// uncommitted object is recoverable: it follows the last committed one
+		uncommitted := wal.hdrs[crashOn-1]
+		require.Nil(t, uncommitted.ID())
+		require.Equal(t, s.objects[crashOn-2].ID(), uncommitted.PreviousID())
+	})
+
+	t.Run("append failure", func(t *testing.T) {
+		s := new(memStorage)
+
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithWAL(walFunc(func(*object.RawObject) error {
+			return errors.New("any error")
+		})))
+
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(testPayload(t, maxSize))
+		require.NoError(t, err)
+
+		_, err = target.Close()
+		require.Error(t, err)
+		require.Empty(t, s.objects)
+	})
+}
+
+type walFunc func(*object.RawObject) error
+
+func (f walFunc) Append(hdr *object.RawObject) error {
+	return f(hdr)
+}
+
+func TestNewPayloadSizeLimiterWithHashers(t *testing.T) {
+	const (
+		maxSize = 64
+		attrMD5 = "MD5"
+	)
+
+	md5Hasher := func(obj *object.RawObject) (hash.Hash, func([]byte)) {
+		return md5.New(), func(cs []byte) {
+			addAttribute(obj, attrMD5, hex.EncodeToString(cs))
+		}
+	}
+
+	hashers := []ChecksumHasherFactory{SHA256ChecksumHasher, md5Hasher}
+
+	t.Run("split-chain", func(t *testing.T) {
+		s := new(memStorage)
+		payload := testPayload(t, 2*maxSize+maxSize/2)
+
+		ids := writeObject(t, NewPayloadSizeLimiterWithHashers(maxSize, s.initializer(), hashers), testHeader(), payload)
+
+		require.Len(t, s.objects, 4)
+
+		for i, part := range s.objects[:3] {
+			from, to := i*maxSize, (i+1)*maxSize
+			if to > len(payload) {
+				to = len(payload)
+			}
+
+			partSHA := sha256.Sum256(payload[from:to])
+			partMD5 := md5.Sum(payload[from:to])
+
+			require.Equal(t, partSHA[:], part.PayloadChecksum().Sum())
+			require.Nil(t, part.PayloadHomomorphicHash())
+
+			val, ok := attributeValue(part, attrMD5)
+			require.True(t, ok)
+			require.Equal(t, hex.EncodeToString(partMD5[:]), val)
+		}
+
+		// parent gets the same set of checksums
+		par := object.NewRawFrom(objectSDK.NewRawFrom(ids.Parent()))
+
+		parSHA := sha256.Sum256(payload)
+		parMD5 := md5.Sum(payload)
+
+		require.Equal(t, parSHA[:], par.PayloadChecksum().Sum())
+		require.Nil(t, par.PayloadHomomorphicHash())
+
+		val, ok := attributeValue(par, attrMD5)
+		require.True(t, ok)
+		require.Equal(t, hex.EncodeToString(parMD5[:]), val)
+	})
+
+	t.Run("checkpoints", func(t *testing.T) {
+		s := new(memStorage)
+
+		target := NewPayloadSizeLimiterWithHashers(maxSize, s.initializer(), hashers,
+			WithCheckpoints(new(memCheckpointStore), 1, 0))
+
+		require.True(t, errors.Is(target.WriteHeader(testHeader()), errCheckpointsWithCustomHashers))
+	})
+}
+
+func TestBLAKE2bChecksumHasher(t *testing.T) {
+	const maxSize = 64
+
+	t.Run("instead of SHA256", func(t *testing.T) {
+		s := new(memStorage)
+		payload := testPayload(t, 2*maxSize+maxSize/2)
+
+		hashers := []ChecksumHasherFactory{BLAKE2bChecksumHasher, TZChecksumHasher}
+
+		ids := writeObject(t, NewPayloadSizeLimiterWithHashers(maxSize, s.initializer(), hashers), testHeader(), payload)
+
+		require.Len(t, s.objects, 4)
+
+		for i, part := range s.objects[:3] {
+			from, to := i*maxSize, (i+1)*maxSize
+			if to > len(payload) {
+				to = len(payload)
+			}
+
+			cs, ok := PayloadBLAKE2bChecksum(part.Object())
+			require.True(t, ok)
+			require.Equal(t, BLAKE2bChecksum(blake2b.Sum256(payload[from:to])), cs)
+			require.Nil(t, part.PayloadChecksum())
+		}
+
+		cs, ok := PayloadBLAKE2bChecksum(object.NewFromSDK(ids.Parent()))
+		require.True(t, ok)
+		require.Equal(t, BLAKE2bChecksum(blake2b.Sum256(payload)), cs)
+	})
+
+	t.Run("alongside SHA256", func(t *testing.T) {
+		s := new(memStorage)
+
+		hashers := []ChecksumHasherFactory{SHA256ChecksumHasher, BLAKE2bChecksumHasher}
+
+		writeObject(t, NewPayloadSizeLimiterWithHashers(maxSize, s.initializer(), hashers), testHeader(), nil)
+
+		require.Len(t, s.objects, 1)
+
+		emptySHA := sha256.Sum256(nil)
+		require.Equal(t, emptySHA[:], s.objects[0].PayloadChecksum().Sum())
+
+		cs, ok := PayloadBLAKE2bChecksum(s.objects[0].Object())
+		require.True(t, ok)
+		require.Equal(t, "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8", hex.EncodeToString(cs[:]))
+	})
+
+	t.Run("wrong length", func(t *testing.T) {
+		_, w := BLAKE2bChecksumHasher(object.NewRaw())
+
+		require.Panics(t, func() { w(make([]byte, blake2b.Size256-1)) })
+	})
+}
+
+func TestPayloadSizeLimiter_Blake3Checksum(t *testing.T) {
+	const maxSize = 64
+
+	t.Run("split-chain", func(t *testing.T) {
+		s := new(memStorage)
+		payload := testPayload(t, 2*maxSize+maxSize/2)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithBlake3Checksum(true)), testHeader(), payload)
+
+		require.Len(t, s.objects, 4)
+
+		for i, part := range s.objects[:3] {
+			from, to := i*maxSize, (i+1)*maxSize
+			if to > len(payload) {
+				to = len(payload)
+			}
+
+			partSHA := sha256.Sum256(payload[from:to])
+			require.Equal(t, partSHA[:], part.PayloadChecksum().Sum())
+			require.NotNil(t, part.PayloadHomomorphicHash())
+
+			cs, ok := PayloadBLAKE3Checksum(part.Object())
+			require.True(t, ok)
+			require.Equal(t, BLAKE3Checksum(blake3.Sum256(payload[from:to])), cs)
+		}
+
+		cs, ok := PayloadBLAKE3Checksum(object.NewFromSDK(ids.Parent()))
+		require.True(t, ok)
+		require.Equal(t, BLAKE3Checksum(blake3.Sum256(payload)), cs)
+	})
+
+	t.Run("empty payload", func(t *testing.T) {
+		s := new(memStorage)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithBlake3Checksum(true), WithHomomorphicHashDisabled()),
+			testHeader(), nil)
+
+		require.Len(t, s.objects, 1)
+		require.Nil(t, s.objects[0].PayloadHomomorphicHash())
+
+		cs, ok := PayloadBLAKE3Checksum(s.objects[0].Object())
+		require.True(t, ok)
+		require.Equal(t, "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262", hex.EncodeToString(cs[:]))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		s := new(memStorage)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithBlake3Checksum(false)), testHeader(), testPayload(t, maxSize))
+
+		_, ok := PayloadBLAKE3Checksum(s.objects[0].Object())
+		require.False(t, ok)
+	})
+
+	t.Run("checkpoints", func(t *testing.T) {
+		target := NewPayloadSizeLimiter(maxSize, new(memStorage).initializer(), WithBlake3Checksum(true),
+			WithCheckpoints(new(memCheckpointStore), 1, 0))
+
+		require.True(t, errors.Is(target.WriteHeader(testHeader()), errCheckpointsWithCustomHashers))
+	})
+}
+
+func TestPayloadSizeLimiter_HomomorphicHashDisabled(t *testing.T) {
+	const maxSize = 64
+
+	payload := testPayload(t, 3*maxSize+maxSize/2)
+
+	t.Run("split-chain", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithHomomorphicHashDisabled(), WithIndex()),
+			testHeader(), payload)
+
+		// parts, linking and index objects
+		require.Len(t, s.objects, 6)
+
+		for i := range s.objects {
+			require.Nil(t, s.objects[i].PayloadHomomorphicHash())
+
+			cs := sha256.Sum256(s.objects[i].Payload())
+			require.Equal(t, cs[:], s.objects[i].PayloadChecksum().Sum())
+		}
+
+		par := ids.Parent()
+		require.Nil(t, par.PayloadHomomorphicHash())
+
+		cs := sha256.Sum256(payload)
+		require.Equal(t, cs[:], par.PayloadChecksum().Sum())
+	})
+
+	t.Run("single object", func(t *testing.T) {
+		s := new(memStorage)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithHomomorphicHashDisabled()),
+			testHeader(), payload[:maxSize])
+
+		require.Len(t, s.objects, 1)
+		require.Nil(t, s.objects[0].PayloadHomomorphicHash())
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		var (
+			s     = new(memStorage)
+			store = new(memCheckpointStore)
+			hdr   = testHeader()
+		)
+
+		expected := writeObject(t, NewPayloadSizeLimiter(maxSize, new(memStorage).initializer(), WithHomomorphicHashDisabled()),
+			hdr, payload)
+
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithHomomorphicHashDisabled(), WithCheckpoints(store, 1, 0))
+		require.NoError(t, target.WriteHeader(hdr))
+
+		_, err := target.Write(payload[:2*maxSize+1])
+		require.NoError(t, err)
+
+		require.Len(t, store.checkpoints, 2)
+
+		cp := store.checkpoints[1]
+		require.Empty(t, cp.PayloadHomomorphicHash)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithHomomorphicHashDisabled(), WithResume(cp)),
+			hdr, payload[cp.Written:])
+
+		require.Equal(t, expected.ParentID(), ids.ParentID())
+		require.Nil(t, ids.Parent().PayloadHomomorphicHash())
+	})
+}
+
+func TestPayloadSizeLimiter_Context(t *testing.T) {
+	const maxSize = 64
+
+	payload := testPayload(t, 4*maxSize)
+
+	t.Run("cancel between writes", func(t *testing.T) {
+		s := new(memStorage)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithContext(ctx))
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(payload[:2*maxSize+1])
+		require.NoError(t, err)
+		require.Len(t, s.objects, 2)
+
+		released := objectIDs(s.objects)
+
+		cancel()
+
+		_, err = target.Write(payload[2*maxSize+1:])
+		require.True(t, errors.Is(err, context.Canceled))
+
+		_, err = target.Close()
+		require.True(t, errors.Is(err, context.Canceled))
+
+		// released objects are not affected
+		require.Equal(t, released, objectIDs(s.objects))
+	})
+
+	t.Run("cancel within write", func(t *testing.T) {
+		s := new(memStorage)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		target := NewPayloadSizeLimiter(maxSize, func() ObjectTarget {
+			// cancel after the 2nd object is started
+			if len(s.objects) == 1 {
+				cancel()
+			}
+
+			return &memTarget{storage: s}
+		}, WithContext(ctx))
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(payload)
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Len(t, s.objects, 1)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+
+		<-ctx.Done()
+
+		target := NewPayloadSizeLimiter(maxSize, new(memStorage).initializer(), WithContext(ctx))
+		require.True(t, errors.Is(target.WriteHeader(testHeader()), context.DeadlineExceeded))
+	})
+
+	t.Run("target failure", func(t *testing.T) {
+		s := new(memStorage)
+
+		target := NewPayloadSizeLimiter(maxSize, func() ObjectTarget {
+			return &crashingTarget{memTarget: &memTarget{storage: s}, crash: true}
+		}, WithContext(context.Background()))
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(payload)
+		require.Error(t, err)
+		require.False(t, errors.Is(err, context.Canceled))
+	})
+}
+
+func TestPayloadSizeLimiter_Receipt(t *testing.T) {
+	const maxSize = 64
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer := WithReceiptSigner(func(data []byte) ([]byte, error) {
+		h := sha256.Sum256(data)
+		return ecdsa.SignASN1(rand.Reader, key, h[:])
+	})
+
+	verifyReceipt := func(t *testing.T, ids *AccessIdentifiers, id *objectSDK.ID, payload []byte) {
+		r, err := DecodeReceipt(ids.Receipt())
+		require.NoError(t, err)
+
+		require.Equal(t, id, r.ObjectID)
+		require.EqualValues(t, len(payload), r.PayloadSize)
+		require.Equal(t, sha256.Sum256(payload), r.PayloadChecksum)
+
+		// receipt is encoded in the canonical form
+		data := append(id.ToV2().GetValue(), make([]byte, 8)...)
+		binary.BigEndian.PutUint64(data[sha256.Size:], uint64(len(payload)))
+		cs := sha256.Sum256(payload)
+		data = append(data, cs[:]...)
+
+		require.Equal(t, data, r.Data())
+		require.Equal(t, ids.Receipt(), r.Encode())
+
+		h := sha256.Sum256(data)
+		require.True(t, ecdsa.VerifyASN1(&key.PublicKey, h[:], r.Signature))
+
+		// any modification breaks the signature
+		r.PayloadSize++
+		h = sha256.Sum256(r.Data())
+		require.False(t, ecdsa.VerifyASN1(&key.PublicKey, h[:], r.Signature))
+	}
+
+	t.Run("split-chain", func(t *testing.T) {
+		s := new(memStorage)
+		payload := testPayload(t, 2*maxSize+maxSize/2)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), signer), testHeader(), payload)
+
+		verifyReceipt(t, ids, ids.ParentID(), payload)
+	})
+
+	t.Run("single object", func(t *testing.T) {
+		s := new(memStorage)
+		payload := testPayload(t, maxSize/2)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), signer), testHeader(), payload)
+
+		require.Nil(t, ids.ParentID())
+		verifyReceipt(t, ids, ids.SelfID(), payload)
+	})
+
+	t.Run("no signer", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer()), testHeader(), testPayload(t, maxSize))
+
+		require.Nil(t, ids.Receipt())
+	})
+
+	t.Run("signer failure", func(t *testing.T) {
+		s := new(memStorage)
+		errSign := errors.New("any error")
+
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithReceiptSigner(func([]byte) ([]byte, error) {
+			return nil, errSign
+		}))
+
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(testPayload(t, maxSize))
+		require.NoError(t, err)
+
+		_, err = target.Close()
+		require.True(t, errors.Is(err, errSign))
+	})
+
+	t.Run("invalid receipt", func(t *testing.T) {
+		_, err := DecodeReceipt(make([]byte, receiptDataSize-1))
+		require.True(t, errors.Is(err, errInvalidReceipt))
+	})
+}
+
+func TestPayloadSizeLimiter_ChildIDs(t *testing.T) {
+	const maxSize = 64
+
+	t.Run("split-chain", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer()), testHeader(), testPayload(t, 3*maxSize+1))
+
+		require.Len(t, s.objects, 5)
+
+		require.Equal(t, objectIDs(s.objects[:4]), ids.ChildIDs())
+		require.Equal(t, s.objects[4].ID(), ids.LinkID())
+		require.Equal(t, s.objects[4].Children(), ids.ChildIDs())
+	})
+
+	t.Run("single object", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer()), testHeader(), testPayload(t, maxSize))
+
+		require.Len(t, s.objects, 1)
+
+		require.Equal(t, []*objectSDK.ID{ids.SelfID()}, ids.ChildIDs())
+		require.Nil(t, ids.LinkID())
+	})
+}
+
+// maxSizeFunc is a MaxSizeSource implemented by a function.
+type maxSizeFunc func(*cid.ID) (uint64, error)
+
+func (f maxSizeFunc) MaxObjectSize(id *cid.ID) (uint64, error) {
+	return f(id)
+}
+
+func TestPayloadSizeLimiter_MaxSizeSource(t *testing.T) {
+	const maxSize = 64
+
+	var (
+		hdr     = testHeader()
+		limit   uint64
+		queried []*cid.ID
+	)
+
+	src := WithMaxSizeSource(maxSizeFunc(func(id *cid.ID) (uint64, error) {
+		queried = append(queried, id)
+		return limit, nil
+	}))
+
+	t.Run("resolved size", func(t *testing.T) {
+		s := new(memStorage)
+		limit = maxSize / 4
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), src), hdr, testPayload(t, maxSize))
+
+		// 4 children and linking object
+		require.Len(t, s.objects, 5)
+
+		for i := range s.objects[:4] {
+			require.EqualValues(t, limit, s.objects[i].PayloadSize())
+		}
+
+		for i := range queried {
+			require.Equal(t, hdr.ContainerID(), queried[i])
+		}
+	})
+
+	t.Run("changed size", func(t *testing.T) {
+		s := new(memStorage)
+		limit = maxSize / 4
+
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), src)
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(testPayload(t, maxSize/4))
+		require.NoError(t, err)
+
+		limit = maxSize / 2
+
+		_, err = target.Write(testPayload(t, 1))
+		require.True(t, errors.Is(err, ErrMaxSizeChanged))
+
+		_, err = target.Close()
+		require.True(t, errors.Is(err, ErrMaxSizeChanged))
+	})
+
+	t.Run("source failure", func(t *testing.T) {
+		s := new(memStorage)
+		errSrc := errors.New("any error")
+
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithMaxSizeSource(maxSizeFunc(func(*cid.ID) (uint64, error) {
+			return 0, errSrc
+		})))
+
+		require.True(t, errors.Is(target.WriteHeader(testHeader()), errSrc))
+
+		target = NewPayloadSizeLimiter(maxSize, s.initializer(), WithMaxSizeSource(maxSizeFunc(func(*cid.ID) (uint64, error) {
+			return 0, nil
+		})))
+
+		require.Error(t, target.WriteHeader(testHeader()))
+	})
+}
+
+func TestPayloadSizeLimiter_ProgressCallback(t *testing.T) {
+	const maxSize = 64
+
+	type progress struct {
+		written  uint64
+		released int
+	}
+
+	var (
+		s   = new(memStorage)
+		res []progress
+	)
+
+	target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithProgressCallback(func(written uint64, released int) {
+		res = append(res, progress{written, released})
+	}))
+
+	require.NoError(t, target.WriteHeader(testHeader()))
+
+	_, err := target.Write(testPayload(t, 3*maxSize+1))
+	require.NoError(t, err)
+
+	require.Equal(t, []progress{
+		{maxSize, 1},
+		{2 * maxSize, 2},
+		{3 * maxSize, 3},
+		{3*maxSize + 1, 3}, // end of Write
+	}, res)
+
+	_, err = target.Close()
+	require.NoError(t, err)
+
+	// last child and linking object
+	require.Equal(t, []progress{
+		{3*maxSize + 1, 4},
+		{3*maxSize + 1, 5},
+	}, res[4:])
+
+	require.Len(t, s.objects, 5)
+}
+
+func TestPayloadSizeLimiter_RecordSplit(t *testing.T) {
+	const (
+		maxSize        = 1024
+		recordsPerPart = 3
+	)
+
+	writeRecords := func(t *testing.T, s *memStorage, records [][]byte) {
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithRecordSplit(recordsPerPart))
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		rt, ok := target.(RecordTarget)
+		require.True(t, ok)
+
+		for i := range records {
+			// records are written in several chunks
+			half := len(records[i]) / 2
+
+			_, err := target.Write(records[i][:half])
+			require.NoError(t, err)
+
+			_, err = target.Write(records[i][half:])
+			require.NoError(t, err)
+
+			rt.RecordBoundary()
+		}
+
+		_, err := target.Close()
+		require.NoError(t, err)
+	}
+
+	t.Run("by records", func(t *testing.T) {
+		s := new(memStorage)
+
+		records := make([][]byte, 3*recordsPerPart+1)
+		for i := range records {
+			records[i] = testPayload(t, 10+i)
+		}
+
+		writeRecords(t, s, records)
+
+		// 4 children and linking object
+		require.Len(t, s.objects, 5)
+
+		var payload []byte
+
+		for i, part := range s.objects[:4] {
+			from := i * recordsPerPart
+			to := from + recordsPerPart
+			if to > len(records) {
+				to = len(records)
+			}
+
+			require.Equal(t, bytes.Join(records[from:to], nil), part.Payload())
+
+			payload = append(payload, part.Payload()...)
+		}
+
+		par := object.NewRawFrom(objectSDK.NewRawFrom(s.objects[4].Parent()))
+		require.EqualValues(t, len(payload), par.PayloadSize())
+
+		cs := sha256.Sum256(payload)
+		require.Equal(t, cs[:], par.PayloadChecksum().Sum())
+	})
+
+	t.Run("by size", func(t *testing.T) {
+		s := new(memStorage)
+
+		// the second record does not fit the first part
+		records := [][]byte{
+			testPayload(t, maxSize/2),
+			testPayload(t, maxSize),
+			testPayload(t, 10),
+			testPayload(t, 10),
+			testPayload(t, 10),
+			testPayload(t, 10),
+		}
+
+		writeRecords(t, s, records)
+
+		require.Len(t, s.objects, 4)
+
+		payload := bytes.Join(records, nil)
+
+		// size limit is reached, counting starts over from the rest of the second
+		// record in the second part
+		require.Equal(t, payload[:maxSize], s.objects[0].Payload())
+		require.Equal(t, payload[maxSize:maxSize+maxSize/2+20], s.objects[1].Payload())
+		require.Equal(t, payload[maxSize+maxSize/2+20:], s.objects[2].Payload())
+	})
+
+	t.Run("last record boundary", func(t *testing.T) {
+		s := new(memStorage)
+
+		records := make([][]byte, recordsPerPart)
+		for i := range records {
+			records[i] = testPayload(t, 10)
+		}
+
+		writeRecords(t, s, records)
+
+		// no empty object is released after the last boundary
+		require.Len(t, s.objects, 1)
+		require.Equal(t, bytes.Join(records, nil), s.objects[0].Payload())
+	})
+}
+
+func TestPayloadSizeLimiter_ParallelHashing(t *testing.T) {
+	const maxSize = 1 << 20
+
+	var (
+		hdr     = testHeader()
+		payload = testPayload(t, 4*maxSize+maxSize/3)
+	)
+
+	write := func(opts ...Option) []*object.RawObject {
+		s := new(memStorage)
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), opts...)
+
+		require.NoError(t, target.WriteHeader(hdr))
+
+		// odd chunk size to cross the object boundaries within the chunk
+		for data := payload; len(data) > 0; {
+			n := 100 * 1024
+			if n > len(data) {
+				n = len(data)
+			}
+
+			_, err := target.Write(data[:n])
+			require.NoError(t, err)
+
+			data = data[n:]
+		}
+
+		_, err := target.Close()
+		require.NoError(t, err)
+
+		return s.objects
+	}
+
+	serial := write()
+
+	for _, workers := range []int{1, 2, 8} {
+		parallel := write(WithParallelHashing(workers))
+
+		require.Len(t, parallel, len(serial))
+
+		for i := range serial {
+			require.Equal(t, serial[i].PayloadChecksum(), parallel[i].PayloadChecksum())
+			require.Equal(t, serial[i].PayloadHomomorphicHash(), parallel[i].PayloadHomomorphicHash())
+		}
+
+		serialPar := serial[len(serial)-1].Parent()
+		parallelPar := parallel[len(parallel)-1].Parent()
+
+		require.Equal(t, serialPar.PayloadChecksum(), parallelPar.PayloadChecksum())
+		require.Equal(t, serialPar.PayloadHomomorphicHash(), parallelPar.PayloadHomomorphicHash())
+	}
+
+	cs := sha256.Sum256(payload)
+	require.Equal(t, cs[:], serial[len(serial)-1].Parent().PayloadChecksum().Sum())
+}
+
+func BenchmarkPayloadSizeLimiter_ParallelHashing(b *testing.B) {
+	const (
+		maxSize   = 1 << 20
+		chunkSize = 64 * 1024
+	)
+
+	var (
+		hdr     = testHeader()
+		payload = testPayload(b, 4*maxSize)
+	)
+
+	bench := func(b *testing.B, opts ...Option) {
+		b.SetBytes(int64(len(payload)))
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			target := NewPayloadSizeLimiter(maxSize, new(memStorage).initializer(), opts...)
+
+			if err := target.WriteHeader(hdr); err != nil {
+				b.Fatal(err)
+			}
+
+			for data := payload; len(data) > 0; data = data[chunkSize:] {
+				if _, err := target.Write(data[:chunkSize]); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			if _, err := target.Close(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	b.Run("serial", func(b *testing.B) {
+		bench(b)
+	})
+
+	for _, workers := range []int{1, 2} {
+		b.Run("workers="+strconv.Itoa(workers), func(b *testing.B) {
+			bench(b, WithParallelHashing(workers))
+		})
+	}
+}
+
+func TestPredictSplit(t *testing.T) {
+	const maxSize = 16
+
+	for _, size := range []uint64{
+		0, 1, maxSize - 1, maxSize, maxSize + 1,
+		2*maxSize - 1, 2 * maxSize, 2*maxSize + 1, 10 * maxSize,
+	} {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer()), testHeader(), testPayload(t, int(size)))
+
+		children, linking := PredictSplit(size, maxSize)
+
+		require.Equal(t, len(ids.ChildIDs()), children, size)
+		require.Equal(t, ids.LinkID() != nil, linking, size)
+
+		if linking {
+			children++
+		}
+
+		require.Len(t, s.objects, children, size)
+	}
+}
+
+func TestPayloadSizeLimiter_EmptyPayload(t *testing.T) {
+	const maxSize = 64
+
+	s := new(memStorage)
+	target := NewPayloadSizeLimiter(maxSize, s.initializer())
+
+	require.NoError(t, target.WriteHeader(testHeader()))
+
+	ids, err := target.Close()
+	require.NoError(t, err)
+
+	require.Len(t, s.objects, 1)
+
+	obj := s.objects[0]
+
+	require.Equal(t, obj.ID(), ids.SelfID())
+	require.Nil(t, ids.ParentID())
+	require.Zero(t, obj.PayloadSize())
+
+	cs := sha256.Sum256(nil)
+	require.Equal(t, pkg.ChecksumSHA256, obj.PayloadChecksum().Type())
+	require.Equal(t, cs[:], obj.PayloadChecksum().Sum())
+
+	tzCs := tz.Sum(nil)
+	require.Equal(t, pkg.ChecksumTZ, obj.PayloadHomomorphicHash().Type())
+	require.Equal(t, tzCs[:], obj.PayloadHomomorphicHash().Sum())
+
+	t.Run("no header", func(t *testing.T) {
+		target := NewPayloadSizeLimiter(maxSize, s.initializer())
+
+		_, err := target.Write([]byte{1})
+		require.True(t, errors.Is(err, errHeaderNotWritten))
+
+		_, err = target.Close()
+		require.True(t, errors.Is(err, errHeaderNotWritten))
+	})
+}
+
+func TestPayloadSizeLimiter_ChildAttributes(t *testing.T) {
+	const maxSize = 64
+
+	var (
+		inherited = testAttribute("Inherited", "val")
+		filtered  = testAttribute("Filtered", "val")
+		sys       = testAttribute("__NEOFS__EXPIRATION_EPOCH", "100")
+	)
+
+	hdr := testHeader(inherited, filtered, sys)
+
+	t.Run("filter", func(t *testing.T) {
+		s := new(memStorage)
+
+		target := NewPayloadSizeLimiter(maxSize, s.initializer(), WithChildAttributes(func(a *objectSDK.Attribute) bool {
+			return a.Key() == inherited.Key()
+		}))
+
+		ids := writeObject(t, target, hdr, testPayload(t, 3*maxSize))
+
+		// 3 children and linking object
+		require.Len(t, s.objects, 4)
+
+		for _, child := range s.objects {
+			_, ok := attributeValue(child, inherited.Key())
+			require.True(t, ok)
+
+			_, ok = attributeValue(child, sys.Key())
+			require.True(t, ok)
+
+			_, ok = attributeValue(child, filtered.Key())
+			require.False(t, ok)
+		}
+
+		par := object.NewRawFrom(objectSDK.NewRawFrom(ids.Parent()))
+		require.ElementsMatch(t, []*objectSDK.Attribute{inherited, filtered, sys}, par.Attributes())
+	})
+
+	t.Run("default", func(t *testing.T) {
+		s := new(memStorage)
+
+		ids := writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer()), hdr, testPayload(t, 3*maxSize))
+
+		require.Len(t, s.objects, 4)
+
+		for _, child := range s.objects[:3] {
+			require.Empty(t, child.Attributes())
+		}
+
+		par := object.NewRawFrom(objectSDK.NewRawFrom(ids.Parent()))
+		require.Len(t, par.Attributes(), 3)
+	})
+}
+
+func TestPayloadSizeLimiter_SessionToken(t *testing.T) {
+	const maxSize = 64
+
+	check := func(t *testing.T, s *memStorage, tok *session.Token) {
+		expected, err := tok.Marshal()
+		require.NoError(t, err)
+
+		requireToken := func(actual *session.Token) {
+			require.NotNil(t, actual)
+
+			data, err := actual.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, expected, data)
+		}
+
+		// 3 children and linking object
+		require.Len(t, s.objects, 4)
+
+		for _, obj := range s.objects {
+			requireToken(obj.SessionToken())
+		}
+
+		par := s.objects[3].Parent()
+		require.NotNil(t, par)
+		requireToken(par.SessionToken())
+	}
+
+	t.Run("from header", func(t *testing.T) {
+		s := new(memStorage)
+		tok := sessiontest.Generate()
+
+		hdr := testHeader()
+		hdr.SetSessionToken(tok)
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer()), hdr, testPayload(t, 3*maxSize))
+
+		check(t, s, tok)
+	})
+
+	t.Run("option", func(t *testing.T) {
+		s := new(memStorage)
+		tok := sessiontest.Generate()
+
+		hdr := testHeader()
+		hdr.SetSessionToken(sessiontest.Generate())
+
+		writeObject(t, NewPayloadSizeLimiter(maxSize, s.initializer(), WithSessionToken(tok)), hdr, testPayload(t, 3*maxSize))
+
+		check(t, s, tok)
+	})
+}
+
+func TestPayloadSizeLimiter_ContentRouting(t *testing.T) {
+	const maxSize = 64
+
+	var buckets [2]memStorage
+
+	router := func(cs []byte) ObjectTarget {
+		return buckets[cs[0]%2].initializer()()
+	}
+
+	payload := testPayload(t, 5*maxSize+maxSize/2)
+
+	// initializer must not be used
+	unused := func() ObjectTarget {
+		t.Fatal("target initializer is called")
+		return nil
+	}
+
+	ids := writeObject(t, NewPayloadSizeLimiter(maxSize, unused, WithContentRouting(router), WithIndex()),
+		testHeader(), payload)
+
+	var routed []*object.RawObject
+
+	for i := range buckets {
+		for _, obj := range buckets[i].objects {
+			cs := sha256.Sum256(obj.Payload())
+
+			// bucket is selected by the actual payload
+			require.EqualValues(t, i, cs[0]%2)
+			require.Equal(t, cs[:], obj.PayloadChecksum().Sum())
+		}
+
+		routed = append(routed, buckets[i].objects...)
+	}
+
+	// parts, linking and index objects
+	require.Len(t, routed, 8)
+
+	var (
+		restored []byte
+		parts    = make(map[string]*object.RawObject, len(routed))
+	)
+
+	for _, obj := range routed {
+		parts[obj.ID().String()] = obj
+	}
+
+	for _, id := range ids.ChildIDs() {
+		part, ok := parts[id.String()]
+		require.True(t, ok)
+
+		restored = append(restored, part.Payload()...)
+	}
+
+	require.Equal(t, payload, restored)
+	require.Contains(t, parts, ids.LinkID().String())
+	require.Contains(t, parts, ids.IndexID().String())
+
+	t.Run("no target", func(t *testing.T) {
+		target := NewPayloadSizeLimiter(maxSize, unused, WithContentRouting(func([]byte) ObjectTarget {
+			return nil
+		}))
+
+		require.NoError(t, target.WriteHeader(testHeader()))
+
+		_, err := target.Write(testPayload(t, maxSize))
+		require.NoError(t, err)
+
+		_, err = target.Close()
+		require.True(t, errors.Is(err, errNoRoutedTarget))
+	})
+}
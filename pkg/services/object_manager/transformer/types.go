@@ -11,9 +11,15 @@ import (
 // that are returned after writing the object.
 // Consists of the ID of the stored object and the ID of the parent object.
 type AccessIdentifiers struct {
-	par, self *objectSDK.ID
+	par, self, index *objectSDK.ID
 
 	parHdr *objectSDK.Object
+
+	receipt []byte
+
+	children []*objectSDK.ID
+
+	link *objectSDK.ID
 }
 
 // ObjectTarget is an interface of the object writer.
@@ -111,3 +117,91 @@ func (a *AccessIdentifiers) WithParent(v *objectSDK.Object) *AccessIdentifiers {
 
 	return res
 }
+
+// IndexID returns identifier of the index object of the split-chain.
+func (a *AccessIdentifiers) IndexID() *objectSDK.ID {
+	if a != nil {
+		return a.index
+	}
+
+	return nil
+}
+
+// WithIndexID returns AccessIdentifiers with passed index object identifier.
+func (a *AccessIdentifiers) WithIndexID(v *objectSDK.ID) *AccessIdentifiers {
+	res := a
+	if res == nil {
+		res = new(AccessIdentifiers)
+	}
+
+	res.index = v
+
+	return res
+}
+
+// Receipt returns signed receipt of the written object (see WithReceiptSigner).
+func (a *AccessIdentifiers) Receipt() []byte {
+	if a != nil {
+		return a.receipt
+	}
+
+	return nil
+}
+
+// WithReceipt returns AccessIdentifiers with passed signed receipt.
+func (a *AccessIdentifiers) WithReceipt(v []byte) *AccessIdentifiers {
+	res := a
+	if res == nil {
+		res = new(AccessIdentifiers)
+	}
+
+	res.receipt = v
+
+	return res
+}
+
+// ChildIDs returns ordered list of identifiers of the payload-bearing
+// objects produced by the transformer. Single (unsplit) object is
+// reported as the only child.
+func (a *AccessIdentifiers) ChildIDs() []*objectSDK.ID {
+	if a != nil {
+		return a.children
+	}
+
+	return nil
+}
+
+// WithChildIDs returns AccessIdentifiers with passed child object identifiers.
+func (a *AccessIdentifiers) WithChildIDs(v []*objectSDK.ID) *AccessIdentifiers {
+	res := a
+	if res == nil {
+		res = new(AccessIdentifiers)
+	}
+
+	res.children = v
+
+	return res
+}
+
+// LinkID returns identifier of the linking object of the split-chain.
+//
+// Returns nil if object was not split.
+func (a *AccessIdentifiers) LinkID() *objectSDK.ID {
+	if a != nil {
+		return a.link
+	}
+
+	return nil
+}
+
+// WithLinkID returns AccessIdentifiers with passed linking object identifier.
+func (a *AccessIdentifiers) WithLinkID(v *objectSDK.ID) *AccessIdentifiers {
+	res := a
+	if res == nil {
+		res = new(AccessIdentifiers)
+	}
+
+	res.link = v
+
+	return res
+}
@@ -0,0 +1,28 @@
+package transformer
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/core/object"
+)
+
+// WAL is an interface of the write-ahead log of the released objects.
+type WAL interface {
+	// Append persists the header of the object which is going to be
+	// committed to the target. Header can be modified by the target
+	// after Append returns, so it must be persisted (or copied) in place.
+	//
+	// Header must not be modified.
+	Append(hdr *object.RawObject) error
+}
+
+// WithWAL returns option to append the header of each generated object to
+// the write-ahead log before the object is committed by the target Close.
+// Write fails if the header can not be appended, the object is not committed
+// then.
+//
+// Since the objects are committed one by one, after the crash WAL contains all
+// the committed objects of the split-chain and no more than one uncommitted.
+func WithWAL(wal WAL) Option {
+	return func(c *cfg) {
+		c.wal = wal
+	}
+}
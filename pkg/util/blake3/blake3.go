@@ -0,0 +1,265 @@
+// Package blake3 implements BLAKE3 hash function in the default hashing mode
+// with 256-bit output.
+//
+// Implementation follows the reference one and is not optimized, it is
+// provided for the rare cases when BLAKE3 digest is required by the
+// external systems.
+package blake3
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// Size is the size of BLAKE3 digest in bytes.
+const Size = 32
+
+const (
+	blockLen = 64
+	chunkLen = 1024
+
+	flagChunkStart = 1 << 0
+	flagChunkEnd   = 1 << 1
+	flagParent     = 1 << 2
+	flagRoot       = 1 << 3
+)
+
+var iv = [8]uint32{
+	0x6A09E667, 0xBB67AE85, 0x3C6EF372, 0xA54FF53A,
+	0x510E527F, 0x9B05688C, 0x1F83D9AB, 0x5BE0CD19,
+}
+
+var msgPermutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+type digest struct {
+	// chaining value and compressed blocks of the current chunk
+	cv               [8]uint32
+	chunkCounter     uint64
+	blocksCompressed int
+
+	// buffered block of the current chunk
+	block    [blockLen]byte
+	blockLen int
+
+	// chaining values of the completed subtrees
+	stack [][8]uint32
+}
+
+// output represents the state just prior to the last compression
+// of the tree node.
+type output struct {
+	cv       [8]uint32
+	block    [16]uint32
+	counter  uint64
+	blockLen uint32
+	flags    uint32
+}
+
+// New returns new hash.Hash computing BLAKE3 digest.
+func New() hash.Hash {
+	d := new(digest)
+	d.Reset()
+
+	return d
+}
+
+// Sum256 returns BLAKE3 digest of the data.
+func Sum256(data []byte) [Size]byte {
+	var res [Size]byte
+
+	d := New()
+	_, _ = d.Write(data)
+	d.Sum(res[:0])
+
+	return res
+}
+
+func (d *digest) Size() int { return Size }
+
+func (d *digest) BlockSize() int { return blockLen }
+
+func (d *digest) Reset() {
+	d.cv = iv
+	d.chunkCounter = 0
+	d.blocksCompressed = 0
+	d.blockLen = 0
+	d.stack = d.stack[:0]
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	ln := len(p)
+
+	for len(p) > 0 {
+		// chunk is finalized only when more input arrives, since the last
+		// chunk must be processed differently
+		if d.chunkSize() == chunkLen {
+			d.pushChunk(d.chunkOutput().chainingValue())
+		}
+
+		if d.blockLen == blockLen {
+			block := wordsOf(d.block[:])
+			out := compress(&d.cv, &block, d.chunkCounter, blockLen, d.startFlag())
+			copy(d.cv[:], out[:8])
+
+			d.blocksCompressed++
+			d.blockLen = 0
+		}
+
+		n := copy(d.block[d.blockLen:], p)
+
+		d.blockLen += n
+		p = p[n:]
+	}
+
+	return ln, nil
+}
+
+func (d *digest) Sum(b []byte) []byte {
+	out := d.chunkOutput()
+
+	for i := len(d.stack) - 1; i >= 0; i-- {
+		out = parentOutput(d.stack[i], out.chainingValue())
+	}
+
+	words := compress(&out.cv, &out.block, 0, out.blockLen, out.flags|flagRoot)
+
+	var res [Size]byte
+	for i := 0; i < Size/4; i++ {
+		binary.LittleEndian.PutUint32(res[4*i:], words[i])
+	}
+
+	return append(b, res[:]...)
+}
+
+func (d *digest) chunkSize() int {
+	return blockLen*d.blocksCompressed + d.blockLen
+}
+
+func (d *digest) startFlag() uint32 {
+	if d.blocksCompressed == 0 {
+		return flagChunkStart
+	}
+
+	return 0
+}
+
+func (d *digest) chunkOutput() output {
+	block := [blockLen]byte{}
+	copy(block[:], d.block[:d.blockLen])
+
+	return output{
+		cv:       d.cv,
+		block:    wordsOf(block[:]),
+		counter:  d.chunkCounter,
+		blockLen: uint32(d.blockLen),
+		flags:    d.startFlag() | flagChunkEnd,
+	}
+}
+
+// pushChunk merges the chaining value of the completed chunk
+// into the stack of the completed subtrees and starts the next chunk.
+func (d *digest) pushChunk(cv [8]uint32) {
+	d.chunkCounter++
+
+	// number of trailing zeros of the completed chunk count is the number
+	// of the subtrees that become complete with this chunk
+	for total := d.chunkCounter; total&1 == 0; total >>= 1 {
+		cv = parentOutput(d.stack[len(d.stack)-1], cv).chainingValue()
+		d.stack = d.stack[:len(d.stack)-1]
+	}
+
+	d.stack = append(d.stack, cv)
+
+	d.cv = iv
+	d.blocksCompressed = 0
+	d.blockLen = 0
+}
+
+func parentOutput(left, right [8]uint32) output {
+	var block [16]uint32
+
+	copy(block[:8], left[:])
+	copy(block[8:], right[:])
+
+	return output{
+		cv:       iv,
+		block:    block,
+		blockLen: blockLen,
+		flags:    flagParent,
+	}
+}
+
+func (o output) chainingValue() [8]uint32 {
+	var cv [8]uint32
+
+	words := compress(&o.cv, &o.block, o.counter, o.blockLen, o.flags)
+	copy(cv[:], words[:8])
+
+	return cv
+}
+
+func wordsOf(b []byte) [16]uint32 {
+	var w [16]uint32
+
+	for i := range w {
+		w[i] = binary.LittleEndian.Uint32(b[4*i:])
+	}
+
+	return w
+}
+
+func g(s *[16]uint32, a, b, c, d int, mx, my uint32) {
+	s[a] += s[b] + mx
+	s[d] = bits.RotateLeft32(s[d]^s[a], -16)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -12)
+	s[a] += s[b] + my
+	s[d] = bits.RotateLeft32(s[d]^s[a], -8)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -7)
+}
+
+func round(s *[16]uint32, m *[16]uint32) {
+	// columns
+	g(s, 0, 4, 8, 12, m[0], m[1])
+	g(s, 1, 5, 9, 13, m[2], m[3])
+	g(s, 2, 6, 10, 14, m[4], m[5])
+	g(s, 3, 7, 11, 15, m[6], m[7])
+	// diagonals
+	g(s, 0, 5, 10, 15, m[8], m[9])
+	g(s, 1, 6, 11, 12, m[10], m[11])
+	g(s, 2, 7, 8, 13, m[12], m[13])
+	g(s, 3, 4, 9, 14, m[14], m[15])
+}
+
+func compress(cv *[8]uint32, block *[16]uint32, counter uint64, blockLen, flags uint32) [16]uint32 {
+	s := [16]uint32{
+		cv[0], cv[1], cv[2], cv[3],
+		cv[4], cv[5], cv[6], cv[7],
+		iv[0], iv[1], iv[2], iv[3],
+		uint32(counter), uint32(counter >> 32), blockLen, flags,
+	}
+
+	m := *block
+
+	for r := 0; r < 7; r++ {
+		round(&s, &m)
+
+		if r < 6 {
+			var permuted [16]uint32
+			for i := range permuted {
+				permuted[i] = m[msgPermutation[i]]
+			}
+
+			m = permuted
+		}
+	}
+
+	for i := 0; i < 8; i++ {
+		s[i] ^= s[i+8]
+		s[i+8] ^= cv[i]
+	}
+
+	return s
+}